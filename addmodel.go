@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// models registered at runtime with `/addmodel` (kept only in memory, unless persisted)
+var addedModels struct {
+	sync.Mutex
+
+	models []model
+}
+
+// returns given config with models registered at runtime
+//
+// (the ones which are also in given config, eg. persisted and reloaded, are not appended again)
+func withAddedModels(conf config) config {
+	addedModels.Lock()
+	defer addedModels.Unlock()
+
+	models := conf.Models
+	for _, m := range addedModels.models {
+		if !slices.ContainsFunc(conf.Models, func(c model) bool { return c.String() == m.String() }) {
+			models = append(models, m)
+		}
+	}
+	if len(models) > len(conf.Models) {
+		conf.Models = append([]model{}, models...)
+	}
+
+	return conf
+}
+
+// drop models registered at runtime which are also in given (reloaded) config
+func dropReloadedModels(conf config) {
+	addedModels.Lock()
+	defer addedModels.Unlock()
+
+	addedModels.models = slices.DeleteFunc(addedModels.models, func(m model) bool {
+		return slices.ContainsFunc(conf.Models, func(c model) bool { return c.String() == m.String() })
+	})
+}
+
+// check files of given model, which will be registered at runtime, and return found problems
+//
+// (executables and other files should be in `models_dir`, and `env` is not allowed,
+// so that a leaked admin account cannot run arbitrary commands)
+func checkAddedModelFiles(conf config, m model) (problems []string) {
+	dir := conf.modelsDirPath()
+	for _, file := range []struct {
+		key  string
+		path *string
+	}{
+		{"llamafile_path", m.LlamafilePath},
+		{"image_generator_path", m.ImageGeneratorPath},
+		{"grammar_file", m.GrammarFile},
+		{"prompt_cache_path", m.PromptCachePath},
+	} {
+		if file.path == nil {
+			continue
+		}
+		if dir == "" {
+			problems = append(problems, fmt.Sprintf("`%s` is not allowed without `models_dir`", file.key))
+		} else if !isPathInDir(*file.path, dir) {
+			problems = append(problems, fmt.Sprintf("`%s` is not in `models_dir`: %s", file.key, *file.path))
+		}
+	}
+	if len(m.Env) > 0 {
+		problems = append(problems, "`env` is not allowed")
+	}
+
+	return problems
+}
+
+// check if given path is in given directory (after resolving symbolic links)
+func isPathInDir(path, dir string) bool {
+	resolve := func(p string) string {
+		if abs, err := filepath.Abs(p); err == nil {
+			p = abs
+		}
+		if resolved, err := filepath.EvalSymlinks(p); err == nil {
+			return resolved
+		} else if parent, err := filepath.EvalSymlinks(filepath.Dir(p)); err == nil { // (eg. a prompt cache file which is not created yet)
+			return filepath.Join(parent, filepath.Base(p))
+		}
+		return p
+	}
+
+	rel, err := filepath.Rel(resolve(dir), resolve(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// handle `/addmodel` command: validate and register a model from given JSON at runtime, and return a message about it
+//
+// (with a leading `persist` argument, the model is also appended to `models` in the config file)
+func addModelMessage(conf config, args string) string {
+	snippet, persist := strings.CutPrefix(args, toggleArgPersist)
+	snippet = strings.TrimSpace(snippet)
+	if snippet == "" {
+		return fmt.Sprintf(`Usage: %s [%s] {"ollama_model": "llama3:8b"}`, CommandAddModel, toggleArgPersist)
+	}
+
+	var m model
+	decoder := json.NewDecoder(strings.NewReader(snippet))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&m); err != nil {
+		return fmt.Sprintf("Malformed model: %s", escapeForHTML(withUnknownFieldPosition([]byte(snippet), err).Error()))
+	}
+
+	if m.Pool != nil {
+		return "Models with `pool` cannot be added at runtime."
+	}
+	if problems := checkAddedModelFiles(conf, m); len(problems) > 0 {
+		return fmt.Sprintf("<b>%s</b> cannot be added at runtime:\n- %s", escapeForHTML(m.String()), escapeForHTML(strings.Join(problems, "\n- ")))
+	}
+	if _, exists := modelByIndexOrName(conf, m.String()); exists {
+		return fmt.Sprintf("<b>%s</b> already exists.", escapeForHTML(m.String()))
+	}
+
+	// (same as models in the config file)
+	added := config{Models: []model{m}}
+	applyChatTemplates(&added)
+	fixExecutePermissions(added)
+	m = added.Models[0]
+
+	if problems := validateModel(m); len(problems) > 0 {
+		return fmt.Sprintf("<b>%s</b> is misconfigured:\n- %s", escapeForHTML(m.String()), escapeForHTML(strings.Join(problems, "\n- ")))
+	}
+
+	log.Printf(">>> adding model at runtime: %s", m)
+
+	addedModels.Lock()
+	addedModels.models = append(addedModels.models, m)
+	addedModels.Unlock()
+
+	if persist {
+		if err := persistAddedModel(conf, snippet); err != nil {
+			log.Printf("Error: failed to write model %s to the config file: %s", m, err)
+			return fmt.Sprintf("<b>%s</b> was added as model #%d, but failed to write it to the config file: %s", escapeForHTML(m.String()), len(conf.Models), escapeForHTML(err.Error()))
+		}
+		return fmt.Sprintf("<b>%s</b> was added as model #%d (and written to the config file).", escapeForHTML(m.String()), len(conf.Models))
+	}
+
+	return fmt.Sprintf("<b>%s</b> was added as model #%d (until restart).", escapeForHTML(m.String()), len(conf.Models))
+}
+
+// append a model of given JSON snippet to `models` in the config file
+func persistAddedModel(conf config, snippet string) error {
+	return updateConfigFile(conf, func(raw map[string]any) error {
+		var m map[string]any
+		decoder := json.NewDecoder(strings.NewReader(snippet))
+		decoder.UseNumber()
+		if err := decoder.Decode(&m); err != nil {
+			return err
+		}
+
+		models, _ := raw["models"].([]any)
+		raw["models"] = append(models, m)
+
+		return nil
+	})
+}
+
+// update the config file with given function
+//
+// NOTE: keys of the file are rewritten in sorted order, and encrypted files cannot be updated
+func updateConfigFile(conf config, fn func(raw map[string]any) error) error {
+	if conf.path == "" {
+		return fmt.Errorf("path of the config file is unknown")
+	}
+
+	bytes, err := os.ReadFile(conf.path)
+	if err != nil {
+		return err
+	}
+	if isEncrypted(bytes) {
+		return fmt.Errorf("encrypted config file cannot be updated")
+	}
+
+	var raw map[string]any
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber() // (for keeping numbers as they are)
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+
+	if err := fn(raw); err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(raw); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(conf.path)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file, and replace the config file with it
+	tmp, err := os.CreateTemp(filepath.Dir(conf.path), filepath.Base(conf.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(buf.String()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), conf.path)
+}
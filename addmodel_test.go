@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckAddedModelFiles(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "models")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	inside, outside := filepath.Join(dir, "model.llamafile"), filepath.Join(root, "evil")
+	for _, path := range []string{inside, outside} {
+		if err := os.WriteFile(path, nil, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	link := filepath.Join(dir, "link.llamafile")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Fatal(err)
+	}
+
+	escaped, cache, ollama := filepath.Join(dir, "..", "evil"), filepath.Join(dir, "cache.bin"), "llama3:8b"
+
+	conf := config{ModelsDir: dir}
+	for _, test := range []struct {
+		model model
+		ok    bool
+	}{
+		{model{LlamafilePath: &inside}, true},
+		{model{LlamafilePath: &outside}, false},
+		{model{LlamafilePath: &link}, false},
+		{model{LlamafilePath: &escaped}, false},
+		{model{ImageGeneratorPath: &outside}, false},
+		{model{LlamafilePath: &inside, PromptCachePath: &cache}, true},
+		{model{LlamafilePath: &inside, Env: map[string]string{"LD_PRELOAD": outside}}, false},
+		{model{OllamaModel: &ollama}, true},
+	} {
+		if problems := checkAddedModelFiles(conf, test.model); (len(problems) == 0) != test.ok {
+			t.Errorf("unexpected problems of model %+v: %v", test.model, problems)
+		}
+	}
+
+	if problems := checkAddedModelFiles(config{}, model{LlamafilePath: &inside}); len(problems) == 0 {
+		t.Errorf("files should not be allowed without `models_dir`")
+	}
+}
+
+func TestWithAddedModels(t *testing.T) {
+	added, persisted := "added", "persisted"
+
+	addedModels.Lock()
+	addedModels.models = []model{{Name: &added}, {Name: &persisted}}
+	addedModels.Unlock()
+	defer func() {
+		addedModels.Lock()
+		addedModels.models = nil
+		addedModels.Unlock()
+	}()
+
+	conf := config{Models: []model{{Name: &persisted}}}
+	if models := withAddedModels(conf).Models; len(models) != 2 {
+		t.Errorf("persisted model should not be appended again: %d model(s)", len(models))
+	}
+
+	dropReloadedModels(conf)
+	if models := withAddedModels(config{}).Models; len(models) != 1 || models[0].String() != "added" {
+		t.Errorf("reloaded model should be dropped: %v", models)
+	}
+}
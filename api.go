@@ -0,0 +1,266 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// default listen address of the REST API
+const DefaultAPIListenAddress = "127.0.0.1:8081"
+
+// max size of REST API request bodies
+const MaxAPIRequestBytes = 1024 * 1024
+
+// config for the local REST API
+type apiConfig struct {
+	ListenAddress string `json:"listen_address,omitempty"` // (default: "127.0.0.1:8081")
+	APIKey        string `json:"api_key,omitempty"`        // if set, requests need it in `Authorization: Bearer` header
+}
+
+// body of `POST /v1/generate`
+type apiGenerateRequest struct {
+	Model  string `json:"model,omitempty"` // index or name of the model (default: the first enabled one)
+	Prompt string `json:"prompt"`
+
+	MaxTokens   *int     `json:"max_tokens,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	Seed        *int     `json:"seed,omitempty"`
+}
+
+// result of a request from the REST API
+type apiResult struct {
+	ID     uint64 `json:"id,omitempty"`
+	Model  string `json:"model,omitempty"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// regular expression for stripping HTML tags from replies
+var htmlTagRegexp = regexp.MustCompile(`<[^>]+>`)
+
+// start the local REST API in background, if configured
+//
+// requests are enqueued into the same queue as the ones from Telegram, and handled with the same backends
+func startAPIServer(conf config, bot *tg.Bot, reqQueue *priorityQueue) {
+	if conf.API == nil {
+		return
+	}
+
+	addr := apiListenAddress(conf)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/generate", func(w http.ResponseWriter, r *http.Request) {
+		if authorizedForAPI(conf, w, r) {
+			handleAPIGenerate(liveConfig(conf), bot, reqQueue, w, r)
+		}
+	})
+	mux.HandleFunc("/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		if authorizedForAPI(conf, w, r) {
+			handleAPIStatus(w)
+		}
+	})
+	mux.HandleFunc("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if authorizedForAPI(conf, w, r) {
+			handleAPIModels(liveConfig(conf), w)
+		}
+	})
+
+	log.Printf(">>> starting REST API on %s", addr)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Error: REST API stopped: %s", err)
+		}
+	}()
+}
+
+// write given value as JSON
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// check the api key of given request, and write an error if it is not authorized
+func authorizedForAPI(conf config, w http.ResponseWriter, r *http.Request) bool {
+	if conf.API.APIKey == "" {
+		return true
+	}
+
+	key, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(key), []byte(conf.API.APIKey)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, apiResult{Error: "invalid api key"})
+		return false
+	}
+	return true
+}
+
+// returns the listen address of the REST API in given config
+func apiListenAddress(conf config) string {
+	if conf.API == nil || conf.API.ListenAddress == "" {
+		return DefaultAPIListenAddress
+	}
+	return conf.API.ListenAddress
+}
+
+// state of the queue, for `GET /v1/status` (and `Status` of the gRPC service)
+type apiStatus struct {
+	UptimeSeconds int64 `json:"uptime_seconds"`
+	Queued        int   `json:"queued"`
+	InFlight      int   `json:"in_flight"`
+	Overflows     int   `json:"overflows"`
+}
+
+// an enabled model, for `GET /v1/models` (and `ListModels` of the gRPC service)
+type apiModelInfo struct {
+	Index       int    `json:"index"`
+	Name        string `json:"name"`
+	Generations int    `json:"generations"`
+	Failures    int    `json:"failures"`
+}
+
+// returns the current state of the queue
+func currentAPIStatus() apiStatus {
+	stats.Lock()
+	uptime := time.Since(stats.startedAt)
+	overflows := stats.overflows
+	stats.Unlock()
+
+	queued, inFlight := tracker.counts()
+
+	return apiStatus{
+		UptimeSeconds: int64(uptime.Seconds()),
+		Queued:        queued,
+		InFlight:      inFlight,
+		Overflows:     overflows,
+	}
+}
+
+// returns the enabled models (except image generators) with their indices
+func enabledAPIModels(conf config) []apiModelInfo {
+	models := []apiModelInfo{}
+	for i, model := range conf.Models {
+		if model.isDisabled() || model.isImageGenerator() {
+			continue
+		}
+
+		stats.Lock()
+		ms := *stats.model(model)
+		stats.Unlock()
+
+		models = append(models, apiModelInfo{
+			Index:       i,
+			Name:        model.String(),
+			Generations: ms.generations,
+			Failures:    ms.failures,
+		})
+	}
+	return models
+}
+
+// handle `GET /v1/status`
+func handleAPIStatus(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, currentAPIStatus())
+}
+
+// handle `GET /v1/models`
+func handleAPIModels(conf config, w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]any{"models": enabledAPIModels(conf)})
+}
+
+// handle `POST /v1/generate`
+func handleAPIGenerate(conf config, bot *tg.Bot, reqQueue *priorityQueue, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, apiResult{Error: "only POST is allowed"})
+		return
+	}
+
+	var body apiGenerateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxAPIRequestBytes)).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, apiResult{Error: "malformed request: " + err.Error()})
+		return
+	}
+
+	response := make(chan apiResult, 1)
+	if err := enqueueAPIRequest(conf, bot, reqQueue, body, requestExtra{response: response}); err != nil {
+		writeJSON(w, http.StatusBadRequest, apiResult{Error: err.Error()})
+		return
+	}
+
+	select {
+	case result := <-response:
+		status := http.StatusOK
+		if result.Error != "" {
+			status = http.StatusInternalServerError
+		}
+		writeJSON(w, status, result)
+	case <-r.Context().Done():
+		log.Printf(">>> client of REST API went away before the result")
+	}
+}
+
+// validate given request from the API, and enqueue it with given extra (for the result)
+func enqueueAPIRequest(conf config, bot *tg.Bot, reqQueue *priorityQueue, body apiGenerateRequest, extra requestExtra) error {
+	if strings.TrimSpace(body.Prompt) == "" {
+		return fmt.Errorf("`prompt` is empty")
+	}
+
+	model, found := apiModel(conf, body.Model)
+	if !found {
+		return fmt.Errorf("no such model: %s", body.Model)
+	}
+
+	// (clamped within `directive_bounds`, as directives)
+	maxTemperature, maxTokens := directiveLimits(conf)
+	options := generationOptions{seed: body.Seed}
+	if body.MaxTokens != nil {
+		tokens := min(max(*body.MaxTokens, 1), maxTokens)
+		options.maxTokens = &tokens
+	}
+	if body.Temperature != nil {
+		// (NaN would pass through `min` and `max`)
+		if math.IsNaN(*body.Temperature) || math.IsInf(*body.Temperature, 0) {
+			return fmt.Errorf("`temperature` should be a finite number")
+		}
+		temperature := min(max(*body.Temperature, 0), maxTemperature)
+		options.temperature = &temperature
+	}
+
+	prompt := escapeForShell(body.Prompt)
+	message := tg.Message{Date: int(time.Now().Unix())} // (not from any chat)
+
+	enqueueRequest(conf, bot, reqQueue, model, &prompt, nil, options, nil, &extra, message)
+
+	return nil
+}
+
+// returns the enabled model with given index or name (or the first enabled one, if empty)
+func apiModel(conf config, name string) (model, bool) {
+	m, found := modelByIndexOrName(conf, name)
+	if !found || m.isDisabled() || m.isImageGenerator() {
+		return model{}, false
+	}
+	return m, true
+}
+
+// send the result of given request from the REST API (the generated text, or the reply as an error)
+func respondToAPI(request request, reply string) {
+	result := apiResult{ID: request.id, Model: request.model.String()}
+	if request.extra.output != "" {
+		result.Output = request.extra.output
+	} else {
+		result.Error = html.UnescapeString(htmlTagRegexp.ReplaceAllString(reply, ""))
+	}
+
+	request.extra.response <- result
+}
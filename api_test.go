@@ -0,0 +1,20 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEnqueueAPIRequestWithInvalidTemperature(t *testing.T) {
+	path := "/models/test.llamafile"
+	conf := config{Models: []model{{LlamafilePath: &path}}}
+
+	for _, temperature := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		body := apiGenerateRequest{Prompt: "hello", Temperature: &temperature}
+
+		if err := enqueueAPIRequest(conf, nil, newPriorityQueue(1), body, requestExtra{}); err == nil || !strings.Contains(err.Error(), "temperature") {
+			t.Errorf("temperature %f should be rejected, got: %v", temperature, err)
+		}
+	}
+}
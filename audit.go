@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaults of the audit log
+const (
+	DefaultAuditLogMaxSizeMB = 10
+	DefaultAuditLogMaxFiles  = 5
+)
+
+// config for the audit log
+type auditLogConfig struct {
+	Path      string `json:"path"`                  // path of the audit log file (in JSONL format)
+	MaxSizeMB int    `json:"max_size_mb,omitempty"` // rotate the file when it exceeds this size (default: 10)
+	MaxFiles  int    `json:"max_files,omitempty"`   // number of rotated files to keep (default: 5)
+}
+
+// an entry of the audit log
+type auditEntry struct {
+	Time       time.Time `json:"time"`
+	RequestID  uint64    `json:"request_id"`
+	RequestRef string    `json:"request_ref,omitempty"`
+
+	ChatID    int64  `json:"chat_id"`
+	MessageID int64  `json:"message_id"`
+	UserID    int64  `json:"user_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+
+	Model string `json:"model"`
+	Route string `json:"route,omitempty"`
+
+	OriginalText *string `json:"original_text,omitempty"`
+	CommentText  *string `json:"comment_text,omitempty"`
+
+	EnqueuedAt     time.Time `json:"enqueued_at"`
+	DurationMillis int64     `json:"duration_ms"` // from enqueueing to finishing
+	ResultSize     int       `json:"result_size"` // number of characters in the result
+}
+
+var auditLogLock sync.Mutex
+
+// append an entry of given finished request to the audit log, if configured
+func writeAuditEntry(conf config, request request, result string) {
+	if conf.AuditLog == nil || conf.AuditLog.Path == "" {
+		return
+	}
+
+	entry := auditEntry{
+		Time:       time.Now(),
+		RequestID:  request.id,
+		RequestRef: request.ref,
+
+		ChatID:    request.targetChatID,
+		MessageID: request.targetMessageID,
+		UserID:    request.userID,
+
+		Model: request.model.String(),
+		Route: request.extra.route,
+
+		OriginalText: request.originalText,
+		CommentText:  request.commentText,
+
+		EnqueuedAt:     request.enqueuedAt,
+		DurationMillis: time.Since(request.enqueuedAt).Milliseconds(),
+		ResultSize:     len([]rune(result)),
+	}
+	if request.username != nil {
+		entry.Username = *request.username
+	}
+
+	if err := appendAuditEntry(*conf.AuditLog, entry); err != nil {
+		log.Printf("Error: failed to write audit log: %s", err)
+	}
+}
+
+// append given entry to the audit log file, rotating it if needed
+func appendAuditEntry(auditConf auditLogConfig, entry auditEntry) error {
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	auditLogLock.Lock()
+	defer auditLogLock.Unlock()
+
+	if err := rotateAuditLogIfNeeded(auditConf); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(auditConf.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(bytes, '\n'))
+	return err
+}
+
+// rotate the audit log file if it exceeds the max size
+// (`path` => `path.1` => `path.2` => ..., and the oldest one is removed)
+func rotateAuditLogIfNeeded(auditConf auditLogConfig) error {
+	maxSizeMB := auditConf.MaxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = DefaultAuditLogMaxSizeMB
+	}
+	maxFiles := auditConf.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = DefaultAuditLogMaxFiles
+	}
+
+	info, err := os.Stat(auditConf.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if info.Size() < int64(maxSizeMB)*1024*1024 {
+		return nil
+	}
+
+	_ = os.Remove(fmt.Sprintf("%s.%d", auditConf.Path, maxFiles))
+	for i := maxFiles - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", auditConf.Path, i), fmt.Sprintf("%s.%d", auditConf.Path, i+1))
+	}
+
+	log.Printf(">>> rotating audit log: %s", auditConf.Path)
+
+	return os.Rename(auditConf.Path, auditConf.Path+".1")
+}
@@ -1,49 +1,272 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
+	"unicode/utf8"
 
 	tg "github.com/meinside/telegram-bot-go"
+	"gopkg.in/yaml.v3"
 )
 
 const (
 	PollingIntervalSeconds = 1
 
 	RequestQueueSize = 10
-	ProcessQueueSize = 1
+
+	InlineQueryDebounceMilliseconds = 1500
+	InlineQueryTimeoutSeconds       = 60 // (inline queries waited longer than this are not answered)
+)
+
+// fields of generation info
+const (
+	GenerationInfoModel     = "model"
+	GenerationInfoElapsed   = "elapsed"
+	GenerationInfoQueueWait = "queue_wait"
+	GenerationInfoSeed      = "seed"
+	GenerationInfoTokens    = "tokens"
+)
+
+// default fields of generation info
+var DefaultGenerationInfoFields = []string{GenerationInfoModel, GenerationInfoElapsed, GenerationInfoQueueWait, GenerationInfoSeed, GenerationInfoTokens}
+
+// policies for a full request queue
+const (
+	QueueOverflowReject     = "reject"      // reject the new request
+	QueueOverflowDropOldest = "drop_oldest" // drop the oldest request in the queue, and enqueue the new one
 )
 
 // struct for config.json
 type config struct {
-	TelegramBotToken         string   `json:"telegram_bot_token"`
-	AllowedTelegramUsernames []string `json:"allowed_telegram_usernames,omitempty"`
+	Schema             string `json:"$schema,omitempty"`              // (for editors, see the `schema` subcommand)
+	AllowUnknownFields bool   `json:"allow_unknown_fields,omitempty"` // ignore unknown fields in config, instead of refusing them
+
+	TelegramBotToken          string   `json:"telegram_bot_token,omitempty"`
+	TelegramBotTokenFile      string   `json:"telegram_bot_token_file,omitempty"`    // file which contains the bot token (instead of `telegram_bot_token`)
+	TelegramBotTokenCommand   []string `json:"telegram_bot_token_command,omitempty"` // command which prints the bot token, eg. `["pass", "show", "telegram/bot-token"]`
+	TelegramAPIBaseURL        string   `json:"telegram_api_base_url,omitempty"`      // base URL of a self-hosted Bot API server (eg. "http://127.0.0.1:8081"), instead of api.telegram.org
+	TelegramProxy             string   `json:"telegram_proxy,omitempty"`             // proxy for the Bot API (eg. "socks5://127.0.0.1:1080", default: `HTTPS_PROXY` or `ALL_PROXY`)
+	TelegramTimeoutSeconds    int      `json:"telegram_timeout_seconds,omitempty"`   // timeout of connecting to (and waiting for responses of) the Bot API (default: 10)
+	AllowedTelegramUsernames  []string `json:"allowed_telegram_usernames,omitempty"`
+	AdminTelegramUsernames    []string `json:"admin_telegram_usernames,omitempty"`
+	AdminTelegramUserIDs      []int64  `json:"admin_telegram_user_ids,omitempty"`     // admins matched by their ids (usernames can be changed and taken by others), required for `/addmodel`
+	PriorityTelegramUsernames []string `json:"priority_telegram_usernames,omitempty"` // requests of these users (and admins) jump ahead in the queue
+
+	Bots []botConfig `json:"bots,omitempty"` // additional bots, run in the same process (sharing the queue and backends of models)
+
+	Quota    *quotaConfig    `json:"quota,omitempty"`    // per-user budgets of generation time
+	Payments *paymentsConfig `json:"payments,omitempty"` // for purchasing credits of generation time with Telegram Stars
+
+	DBPath string `json:"db_path,omitempty"` // JSON file for persisting data (if omitted, data will be kept only in memory)
+
+	Models    []model `json:"models"`
+	ModelsDir string  `json:"models_dir,omitempty"` // directory of JSON files with a model (or an array of models) each, appended to `models` in order of their names
+
+	ChatModels map[int64][]string `json:"chat_models,omitempty"` // indices, names, or pools of models available in each chat (keyed by chat id, other chats can use all models)
+
+	Presets   []preset      `json:"presets,omitempty"`
+	Schedules []schedule    `json:"schedules,omitempty"` // prompts generated and posted to chats periodically
+	Digest    *digestConfig `json:"digest,omitempty"`    // daily digests of group chats which opted in with `/digest`
+
+	QueueOverflowPolicy string `json:"queue_overflow_policy,omitempty"` // "reject" (default) or "drop_oldest"
+	MaxQueueAgeSeconds  int    `json:"max_queue_age_seconds,omitempty"` // requests waiting longer than this will expire (0 = never)
+
+	RequestTimeoutSeconds int `json:"request_timeout_seconds,omitempty"` // handling a request longer than this will be canceled (default: 900)
+
+	DirectiveBounds directiveBounds `json:"directive_bounds,omitempty"`
+
+	RAG *ragConfig `json:"rag,omitempty"` // for answering questions with local documents (`/ask`)
+
+	SemanticCache *semanticCacheConfig `json:"semantic_cache,omitempty"` // for returning cached responses of similar prompts
+
+	Tools toolsConfig `json:"tools,omitempty"` // tools for models with `use_tools`
+
+	Hooks hooksConfig `json:"hooks,omitempty"` // commands for pre/post-processing prompts and outputs
+
+	TTS *ttsConfig `json:"tts,omitempty"` // for voice replies (`/voice`)
+
+	DocumentSummarization *documentSummarizationConfig `json:"document_summarization,omitempty"` // for summarizing uploaded documents
+	URLSummarization      urlSummarizationConfig       `json:"url_summarization,omitempty"`      // for summarizing web pages (`/url`)
+	YouTubeSummarization  youTubeSummarizationConfig   `json:"youtube_summarization,omitempty"`  // for summarizing transcripts of YouTube videos (`/yt`)
+
+	Router *routerConfig `json:"router,omitempty"` // for routing messages to models by their prompts
+
+	ContentFilter  *contentFilterConfig  `json:"content_filter,omitempty"`  // for blocking prompts and outputs with banned patterns or words
+	InjectionGuard *injectionGuardConfig `json:"injection_guard,omitempty"` // for guarding against prompt injections in replied-to texts
+
+	ErrorReportChatID          *int64 `json:"error_report_chat_id,omitempty"`          // chat for reporting errors to the operator (recovered panics immediately, others in batches)
+	ErrorReportIntervalSeconds int    `json:"error_report_interval_seconds,omitempty"` // interval of batched error reports (default: 60)
+
+	WarmupModels         bool `json:"warmup_models,omitempty"`          // run each model once on startup, for loading its weights into memory
+	DisableInvalidModels bool `json:"disable_invalid_models,omitempty"` // disable misconfigured models on startup, instead of refusing to start
+
+	Sandbox *sandboxConfig `json:"sandbox,omitempty"` // for running llamafiles in a read-only working directory
+
+	CombineModelOutputs bool `json:"combine_model_outputs,omitempty"` // send one combined reply with all models' outputs
+
+	ConversationTurns       int  `json:"conversation_turns,omitempty"`        // number of recent turns to remember for each chat and model (0 = disabled)
+	SummarizationModelIndex *int `json:"summarization_model_index,omitempty"` // model for summarizing older turns (if omitted, each model summarizes its own)
+
+	LogFile        *logFileConfig  `json:"log_file,omitempty"`         // for logging to a file with rotation
+	LogOutput      string          `json:"log_output,omitempty"`       // "stderr" (default), "syslog", or "journald"
+	LogUserContent bool            `json:"log_user_content,omitempty"` // log texts of messages and prompts as they are (if false, only their lengths and hashes are logged)
+	AuditLog       *auditLogConfig `json:"audit_log,omitempty"`        // append-only audit log of all requests, independent of normal logs
+	ShowRequestID  bool            `json:"show_request_id,omitempty"`  // append the id of each request to its reply, for finding it in logs
 
-	Models []model `json:"models"`
+	Dashboard *dashboardConfig `json:"dashboard,omitempty"` // for the web admin dashboard
+	Pprof     *pprofConfig     `json:"pprof,omitempty"`     // for profiling with `go tool pprof` (on a loopback address only)
+	Tracing   *tracingConfig   `json:"tracing,omitempty"`   // for exporting traces of requests to an OpenTelemetry collector
+	Health    *healthConfig    `json:"health,omitempty"`    // for `/healthz` and `/readyz` endpoints
+	API       *apiConfig       `json:"api,omitempty"`       // for the local REST API
+	GRPC      *grpcConfig      `json:"grpc,omitempty"`      // for the gRPC service
+
+	DedupWindowSeconds int `json:"dedup_window_seconds,omitempty"` // identical messages from the same user within this window will be merged
+
+	RegenerationReaction *string `json:"regeneration_reaction,omitempty"` // reacting to a reply with this emoji (eg. "🔄") regenerates it with a new seed
+	RegenerationMode     string  `json:"regeneration_mode,omitempty"`     // "edit" (default) the reply, or post a "new" one
+
+	DebounceWindowSeconds int    `json:"debounce_window_seconds,omitempty"` // messages from the same user within this window will be handled as one
+	DebouncePolicy        string `json:"debounce_policy,omitempty"`         // "latest" (default) or "coalesce"
+	MergeWindowSeconds    int    `json:"merge_window_seconds,omitempty"`    // window of merge mode (`/merge`, default: 60)
+
+	SendRetryPolicy          retryPolicy `json:"send_retry_policy,omitempty"`
+	SendIntervalMilliseconds int         `json:"send_interval_milliseconds,omitempty"` // min interval between outgoing messages
+
+	botName string // name of the additional bot (empty for the main one)
+	path    string // path of the config file
 }
 
 // model struct in config
 type model struct {
-	LlamafilePath              *string  `json:"llamafile_path,omitempty"`
-	LlamafilePromptPattern     *string  `json:"llamafile_prompt_pattern,omitempty"`
-	LlamafilePromptPlaceholder *string  `json:"llamafile_prompt_placeholder,omitempty"`
-	LlamafileOtherParameters   []string `json:"llamafile_other_parameters,omitempty"`
+	Name    *string  `json:"name,omitempty"`    // human-friendly name, shown in replies and logs (eg. "Mistral 7B Q4")
+	Aliases []string `json:"aliases,omitempty"` // other names for referencing this model in commands (eg. "mistral")
+
+	LlamafilePath                *string  `json:"llamafile_path,omitempty"`
+	LlamafilePromptPattern       *string  `json:"llamafile_prompt_pattern,omitempty"` // text/template pattern if all placeholders are omitted
+	LlamafilePromptPlaceholder   *string  `json:"llamafile_prompt_placeholder,omitempty"`
+	LlamafileOriginalPlaceholder *string  `json:"llamafile_original_placeholder,omitempty"` // placeholder only for the original text
+	LlamafileCommentPlaceholder  *string  `json:"llamafile_comment_placeholder,omitempty"`  // placeholder only for the comment text
+	LlamafileOtherParameters     []string `json:"llamafile_other_parameters,omitempty"`
+
+	// default seed for generations (if omitted, a random seed will be used for each request)
+	Seed *int `json:"seed,omitempty"`
+
+	// for constraining outputs with a GBNF grammar file (`--grammar-file`), or a JSON schema (`--json-schema`)
+	GrammarFile *string         `json:"grammar_file,omitempty"`
+	JSONSchema  json.RawMessage `json:"json_schema,omitempty"`
+
+	// max number of tokens to generate (`-n`)
+	MaxTokens *int `json:"max_tokens,omitempty"`
+
+	// file for caching the prompt state (`--prompt-cache`), and whether to cache generated outputs too (`--prompt-cache-all`)
+	PromptCachePath *string `json:"prompt_cache_path,omitempty"`
+	PromptCacheAll  bool    `json:"prompt_cache_all,omitempty"`
+
+	// if set, llamafile is kept running in server mode on this (local) port, instead of being run for each generation
+	LlamafileServerPort *int `json:"llamafile_server_port,omitempty"`
+
+	// generation stops at (and outputs are trimmed at) any of these sequences
+	StopSequences []string `json:"stop_sequences,omitempty"`
+
+	// use this (fast) model for answering inline queries
+	UseForInlineQuery bool `json:"use_for_inline_query,omitempty"`
+
+	// answer with tools in `tools` config (tool calls are constrained with a grammar)
+	UseTools bool `json:"use_tools,omitempty"`
+
+	// whether to show generation info below each reply (default: true), and which fields to show in it
+	// ("model", "elapsed", "queue_wait", "seed", and "tokens"; default: all of them)
+	ShowGenerationInfo   *bool    `json:"show_generation_info,omitempty"`
+	GenerationInfoFields []string `json:"generation_info_fields,omitempty"`
+
+	// for OpenAI-compatible servers (eg. llama.cpp server, vLLM, OpenRouter), instead of llamafiles
+	APIBaseURL *string `json:"api_base_url,omitempty"` // eg. "http://localhost:8080/v1"
+	APIKey     *string `json:"api_key,omitempty"`
+	ModelName  *string `json:"model_name,omitempty"`
+
+	// for models of a local ollama daemon, instead of llamafiles
+	OllamaModel   *string `json:"ollama_model,omitempty"`    // eg. "llama3:8b"
+	OllamaBaseURL *string `json:"ollama_base_url,omitempty"` // (default: "http://localhost:11434")
+
+	// for image generators (eg. stable-diffusion.cpp), instead of llamafiles:
+	// `%p` and `%o` in the parameters are replaced with the prompt and the path of the output PNG file
+	ImageGeneratorPath       *string  `json:"image_generator_path,omitempty"`
+	ImageGeneratorParameters []string `json:"image_generator_parameters,omitempty"`
+
+	// models with the same pool name are treated as one model, and each request goes to the least busy one of them
+	Pool *string `json:"pool,omitempty"`
+
+	GPU *gpuOffload `json:"gpu,omitempty"` // GPU offload of the llamafile (`-ngl`, `--gpu`, ...)
+
+	Threads   *int `json:"threads,omitempty"`    // number of threads (`-t`), default: half of the available CPUs
+	BatchSize *int `json:"batch_size,omitempty"` // batch size for prompt processing (`-b`), default: 512
+
+	SkipPreflightCheck bool              `json:"skip_preflight_check,omitempty"` // launch the llamafile without checking available memory (and VRAM)
+	ResourceLimits     *resourceLimits   `json:"resource_limits,omitempty"`      // limits of the llamafile process, so a runaway generation cannot exhaust the machine
+	Scheduling         *scheduling       `json:"scheduling,omitempty"`           // niceness, I/O priority, and CPU affinity of the llamafile process
+	Watchdog           *watchdogConfig   `json:"watchdog,omitempty"`             // for killing (and retrying once) a stuck generation
+	Env                map[string]string `json:"env,omitempty"`                  // environment variables of the llamafile (or image generator) process (eg. `CUDA_VISIBLE_DEVICES`)
+
+	AllowedUsernames []string `json:"allowed_usernames,omitempty"` // users who can use this model, besides admins (default: everyone who can use the bot)
 
 	Disabled bool `json:"disabled,omitempty"`
 }
 
+// check if it is an image generator
+func (m model) isImageGenerator() bool {
+	return m.ImageGeneratorPath != nil
+}
+
+// check if it is a llamafile model
+func (m model) isLlamafile() bool {
+	return m.LlamafilePath != nil && m.LlamafilePromptPattern != nil
+}
+
+// check if this model has given name (or alias)
+func (m model) isNamed(name string) bool {
+	return name == m.String() || slices.Contains(m.Aliases, name)
+}
+
+// check if given user can use this model
+//
+// NOTE: if `allowed_usernames` is empty, everyone who can use the bot can use it
+func (m model) allowsUser(conf config, user *tg.User) bool {
+	if len(m.AllowedUsernames) == 0 || isAdmin(conf, user) {
+		return true
+	}
+	return user != nil && user.Username != nil && slices.Contains(m.AllowedUsernames, *user.Username)
+}
+
 // for debug-printing models
 func (m model) String() string {
 	var str string
 
-	if m.LlamafilePath != nil && m.LlamafilePromptPattern != nil && m.LlamafilePromptPlaceholder != nil { // or Llamafile,
+	if m.Name != nil {
+		str = *m.Name
+	} else if m.isLlamafile() {
 		str = fmt.Sprintf("Llamafile (%s)", filepath.Base(*m.LlamafilePath))
+	} else if m.isOpenAICompatible() {
+		str = fmt.Sprintf("OpenAI-compatible (%s @ %s)", *m.ModelName, m.apiHost())
+	} else if m.isOllama() {
+		str = fmt.Sprintf("Ollama (%s)", *m.OllamaModel)
+	} else if m.isImageGenerator() {
+		str = fmt.Sprintf("Image generator (%s)", filepath.Base(*m.ImageGeneratorPath))
 	} else {
 		str = "misconfigured model"
 	}
@@ -53,257 +276,1534 @@ func (m model) String() string {
 
 // request struct
 type request struct {
+	id  uint64
+	ref string // short random id, for correlating replies with logs (unique across restarts, unlike `id`)
+
 	model model
 
 	originalText *string
 	commentText  *string
 
+	userID    int64
+	username  *string
+	chatTitle *string
+	date      time.Time
+
+	options generationOptions
+
+	history []conversationTurn // recent conversation turns of the chat with the model
+	extra   requestExtra
+
+	group      *requestGroup // for combining outputs (nil if not combined)
+	groupIndex int
+
 	targetChatID    int64
 	targetMessageID int64
 
+	bot      *tg.Bot // bot which received the request (nil = the main one)
+	botName  string  // (empty for the main bot)
+	updateID int64   // id of the update which enqueued this request (0 if none), for resuming polling from unanswered updates
+
+	trace *requestTrace // (nil if tracing is disabled)
+
+	priority  bool // (priority requests jump ahead of normal ones in the queue)
+	admin     bool // (requests of admins are exempt from quotas)
+	delivered bool // (its result was already sent in other forms, eg. as an image or a voice, so finishing it only records it)
+
+	enqueuedAt          time.Time
 	startedProcessingAt time.Time
 }
 
+// extra inputs of a request
+type requestExtra struct {
+	sources        []string     // sources of retrieved documents, cited in the reply
+	question       string       // question for `/ask` (chunks of documents are retrieved for it when the request is handled)
+	indexDocuments bool         // for indexing documents (not generating a reply)
+	documentChunks []string     // chunks of a document to be summarized (with map-reduce)
+	chunkPrompt    string       // prompt for summarizing each chunk (if empty, `documentChunkSummaryPrompt` will be used)
+	route          string       // name of the route taken by the router (empty if not routed)
+	routeTo        func(*route) // for classifying a message for the router: called with the classified route (nil = default) for enqueueing the routed requests
+	schedule       string       // name of the schedule which triggered the request (empty if not scheduled)
+
+	replaceMessageID int64 // id of the previously sent reply to be replaced with the result (0 = send a new one)
+
+	inlineQueryID string // for inline queries: the generated text is answered to it, instead of being sent to a chat
+
+	response chan<- apiResult // for requests from the REST API (or the gRPC service): the result is sent to it, instead of Telegram
+	output   string           // generated text (for the REST API)
+
+	priority   bool    // for requests from the gRPC service with priority tokens: jump ahead in the queue
+	enqueuedID *uint64 // for requests from the gRPC service: set to the id of the request when it is enqueued (for streaming its queue position)
+}
+
 // read, parse, and return the parsed config from the given filepath (json format)
+//
+// NOTE: unknown fields (eg. typos like `llamafile_prompt_patern`) are rejected, unless `allow_unknown_fields` is true
 func readConfig(path string) (conf config, err error) {
 	var bytes []byte
-	if bytes, err = os.ReadFile(path); err == nil {
-		if err = json.Unmarshal(bytes, &conf); err == nil {
-			return conf, nil
+	if bytes, err = readMaybeEncryptedFile(path); err != nil { // (read and decrypted only once)
+		return config{}, err
+	}
+	if err = parseJSON(bytes, &conf, true); err != nil {
+		return config{}, err
+	}
+	if !conf.AllowUnknownFields {
+		if err = parseJSON(bytes, &config{}, false); err != nil {
+			return config{}, err
+		}
+	}
+
+	conf.path = path
+
+	if conf.ModelsDir != "" {
+		var models []model
+		if models, err = readModelsDir(conf.modelsDirPath(), conf.AllowUnknownFields); err != nil {
+			return config{}, err
 		}
+		conf.Models = append(conf.Models, models...)
 	}
 
-	return config{}, err
+	return conf, nil
 }
 
-// check if given update is allowed to handle
+// returns the path of `models_dir` (relative to the config file, if it is not absolute)
+func (c config) modelsDirPath() string {
+	if c.ModelsDir == "" || filepath.IsAbs(c.ModelsDir) {
+		return c.ModelsDir
+	}
+	return filepath.Join(filepath.Dir(c.path), c.ModelsDir)
+}
+
+// read models from JSON (or YAML) files in given directory, in order of their names
 //
-// NOTE: if `allowed_telegram_usernames` is empty, every update will be allowed
-func allowed(conf config, update tg.Update) bool {
-	if len(conf.AllowedTelegramUsernames) == 0 {
-		return true
+// each file has a model, or an array of models
+func readModelsDir(dir string, allowUnknownFields bool) (models []model, err error) {
+	var entries []os.DirEntry
+	if entries, err = os.ReadDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to read models_dir: %s", err)
 	}
 
-	if update.Message.From != nil && update.Message.From.Username != nil {
-		for _, username := range conf.AllowedTelegramUsernames {
-			if *update.Message.From.Username == username {
-				return true
+	for _, entry := range entries { // (already sorted by names)
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json":
+			var bytes []byte
+			if bytes, err = readMaybeEncryptedFile(path); err != nil {
+				return nil, err
+			}
+
+			if strings.HasPrefix(strings.TrimSpace(string(bytes)), "[") {
+				var ms []model
+				if err = parseJSON(bytes, &ms, allowUnknownFields); err != nil {
+					return nil, fmt.Errorf("%s: %s", path, err)
+				}
+				models = append(models, ms...)
+			} else {
+				var m model
+				if err = parseJSON(bytes, &m, allowUnknownFields); err != nil {
+					return nil, fmt.Errorf("%s: %s", path, err)
+				}
+				models = append(models, m)
+			}
+		case ".yaml", ".yml":
+			var bytes []byte
+			if bytes, err = readMaybeEncryptedFile(path); err != nil {
+				return nil, err
 			}
+
+			var ms []model
+			if ms, err = parseYAMLModels(bytes, allowUnknownFields); err != nil {
+				return nil, fmt.Errorf("%s: %s", path, err)
+			}
+			models = append(models, ms...)
 		}
 	}
 
-	return false
+	return models, nil
 }
 
-// escapes given text for using in shell (llamafile execution)
-func escapeForShell(text string) string {
-	return strings.ReplaceAll(text, "\"", "”")
+// parse given YAML with a model, or an array of models
+//
+// (it is converted to JSON, so models in YAML have the same fields as in JSON)
+func parseYAMLModels(bytes []byte, allowUnknownFields bool) (models []model, err error) {
+	var v any
+	if err = yaml.Unmarshal(bytes, &v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, fmt.Errorf("no model in the file")
+	}
+
+	var converted []byte
+	if converted, err = json.Marshal(v); err != nil {
+		return nil, fmt.Errorf("failed to convert YAML to JSON: %s", err)
+	}
+
+	decode := func(target any) error {
+		if err := json.Unmarshal(converted, target); err != nil {
+			return err
+		}
+		if allowUnknownFields {
+			return nil
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(string(converted)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(reflect.New(reflect.TypeOf(target).Elem()).Interface()); err != nil {
+			if field, found := strings.CutPrefix(err.Error(), "json: unknown field "); found {
+				return fmt.Errorf("unknown field %s, set `allow_unknown_fields` to true for ignoring unknown fields", field)
+			}
+			return err
+		}
+		return nil
+	}
+
+	if _, isArray := v.([]any); isArray {
+		err = decode(&models)
+	} else {
+		var m model
+		err = decode(&m)
+		models = []model{m}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return models, nil
 }
 
-// escapes given text for using in HTML parse mode ('<', '>', and '&')
-func escapeForHTML(text string) string {
-	return strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(text, "&", "&amp;"), ">", "&gt;"), "<", "&lt;")
+// parse given JSON into `v` (unknown fields are refused unless `allowUnknownFields` is true)
+//
+// errors are reported with their lines
+func parseJSON(bytes []byte, v any, allowUnknownFields bool) (err error) {
+	if err = json.Unmarshal(bytes, v); err != nil {
+		return withJSONErrorPosition(bytes, err)
+	}
+	if allowUnknownFields {
+		return nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(reflect.New(reflect.TypeOf(v).Elem()).Interface()); err != nil {
+		return withUnknownFieldPosition(bytes, err)
+	}
+
+	return nil
 }
 
-func runBot(conf config) {
-	bot := tg.NewClient(conf.TelegramBotToken)
+// add the line(s) of the unknown field in given JSON error to it
+func withUnknownFieldPosition(bytes []byte, err error) error {
+	field, found := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !found {
+		return err
+	}
 
-	if me := bot.GetMe(); me.Ok {
-		requestQueue := make(chan request, RequestQueueSize)
-		processQueue := make(chan request, ProcessQueueSize)
+	lines := []string{}
+	re := regexp.MustCompile(regexp.QuoteMeta(field) + `\s*:`)
+	for _, loc := range re.FindAllIndex(bytes, -1) {
+		lines = append(lines, strconv.Itoa(1+strings.Count(string(bytes[:loc[0]]), "\n")))
+	}
+	if len(lines) == 0 {
+		return err
+	}
 
-		// handle enqueued request queue
-		go func() {
-			for request := range requestQueue {
-				processQueue <- request
-			}
-		}()
+	return fmt.Errorf("unknown field %s (at line %s), set `allow_unknown_fields` to true for ignoring unknown fields", field, strings.Join(lines, ", "))
+}
 
-		// process requests
-		go func() {
-			for request := range processQueue {
-				handleRequest(conf, bot, request)
-			}
-		}()
+// add the line and column of given JSON error (if it has an offset) to it
+func withJSONErrorPosition(bytes []byte, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	} else if errors.As(err, &typeErr) {
+		offset = typeErr.Offset
+	} else {
+		return err
+	}
 
-		// delete webhook before polling updates
-		_ = bot.DeleteWebhook(true)
+	offset = min(offset, int64(len(bytes)))
+	line := 1 + strings.Count(string(bytes[:offset]), "\n")
+	column := int(offset) - strings.LastIndex(string(bytes[:offset]), "\n")
 
-		// poll updates and handle them
-		bot.StartPollingUpdates(0, PollingIntervalSeconds, func(c *tg.Bot, update tg.Update, err error) {
-			// skip it if it has no message or text content
-			if !update.HasMessage() || !update.Message.HasText() {
-				return
-			}
+	return fmt.Errorf("%w (at line %d, column %d)", err, line, column)
+}
 
-			// skip it if it is from a non-allowed user
-			if !allowed(conf, update) {
-				return
-			}
+// returns the types of updates to poll
+//
+// (reactions and pre-checkout queries are not polled by default, so they need to be specified explicitly)
+func allowedUpdates(conf config) []tg.AllowedUpdate {
+	updates := []tg.AllowedUpdate{
+		tg.AllowMessage,
+		tg.AllowEditedMessage,
+		tg.AllowInlineQuery,
+		tg.AllowCallbackQuery,
+	}
+	if conf.RegenerationReaction != nil {
+		updates = append(updates, tg.AllowMessageReaction)
+	}
+	if paymentsEnabled(conf) {
+		updates = append(updates, tg.AllowPreCheckoutQuery)
+	}
 
-			// skip if it is an ignorable message or command
-			if *update.Message.Text == "/start" {
-				return
-			}
+	return updates
+}
+
+// check if given update is allowed to handle
+//
+// NOTE: if `allowed_telegram_usernames` is empty, every update will be allowed
+func allowed(conf config, update tg.Update) bool {
+	if len(conf.AllowedTelegramUsernames) == 0 {
+		return true
+	}
+
+	from := update.GetFrom()
+	if update.MessageReaction != nil { // (not handled by `GetFrom`)
+		from = update.MessageReaction.User
+	}
 
-			// add a reaction for confirming the retrieval of an update
-			if reacted := c.SetMessageReaction(update.Message.Chat.ID, update.Message.MessageID, tg.NewMessageReactionWithEmoji("👌")); !reacted.Ok {
-				log.Printf("Error: failed to react to message: %s", *reacted.Description)
+	if from != nil && from.Username != nil {
+		for _, username := range conf.AllowedTelegramUsernames {
+			if *from.Username == username {
+				return true
 			}
+		}
+	}
 
-			// handle comment request
-			if update.Message.HasReplyTo() && update.Message.ReplyToMessage.HasText() { // it has a parent message (is a comment)
-				// get texts from the message, and cleanse them
-				originalText := escapeForShell(*update.Message.ReplyToMessage.Text)
-				commentText := escapeForShell(*update.Message.Text)
+	return false
+}
 
-				// and enquene requests
-				for _, model := range conf.Models {
-					// skip disabled models
-					if model.Disabled {
-						continue
-					}
+// check if given user is an admin (in `admin_telegram_user_ids` or `admin_telegram_usernames`)
+func isAdmin(conf config, user *tg.User) bool {
+	if isAdminByID(conf, user) {
+		return true
+	}
+	if user == nil || user.Username == nil {
+		return false
+	}
 
-					enqueueRequest(requestQueue, model, &originalText, &commentText, update.Message.Chat.ID, update.Message.MessageID)
-				}
-			} else { // handle message request
-				// get texts from the message, and cleanse them
-				originalText := escapeForShell(*update.Message.Text)
-
-				// and enquene requests
-				for _, model := range conf.Models {
-					// skip disabled models
-					if model.Disabled {
-						continue
-					}
-
-					enqueueRequest(requestQueue, model, &originalText, nil, update.Message.Chat.ID, update.Message.MessageID)
-				}
-			}
-		})
-	} else {
-		log.Printf("Error: failed to get info about this bot: %s", *me.Description)
+	for _, username := range conf.AdminTelegramUsernames {
+		if *user.Username == username {
+			return true
+		}
 	}
+
+	return false
 }
 
-// enqueue request
-func enqueueRequest(reqQueue chan request, model model, originalText, commentText *string, chatID, messageID int64) {
-	go func(queue chan request) {
-		if originalText != nil && commentText != nil {
-			log.Printf(`>>> enqueueing request for model: %s
-- originalText: %s
-- commentText: %s`, model, *originalText, *commentText)
+// check if given user is an admin in `admin_telegram_user_ids`
+func isAdminByID(conf config, user *tg.User) bool {
+	return user != nil && slices.Contains(conf.AdminTelegramUserIDs, user.ID)
+}
 
-			request := request{
-				model: model,
+// check if given user's requests have priority in the queue (admins and `priority_telegram_usernames`)
+func hasPriority(conf config, user *tg.User) bool {
+	// (admins by id have priority even without usernames)
+	if isAdmin(conf, user) {
+		return true
+	}
+	if user == nil || user.Username == nil {
+		return false
+	}
 
-				originalText: originalText,
-				commentText:  commentText,
+	for _, username := range conf.PriorityTelegramUsernames {
+		if *user.Username == username {
+			return true
+		}
+	}
 
-				targetChatID:    chatID,
-				targetMessageID: messageID,
-			}
+	return false
+}
+
+// fill in prompt patterns of models which have no `llamafile_prompt_pattern`,
+// with the chat templates read from their GGUF metadata
+func applyChatTemplates(conf *config) {
+	for i, model := range conf.Models {
+		if model.Disabled || model.LlamafilePath == nil || model.LlamafilePromptPattern != nil {
+			continue
+		}
+
+		if name, pattern, err := promptPatternFromGGUF(*model.LlamafilePath); err == nil {
+			log.Printf(">>> applying %s chat template to model: %s", name, filepath.Base(*model.LlamafilePath))
+
+			conf.Models[i].LlamafilePromptPattern = &pattern
+			conf.Models[i].LlamafilePromptPlaceholder = nil
+			conf.Models[i].LlamafileOriginalPlaceholder = nil
+			conf.Models[i].LlamafileCommentPlaceholder = nil
+		} else {
+			log.Printf("Error: failed to read chat template of model %s: %s", filepath.Base(*model.LlamafilePath), err)
+		}
+	}
+}
 
-			queue <- request
-		} else if originalText != nil {
-			log.Printf(`>>> enqueueing request for model: %s
-- originalText: %s`, model, *originalText)
+// run each enabled model once with a trivial prompt, so that its weights get paged into memory
+func warmupModels(conf config) {
+	const warmupPrompt = "Hello"
 
-			request := request{
-				model: model,
+	for _, model := range conf.Models {
+		if model.Disabled || !model.isLlamafile() {
+			continue
+		}
 
-				originalText: originalText,
-				commentText:  nil,
+		log.Printf(">>> warming up model: %s", model)
 
-				targetChatID:    chatID,
-				targetMessageID: messageID,
+		// (llamafiles in server mode are just started)
+		if model.LlamafileServerPort != nil {
+			if _, err := startLlamafileServer(model); err != nil {
+				log.Printf("Error: failed to warm up model %s: %s", model, err)
 			}
+			continue
+		}
 
-			queue <- request
+		params := append(model.otherParameters(), "-n", "1")
+
+		startedAt := time.Now()
+		if _, _, err := generateFromLlamafile(context.Background(), model, warmupPrompt, params...); err == nil {
+			log.Printf(">>> warmed up model: %s (in %s)", model, time.Since(startedAt))
 		} else {
-			log.Printf(`>>> dropping request for mode: %s`, *model.LlamafilePath)
+			log.Printf("Error: failed to warm up model %s: %s", model, err)
 		}
-	}(reqQueue)
+	}
 }
 
-// handle request which was dequeued from the request queue
-func handleRequest(conf config, bot *tg.Bot, request request) {
-	request.startedProcessingAt = time.Now()
+// escapes given text for using in shell (llamafile execution)
+func escapeForShell(text string) string {
+	return strings.ReplaceAll(text, "\"", "”")
+}
 
-	log.Printf(">>> handling request: %+v", request)
+// escapes given text for using in HTML parse mode ('<', '>', and '&')
+func escapeForHTML(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(text, "&", "&amp;"), ">", "&gt;"), "<", "&lt;")
+}
 
-	if acted := bot.SendChatAction(request.targetChatID, tg.ChatActionTyping, tg.OptionsSendChatAction{}); !acted.Ok {
-		log.Printf("Error: failed to send action: %s", *acted.Description)
+func runBot(conf config) {
+	if err := openLogFile(conf); err != nil {
+		log.Printf("Error: refusing to start: %s", err)
+		return
+	}
+	if err := openLogOutput(conf); err != nil {
+		log.Printf("Error: refusing to start: %s", err)
+		return
 	}
 
-	var generated string
+	applyChatTemplates(&conf)
+	fixExecutePermissions(conf)
 
-	model := request.model
-	if model.LlamafilePath != nil && model.LlamafilePromptPattern != nil && model.LlamafilePromptPlaceholder != nil { // or Llamafile,
-		generated = handleLlamafileRequest(conf, request)
-	} else {
-		generated = fmt.Sprintf("Error: misconfiguration in your config (%s)", model)
+	if err := validateModels(&conf); err != nil {
+		log.Printf("Error: refusing to start: %s", err)
+		return
+	}
+	if err := validateTools(conf.Tools); err != nil {
+		log.Printf("Error: refusing to start: %s", err)
+		return
 	}
 
-	// send the result to telegram
-	options := tg.OptionsSendMessage{}.
-		SetReplyParameters(tg.ReplyParameters{MessageID: request.targetMessageID}).
-		SetParseMode(tg.ParseModeHTML)
-	if sent := bot.SendMessage(request.targetChatID, generated, options); !sent.Ok {
-		log.Printf("Error: failed to send message: %s", *sent.Description)
+	if err := compileContentFilter(conf); err != nil {
+		log.Printf("Error: refusing to start: %s", err)
+		return
 	}
-}
 
-func handleLlamafileRequest(conf config, request request) string {
-	model := request.model
+	if err := prepareSandbox(conf); err != nil {
+		log.Printf("Error: refusing to start: %s", err)
+		return
+	}
 
-	var prompt string
-	if request.originalText != nil && request.commentText != nil {
-		prompt = strings.ReplaceAll(*model.LlamafilePromptPattern, *model.LlamafilePromptPlaceholder, fmt.Sprintf("%s: %s", *request.commentText, *request.originalText))
-	} else if request.originalText != nil {
-		prompt = strings.ReplaceAll(*model.LlamafilePromptPattern, *model.LlamafilePromptPlaceholder, *request.originalText)
-	} else if request.commentText != nil {
-		prompt = strings.ReplaceAll(*model.LlamafilePromptPattern, *model.LlamafilePromptPlaceholder, *request.commentText)
+	if conf.DBPath != "" {
+		if err := openDatabase(conf.DBPath); err != nil {
+			log.Printf("Error: failed to open database: %s", err)
+			return
+		}
 	}
 
-	if generated, err := generateFromLlamafile(*model.LlamafilePath, prompt, model.LlamafileOtherParameters...); err == nil {
-		return `<pre><code>
-` + escapeForHTML(generated) + `
-</code></pre>
+	// (for reloading config with `/reload` or SIGHUP)
+	setCurrentConfig(conf)
+	reloadOnSIGHUP()
 
-` + additionalGenerationInfo(request, filepath.Base(*model.LlamafilePath))
-	} else {
-		return fmt.Sprintf(`Failed to generate from prompt '%s' and parameters: %+v: <em>%s</em>`, prompt, model.LlamafileOtherParameters, escapeForHTML(err.Error()))
+	token, err := telegramBotToken(conf)
+	if err != nil {
+		log.Printf("Error: failed to get the bot token: %s", err)
+		return
+	}
+	bot, err := newTelegramClient(conf, token)
+	if err != nil {
+		log.Printf("Error: refusing to start: %s", err)
+		return
 	}
-}
 
-// generate text with `llamafile`
-//
-// NOTE: tested only on macOS
-// FIXME: without `bash`, llamafile fails to run
-func generateFromLlamafile(llamafilePath, prompt string, params ...string) (string, error) {
-	ps := []string{llamafilePath, "-p", fmt.Sprintf("\"%s\"", prompt)}
-	ps = append(ps, params...)
-	ps = append(ps, "--silent-prompt")
+	if me := bot.GetMe(); me.Ok {
+		requestQueue := newPriorityQueue(RequestQueueSize)
 
-	//log.Printf(">>> running: $ bash %s", strings.Join(ps, " "))
+		// start workers of model pools
+		startModelPools(conf, bot)
 
-	cmd := exec.Command("bash", ps...)
-	if out, err := cmd.Output(); err == nil {
-		return strings.TrimSpace(string(out)), nil
-	} else {
-		return "", fmt.Errorf("Failed to run '%s' with params %+v: %s", llamafilePath, params, err)
-	}
-}
+		// send batched error reports
+		startErrorReports(conf, bot)
 
-// generate an additional info about the generation
-func additionalGenerationInfo(request request, model string) string {
-	elapsedSinceProcessing := time.Since(request.startedProcessingAt).Milliseconds()
+		// export traces of requests
+		startTracing(conf)
+
+		// start the web dashboard
+		startDashboard(conf)
 
-	return fmt.Sprintf(`<em>(request was processed by <strong>%s</strong> in %s seconds)</em>`,
-		model,
-		msecsToString(elapsedSinceProcessing),
-	)
+		// start the pprof endpoint
+		startPprof(conf)
+
+		// start the health endpoints
+		startHealthServer(conf, requestQueue)
+
+		// start the REST API
+		startAPIServer(conf, bot, requestQueue)
+
+		// start the gRPC service
+		startGRPCServer(conf, bot, requestQueue)
+
+		// run scheduled prompts, and post digests of group chats
+		startSchedules(conf, bot, requestQueue)
+		startDigests(conf, bot, requestQueue)
+
+		// index documents for `/ask`
+		if conf.RAG != nil {
+			enqueueIndexingDocuments(conf, requestQueue)
+		}
+
+		// process requests
+		go func() {
+			// probe GPU offload, and warm up models before processing any request
+			withRecovery(conf, bot, "probing GPU offload", func() { probeGPUOffloads(conf) })
+			if conf.WarmupModels {
+				withRecovery(conf, bot, "warming up models", func() { warmupModels(conf) })
+			}
+
+			for {
+				request, _ := requestQueue.pop()
+
+				if pool := poolOf(request.model); pool != nil {
+					pool.dispatch(liveConfig(conf), bot, request)
+				} else {
+					handleRequestSafely(liveConfig(conf), bot, request)
+				}
+			}
+		}()
+
+		// start additional bots
+		startAdditionalBots(conf, requestQueue)
+
+		// stop gracefully on signals, and notify systemd of readiness (with `Type=notify`)
+		stopOnSignals(bot)
+		notifySystemdReady(conf)
+
+		// poll updates and handle them (saving the ids of answered ones periodically)
+		startSavingUpdateIDs()
+		pollUpdates(conf, bot, requestQueue)
+		saveAnsweredUpdateIDs()
+
+		log.Printf(">>> stopped polling updates")
+	} else {
+		log.Printf("Error: failed to get info about this bot: %s", *me.Description)
+	}
+}
+
+// poll updates of given bot (resuming from the last handled one), and handle them
+//
+// NOTE: it blocks until polling stops
+func pollUpdates(conf config, bot *tg.Bot, reqQueue *priorityQueue) {
+	// delete webhook before polling updates
+	// (pending updates are dropped only when they cannot be resumed from the database)
+	_ = bot.DeleteWebhook(conf.DBPath == "")
+
+	// poll updates (resuming from the last handled one) and handle them
+	bot.StartPollingUpdates(nextUpdateOffset(conf.botName), PollingIntervalSeconds, func(c *tg.Bot, update tg.Update, err error) {
+		// (reloaded one, with models registered at runtime)
+		conf := liveConfig(conf)
+
+		// recover from a panic while handling this update, so that the bot keeps running
+		defer recoverPanic(conf, c, fmt.Sprintf("handling update #%d", update.UpdateID))
+
+		// mark this update as being handled: it is answered after its handler returns (even when it panicked,
+		// for not handling it again) and its requests are finished, and polling will resume from the lowest unanswered one
+		if err == nil {
+			var chatID, messageID int64
+			if message := updateMessage(update); message != nil {
+				chatID, messageID = message.Chat.ID, message.MessageID
+			}
+			beginUpdate(conf.botName, update.UpdateID, chatID, messageID)
+			defer endUpdate(conf.botName, update.UpdateID, chatID, messageID)
+
+			resetErrors("polling updates")
+		} else {
+			log.Printf("Error: failed to poll updates: %s", err)
+
+			countError(conf, c, "polling updates", err)
+			return
+		}
+
+		// handle inline query
+		if update.HasInlineQuery() {
+			if allowed(conf, update) {
+				handleInlineQuery(conf, c, reqQueue, *update.InlineQuery)
+			}
+			return
+		}
+
+		// handle callback query
+		if update.HasCallbackQuery() {
+			if allowed(conf, update) {
+				handleCallbackQuery(conf, c, *update.CallbackQuery)
+			}
+			return
+		}
+
+		// handle reaction
+		if update.MessageReaction != nil {
+			if allowed(conf, update) {
+				handleMessageReaction(conf, c, reqQueue, *update.MessageReaction)
+			}
+			return
+		}
+
+		// handle pre-checkout query and successful payment
+		if update.HasPreCheckoutQuery() {
+			handlePreCheckoutQuery(conf, c, update)
+			return
+		}
+		if update.HasMessage() && update.Message.SuccessfulPayment != nil {
+			handleSuccessfulPayment(conf, c, update)
+			return
+		}
+
+		// handle edited message
+		if update.HasEditedMessage() {
+			if allowed(conf, update) {
+				handleEditedMessage(conf, c, reqQueue, *update.EditedMessage)
+			}
+			return
+		}
+
+		// handle document
+		if update.HasMessage() && update.Message.HasDocument() {
+			if allowed(conf, update) {
+				handleDocument(conf, c, reqQueue, *update.Message)
+			}
+			return
+		}
+
+		// skip it if it has no message or text content
+		if !update.HasMessage() || !update.Message.HasText() {
+			return
+		}
+
+		// keep it for the digest of its group chat (even if it is from a non-allowed user)
+		bufferForDigest(conf, *update.Message)
+
+		// skip it if it is from a non-allowed user
+		if !allowed(conf, update) {
+			return
+		}
+
+		// handle built-in commands
+		if handleCommand(conf, c, reqQueue, *update.Message) {
+			return
+		}
+
+		// merge duplicated messages (eg. double-taps, or retries of telegram clients)
+		if isDuplicate(conf, *update.Message) {
+			log.Printf(">>> merging a duplicated message: %d", update.Message.MessageID)
+
+			sendReply(conf, c, *update.Message, "This message was merged into the identical one sent just before.")
+			return
+		}
+
+		// add a reaction for confirming the retrieval of an update
+		if reacted := c.SetMessageReaction(update.Message.Chat.ID, update.Message.MessageID, tg.NewMessageReactionWithEmoji("👌")); !reacted.Ok {
+			limiter.pauseIfNeeded(reacted.Parameters)
+
+			log.Printf("Error: failed to react to message: %s", *reacted.Description)
+		}
+
+		// handle preset command
+		if preset, args, isPreset := presetForCommand(conf, *update.Message.Text); isPreset {
+			handlePresetCommand(conf, c, reqQueue, preset, args, *update.Message)
+			return
+		}
+
+		// handle YouTube url
+		if conf.YouTubeSummarization.AutoDetect {
+			if videoID, found := youTubeVideoID(*update.Message.Text); found {
+				handleYouTubeURL(conf, c, reqQueue, videoID, *update.Message)
+				return
+			}
+		}
+
+		// get texts from the message, and cleanse them
+		text, options := parseDirectives(conf, *update.Message.Text)
+		var repliedTo *string
+		if update.Message.HasReplyTo() && update.Message.ReplyToMessage.HasText() { // it has a parent message (is a comment)
+			originalText := escapeForShell(*update.Message.ReplyToMessage.Text)
+			repliedTo = &originalText
+		}
+
+		// and enquene requests (after debouncing)
+		debounceRequests(conf, c, reqQueue, repliedTo, escapeForShell(text), options, *update.Message)
+	}, allowedUpdates(conf))
+}
+
+// returns enabled (text generation) models
+//
+// (models in the same pool are returned as one)
+func enabledModels(conf config) (models []model) {
+	pooled := map[string]bool{}
+	for _, model := range conf.Models {
+		// skip disabled models and image generators
+		if model.isDisabled() || model.isImageGenerator() {
+			continue
+		}
+
+		if model.Pool != nil {
+			if pooled[*model.Pool] {
+				continue
+			}
+			pooled[*model.Pool] = true
+		}
+
+		models = append(models, model)
+	}
+	return models
+}
+
+// returns the model in config with given index or name (or alias), or the first enabled one if empty
+func modelByIndexOrName(conf config, name string) (model, bool) {
+	if name == "" {
+		if models := enabledModels(conf); len(models) > 0 {
+			return models[0], true
+		}
+		return model{}, false
+	}
+
+	if index, err := strconv.Atoi(name); err == nil {
+		if index >= 0 && index < len(conf.Models) {
+			return conf.Models[index], true
+		}
+		return model{}, false
+	}
+
+	for _, m := range conf.Models {
+		if m.isNamed(name) {
+			return m, true
+		}
+	}
+	return model{}, false
+}
+
+// enqueue request
+//
+// NOTE: when the queue is full, it is handled with `queue_overflow_policy`
+func enqueueRequest(conf config, bot *tg.Bot, reqQueue *priorityQueue, model model, originalText, commentText *string, options generationOptions, group *requestGroup, extra *requestExtra, message tg.Message) {
+	if originalText == nil {
+		log.Printf(`>>> dropping request for model: %s`, model)
+		return
+	}
+
+	request := request{
+		model: model,
+
+		originalText: originalText,
+		commentText:  commentText,
+
+		options: options,
+
+		chatTitle: message.Chat.Title,
+		date:      time.Unix(int64(message.Date), 0),
+
+		targetChatID:    message.Chat.ID,
+		targetMessageID: message.MessageID,
+
+		bot:     bot,
+		botName: conf.botName,
+	}
+	if message.From != nil {
+		request.userID = message.From.ID
+		request.username = message.From.Username
+		request.priority = hasPriority(conf, message.From)
+		request.admin = isAdmin(conf, message.From)
+	}
+	if extra != nil {
+		request.extra = *extra
+		request.priority = request.priority || extra.priority
+	}
+	if group != nil {
+		request.group = group
+		request.groupIndex = group.register(model.String())
+	}
+
+	if message.MessageID != 0 {
+		request.updateID = updateIDOfMessage(conf.botName, message.Chat.ID, message.MessageID)
+	}
+
+	tracker.add(&request)
+	request.trace = startTrace(request)
+	if request.extra.enqueuedID != nil {
+		*request.extra.enqueuedID = request.id
+	}
+
+	if commentText != nil {
+		request.logger().Printf(`>>> enqueueing request %s for model: %s
+- originalText: %s
+- commentText: %s`, request, model, loggable(conf, *originalText), loggable(conf, *commentText))
+	} else {
+		request.logger().Printf(`>>> enqueueing request %s for model: %s
+- originalText: %s`, request, model, loggable(conf, *originalText))
+	}
+
+	// block prompts with the content filter
+	if filterBlocks(conf, request, FilterStagePrompt, userInput(request)) {
+		tracker.drop(request)
+		finishRequest(conf, bot, request, escapeForHTML(filterRefusalMessage(conf)))
+		return
+	}
+
+	// refuse requests for models which are not allowed to the user
+	// (requests from the REST API and schedules are already authorized, and routed requests are checked after classification)
+	if request.extra.response == nil && request.extra.schedule == "" && request.extra.routeTo == nil && !model.allowsUser(conf, message.From) {
+		request.logger().Printf(">>> refusing request %s for model: %s (not allowed to the user)", request, model)
+
+		tracker.drop(request)
+		finishRequest(conf, bot, request, fmt.Sprintf("You are not allowed to use <b>%s</b>.", escapeForHTML(model.label())))
+		return
+	}
+
+	// refuse requests for models which are not available in the chat (routed requests are checked after classification)
+	if request.extra.routeTo == nil && !model.availableInChat(conf, request.targetChatID) {
+		request.logger().Printf(">>> refusing request %s for model: %s (not available in chat %d)", request, model, request.targetChatID)
+
+		tracker.drop(request)
+		finishRequest(conf, bot, request, fmt.Sprintf("<b>%s</b> is not available in this chat.", escapeForHTML(model.label())))
+		return
+	}
+
+	// refuse requests of users who used up their quotas
+	if quotaExceeded(conf, request) {
+		tracker.drop(request)
+		finishRequest(conf, bot, request, escapeForHTML(quotaExceededMessage(conf)))
+		return
+	}
+
+	pushRequest(conf, bot, reqQueue, request)
+}
+
+// push given request to given queue (the request queue, or a queue of a pool member)
+//
+// NOTE: when the queue is full, it is handled with `queue_overflow_policy`
+func pushRequest(conf config, bot *tg.Bot, queue *priorityQueue, request request) {
+	if queue.push(request) {
+		return
+	}
+	stats.recordOverflow()
+	collectError("request queue overflowed")
+
+	if conf.QueueOverflowPolicy == QueueOverflowDropOldest {
+		if oldest, dropped := queue.dropOldest(); dropped {
+			oldest.logger().Printf(">>> queue is full, dropping the oldest request %s", oldest)
+
+			tracker.drop(oldest)
+			finishRequest(conf, bot, oldest, "The queue was full, so your request was dropped. Try again later.")
+		}
+
+		if queue.push(request) {
+			return
+		}
+	}
+
+	request.logger().Printf(">>> queue is full, rejecting request %s", request)
+
+	tracker.drop(request)
+	finishRequest(conf, bot, request, "The queue is full, try again later.")
+}
+
+// handle request which was dequeued from the request queue
+func handleRequest(conf config, bot *tg.Bot, request request) {
+	// (reply with the bot which received the request)
+	if request.bot != nil {
+		bot = request.bot
+	}
+
+	// skip expired request
+	if waited := time.Since(request.enqueuedAt); conf.MaxQueueAgeSeconds > 0 && waited > time.Duration(conf.MaxQueueAgeSeconds)*time.Second {
+		request.logger().Printf(">>> request %s expired after waiting %s", request, waited)
+
+		tracker.drop(request)
+		finishRequest(conf, bot, request, fmt.Sprintf("Your request expired after waiting %s seconds.", msecsToString(waited.Milliseconds())))
+		return
+	}
+	if inlineQueryExpired(request) {
+		request.logger().Printf(">>> inline query of request %s expired", request)
+
+		tracker.drop(request)
+		finishRequest(conf, bot, request, "The inline query expired.")
+		return
+	}
+
+	request.startedProcessingAt = time.Now()
+	request.trace.span("queue", request.enqueuedAt, request.startedProcessingAt, nil, nil)
+
+	tracker.start(request)
+	defer tracker.finish(request)
+
+	// (canceled with `/cancel`, or timed out)
+	ctx, cancel := requestContext(conf, request)
+	defer cancel()
+
+	if wasCanceled(request) {
+		request.logger().Printf(">>> request %s was canceled while queued", request)
+
+		finishRequest(conf, bot, request, "Your request was canceled.")
+		return
+	}
+
+	request.logger().Printf(">>> handling request %s for model: %s (chat: %d, message: %d)", request, request.model, request.targetChatID, request.targetMessageID)
+
+	if request.targetChatID != 0 && request.extra.response == nil && request.extra.inlineQueryID == "" {
+		if acted := bot.SendChatAction(request.targetChatID, tg.ChatActionTyping, tg.OptionsSendChatAction{}); !acted.Ok {
+			limiter.pauseIfNeeded(acted.Parameters)
+
+			log.Printf("Error: failed to send action: %s", *acted.Description)
+		}
+	}
+
+	// classify the message for the router (not generating a reply)
+	if request.extra.routeTo != nil {
+		classifyForRouting(ctx, conf, request)
+		return
+	}
+
+	// index documents for `/ask` (not generating a reply)
+	if request.extra.indexDocuments {
+		indexDocuments(ctx, conf)
+		return
+	}
+
+	var reply, generated string
+
+	model := request.model
+	if model.isImageGenerator() {
+		handleImageRequest(ctx, conf, bot, request)
+		return
+	} else if gen, err := generatorFor(model); err == nil {
+		reply, generated = handleGenerationRequest(ctx, conf, request, gen)
+	} else {
+		reply = fmt.Sprintf("Error: misconfiguration in your config (%s)", model)
+	}
+
+	if done, isDone := requestContextReply(ctx); isDone {
+		request.logger().Printf(">>> request %s was not finished: %s", request, ctx.Err())
+
+		reply, generated = done, ""
+	}
+
+	// send the synthesized voice (not for comparisons)
+	if generated != "" && !request.group.isComparison() && request.extra.inlineQueryID == "" && voiceEnabled(conf, request.targetChatID) {
+		if sent := sendVoiceReply(ctx, conf, bot, request, generated); sent && conf.TTS.VoiceOnly && request.group == nil {
+			// (the voice was sent, so it is only recorded)
+			request.delivered = true
+		}
+	}
+
+	// send the result to telegram (or the REST API)
+	request.extra.output = generated
+	finishRequest(conf, bot, request, reply)
+}
+
+// handle given request, and finish it with an error message if it panicked (so that the worker keeps running)
+func handleRequestSafely(conf config, bot *tg.Bot, request request) {
+	if panicked := withRecovery(conf, bot, fmt.Sprintf("handling request %s", request), func() {
+		handleRequest(conf, bot, request)
+	}); panicked {
+		withRecovery(conf, bot, fmt.Sprintf("finishing request %s", request), func() {
+			finishRequest(conf, bot, request, "Error: an unexpected error occurred while handling your request.")
+		})
+	}
+}
+
+// finish given request with a result text
+//
+// if the request is in a group, the result will be delivered after all results of the group are collected
+func finishRequest(conf config, bot *tg.Bot, request request, text string) {
+	if request.bot != nil {
+		bot = request.bot
+	}
+
+	// (delivery is traced as a span, and the trace ends after it)
+	sendStartedAt := time.Now()
+	defer func() {
+		request.trace.span("send", sendStartedAt, time.Now(), nil, nil)
+		request.trace.finish()
+	}()
+
+	writeAuditEntry(conf, request, text)
+	chargeQuota(conf, bot, request)
+
+	if request.delivered {
+		return
+	}
+
+	if request.extra.response != nil {
+		respondToAPI(request, text)
+		return
+	}
+	if request.extra.inlineQueryID != "" {
+		answerInlineQuery(conf, bot, request, text)
+		return
+	}
+
+	if conf.ShowRequestID {
+		text += fmt.Sprintf("\n\n<i>request: %s</i>", request.ref)
+	}
+
+	if request.group != nil {
+		if sections, labels, complete := request.group.add(request.groupIndex, text); complete {
+			if request.group.isComparison() {
+				deliverComparison(conf, bot, request, sections, labels)
+			} else {
+				// (sections are packed into as few messages as possible)
+				deliverMessages(conf, bot, request, packMessages(sections, MaxMessageLength), nil)
+			}
+		}
+		return
+	}
+
+	deliverResult(conf, bot, request, text)
+}
+
+// data for prompt patterns in text/template format
+type promptTemplateData struct {
+	Original  string
+	Comment   string
+	Username  string
+	Date      string
+	ChatTitle string
+	History   []conversationTurn
+}
+
+// build a prompt for llamafile from given request
+//
+// NOTE: if all placeholders are omitted, `llamafile_prompt_pattern` is treated as a text/template
+// (eg. "[INST]{{.Comment}}: {{.Original}}[/INST]")
+//
+// with placeholders, conversation history is prepended in the same pattern, each followed by its reply
+// (eg. "[INST]%p[/INST]" => "[INST]previous message[/INST]previous reply\n[INST]message[/INST]")
+func llamafilePrompt(request request) (string, error) {
+	model := request.model
+
+	if model.LlamafilePromptPlaceholder == nil && model.LlamafileOriginalPlaceholder == nil && model.LlamafileCommentPlaceholder == nil {
+		return llamafileTemplatePrompt(request)
+	}
+
+	var sb strings.Builder
+	for _, turn := range request.history {
+		user := turn.User
+		sb.WriteString(llamafilePlaceholderPrompt(model, &user, nil))
+		sb.WriteString(turn.Assistant + "\n")
+	}
+	sb.WriteString(llamafilePlaceholderPrompt(model, request.originalText, request.commentText))
+
+	return sb.String(), nil
+}
+
+// build a prompt for llamafile from given request, with `llamafile_prompt_pattern` as a text/template
+func llamafileTemplatePrompt(request request) (string, error) {
+	model := request.model
+
+	tpl, err := template.New("prompt").Parse(*model.LlamafilePromptPattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse prompt pattern: %s", err)
+	}
+
+	data := promptTemplateData{
+		Date:    request.date.Format("2006-01-02"),
+		History: request.history,
+	}
+	if request.originalText != nil {
+		data.Original = *request.originalText
+	}
+	if request.commentText != nil {
+		data.Comment = *request.commentText
+	}
+	if request.username != nil {
+		data.Username = *request.username
+	}
+	if request.chatTitle != nil {
+		data.ChatTitle = *request.chatTitle
+	}
+
+	var sb strings.Builder
+	if err := tpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to execute prompt pattern: %s", err)
+	}
+
+	return sb.String(), nil
+}
+
+// fill the placeholders of given model's `llamafile_prompt_pattern` with given texts
+func llamafilePlaceholderPrompt(model model, originalText, commentText *string) (prompt string) {
+	// separate placeholders for original and comment texts
+	if model.LlamafileOriginalPlaceholder != nil || model.LlamafileCommentPlaceholder != nil {
+		var original, comment string
+		if originalText != nil {
+			original = *originalText
+		}
+		if commentText != nil {
+			comment = *commentText
+		}
+
+		prompt = *model.LlamafilePromptPattern
+		if model.LlamafileOriginalPlaceholder != nil {
+			prompt = strings.ReplaceAll(prompt, *model.LlamafileOriginalPlaceholder, original)
+		}
+		if model.LlamafileCommentPlaceholder != nil {
+			prompt = strings.ReplaceAll(prompt, *model.LlamafileCommentPlaceholder, comment)
+		}
+
+		return prompt
+	}
+
+	if originalText != nil && commentText != nil {
+		prompt = strings.ReplaceAll(*model.LlamafilePromptPattern, *model.LlamafilePromptPlaceholder, fmt.Sprintf("%s: %s", *commentText, *originalText))
+	} else if originalText != nil {
+		prompt = strings.ReplaceAll(*model.LlamafilePromptPattern, *model.LlamafilePromptPlaceholder, *originalText)
+	} else if commentText != nil {
+		prompt = strings.ReplaceAll(*model.LlamafilePromptPattern, *model.LlamafilePromptPlaceholder, *commentText)
+	}
+
+	return prompt
+}
+
+// handle a text generation request with given generator, and return the reply (in HTML) with the generated text (empty on failure)
+func handleGenerationRequest(ctx context.Context, conf config, request request, gen Generator) (reply, generated string) {
+	model := request.model
+
+	if !request.group.isComparison() && len(request.extra.documentChunks) == 0 && request.extra.inlineQueryID == "" {
+		request.history = compressedConversationHistory(ctx, conf, request.targetChatID, model)
+	}
+
+	// summarize each chunk of a document first
+	if len(request.extra.documentChunks) > 0 {
+		var err error
+		if request, err = mapDocumentChunks(ctx, request); err != nil {
+			stats.recordFailure(request, err)
+
+			return fmt.Sprintf(`Failed to summarize the document: <em>%s</em>`, escapeForHTML(err.Error())), ""
+		}
+	}
+
+	// retrieve chunks of documents for the question of `/ask`
+	if request.extra.question != "" {
+		var err error
+		if request, err = retrieveForRequest(ctx, conf, request); err != nil {
+			stats.recordFailure(request, err)
+
+			return fmt.Sprintf(`Failed to retrieve documents: <em>%s</em>`, escapeForHTML(err.Error())), ""
+		}
+	}
+
+	// guard against prompt injections in the replied-to text
+	var err error
+	if request, err = guardRequest(ctx, conf, request); err != nil {
+		return fmt.Sprintf(`Refused to generate: <em>%s</em>`, escapeForHTML(err.Error())), ""
+	}
+
+	// (the input is truncated if it doesn't fit in the context)
+	var notices string // (appended to the reply)
+	request, prompt, truncatedTokens, err := promptFor(request)
+	if err != nil {
+		stats.recordFailure(request, err)
+
+		return fmt.Sprintf(`Failed to build a prompt: <em>%s</em>`, escapeForHTML(err.Error())), ""
+	} else if truncatedTokens > 0 {
+		notices = fmt.Sprintf("\n\n<i>(input truncated to %d tokens)</i>", truncatedTokens)
+	}
+	if len(request.extra.sources) > 0 {
+		notices += "\n\n<b>Sources:</b>\n" + escapeForHTML(strings.Join(request.extra.sources, "\n"))
+	}
+	if request.extra.route != "" {
+		notices += fmt.Sprintf("\n\n<i>(routed: %s)</i>", escapeForHTML(request.extra.route))
+	}
+
+	// return the cached response of a similar prompt, if any
+	embedding := embedForCache(ctx, conf, request, prompt.text)
+	if generated, cached := cachedResponseFor(conf, model, request.targetChatID, embedding); cached {
+		request.logger().Printf(">>> returning cached response for request %s, model: %s", request, model)
+
+		appendConversationTurn(ctx, conf, request, generated)
+
+		return `<pre><code>
+` + escapeForHTML(generated) + `
+</code></pre>` + notices + `
+
+<i>(cached)</i>`, generated
+	}
+
+	// seed: request's > model's > random
+	if request.options.seed == nil {
+		seed := rand.Intn(math.MaxInt32)
+		if model.Seed != nil {
+			seed = *model.Seed
+		}
+		request.options.seed = &seed
+	}
+
+	// pre-process the prompt with hooks
+	if prompt.text, err = runHooks(ctx, conf.Hooks.Pre, request, prompt.text); err != nil {
+		stats.recordFailure(request, err)
+
+		return fmt.Sprintf(`Failed to pre-process the prompt: <em>%s</em>`, escapeForHTML(err.Error())), ""
+	}
+
+	request.logger().Printf(">>> generating for request %s with %s", request, model)
+
+	// generate (with tools, if the model uses them)
+	generationStartedAt := time.Now()
+	var timings *llamafileTimings
+	if model.UseTools && model.isLlamafile() && len(conf.Tools.Tools) > 0 {
+		var calls []string
+		if generated, calls, err = generateWithTools(ctx, conf, request, gen); len(calls) > 0 {
+			notices += "\n\n<b>Tools used:</b>\n" + escapeForHTML(strings.Join(calls, "\n"))
+		}
+	} else {
+		var result generationResult
+		if result, err = gen.Generate(ctx, prompt, resolvedOptions(request)); err == nil {
+			generated, timings = result.text, result.timings
+		}
+	}
+	attributes := map[string]any{"prompt.length": int64(len(prompt.text))}
+	if timings != nil {
+		attributes["tokens.prompt"], attributes["tokens.generated"] = int64(timings.promptTokens), int64(timings.generatedTokens)
+	}
+	request.trace.span("generate", generationStartedAt, time.Now(), attributes, err)
+
+	// post-process the output with hooks
+	if err == nil {
+		generated, err = runHooks(ctx, conf.Hooks.Post, request, generated)
+	}
+
+	// block the output with the content filter
+	if err == nil && filterBlocks(conf, request, FilterStageOutput, generated) {
+		return DefaultFilterBlockedOutput, ""
+	}
+
+	if err == nil {
+		stats.recordSuccess(request, time.Since(request.startedProcessingAt), timings)
+
+		appendConversationTurn(ctx, conf, request, generated)
+		cacheResponse(conf, model, request.targetChatID, embedding, generated)
+
+		return generationReply(request, generated, notices, escapeForHTML(model.label()), timings), generated
+	} else {
+		stats.recordFailure(request, err)
+
+		// (models should not be revealed in comparisons, eg. with their paths in errors)
+		if request.group.isComparison() {
+			return fmt.Sprintf(`Failed to generate from prompt '%s'.`, escapeForHTML(loggable(conf, prompt.text))), ""
+		}
+
+		var excerpt string
+		var lerr *llamafileError
+		if errors.As(err, &lerr) && lerr.stderr != "" {
+			excerpt = fmt.Sprintf("\n\n<pre>%s</pre>", escapeForHTML(lerr.stderrExcerpt()))
+		}
+
+		return fmt.Sprintf(`Failed to generate from prompt '%s': <em>%s</em>%s`, escapeForHTML(loggable(conf, prompt.text)), escapeForHTML(err.Error()), excerpt), ""
+	}
+}
+
+// build a reply (in HTML) with given generated text, notices, and generation info
+func generationReply(request request, generated, notices, label string, timings *llamafileTimings) string {
+	reply := `<pre><code>
+` + escapeForHTML(generated) + `
+</code></pre>` + notices
+
+	// (models should not be revealed in comparisons)
+	if request.group.isComparison() {
+		return reply
+	}
+
+	if showGenerationInfo(request) {
+		if info := additionalGenerationInfo(request, label, timings); info != "" {
+			reply += "\n\n" + info
+		}
+	}
+
+	return reply
+}
+
+// build parameters for llamafile from given model and options
+func llamafileParams(model model, opts generationOptions) (params []string) {
+	params = append(params, model.otherParameters()...)
+
+	if opts.seed != nil {
+		params = append(params, "--seed", strconv.Itoa(*opts.seed))
+	}
+	if opts.grammar != nil {
+		params = append(params, "--grammar", *opts.grammar)
+	} else if model.GrammarFile != nil {
+		params = append(params, "--grammar-file", *model.GrammarFile)
+	} else if len(model.JSONSchema) > 0 {
+		params = append(params, "--json-schema", string(model.JSONSchema))
+	}
+	if model.PromptCachePath != nil {
+		params = append(params, "--prompt-cache", *model.PromptCachePath)
+		if model.PromptCacheAll {
+			params = append(params, "--prompt-cache-all")
+		}
+	}
+	for _, stop := range model.StopSequences {
+		params = append(params, "--reverse-prompt", stop)
+	}
+	if opts.temperature != nil {
+		params = append(params, "--temp", strconv.FormatFloat(*opts.temperature, 'f', -1, 64))
+	}
+	if opts.maxTokens != nil {
+		params = append(params, "-n", strconv.Itoa(*opts.maxTokens))
+	}
+
+	return params
+}
+
+// returns the max number of tokens to generate for given request: request's > chat's > model's
+func maxTokensOf(request request) *int {
+	if request.options.maxTokens != nil {
+		return request.options.maxTokens
+	} else if settings := getChatSettings(request.targetChatID); settings.MaxTokens != nil {
+		return settings.MaxTokens
+	}
+	return request.model.MaxTokens
+}
+
+// generator which runs llamafile for each generation
+type llamafileExecGenerator struct {
+	model model
+}
+
+// Generate implements Generator interface
+func (g llamafileExecGenerator) Generate(ctx context.Context, prompt generationPrompt, opts generationOptions) (generationResult, error) {
+	if err := preflightCheck(g.model); err != nil {
+		return generationResult{}, err
+	}
+
+	generated, timings, err := generateFromLlamafile(ctx, g.model, prompt.text, llamafileParams(g.model, opts)...)
+	if err != nil {
+		return generationResult{}, err
+	}
+
+	return generationResult{
+		text:    trimAtStopSequences(generated, g.model.StopSequences),
+		timings: timings,
+	}, nil
+}
+
+// trim given text at the first occurrence of any of the stop sequences
+func trimAtStopSequences(text string, stops []string) string {
+	for _, stop := range stops {
+		if stop == "" {
+			continue
+		}
+
+		if index := strings.Index(text, stop); index >= 0 {
+			text = text[:index]
+		}
+	}
+
+	return strings.TrimSpace(text)
+}
+
+// generate text with the llamafile of given model
+//
+// NOTE: llamafile is launched with a launcher which works on this platform (see `launcherFor`) with `resource_limits` and `scheduling` of the model,
+// and timings of the generation are parsed from its stderr (nil if not printed)
+func generateFromLlamafile(ctx context.Context, model model, prompt string, params ...string) (string, *llamafileTimings, error) {
+	llamafilePath := *model.LlamafilePath
+
+	l, err := launcherFor(llamafilePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to run '%s' with params %+v: %s", llamafilePath, params, err)
+	}
+
+	ps := append([]string{}, l.args...)
+	ps = append(ps, "-p", fmt.Sprintf("\"%s\"", prompt))
+	ps = append(ps, params...)
+	ps = append(ps, "--silent-prompt")
+
+	//log.Printf(">>> running: $ %s %s", l.command, strings.Join(ps, " "))
+
+	var stdout progressWriter
+	var stderr bytes.Buffer
+
+	// (a stuck generation is killed by the watchdog, and retried once)
+	attempts := 1
+	if model.Watchdog != nil && !model.Watchdog.NoRetry {
+		attempts = 2
+	}
+
+	command, ps := wrapLlamafileCommand(model, l.command, ps)
+	var stalled bool
+	for attempt := 1; attempt <= attempts; attempt++ {
+		stdout.buf.Reset()
+		stderr.Reset()
+
+		cmd := exec.CommandContext(ctx, command, ps...)
+		sandboxCommand(cmd, model)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if stalled, err = runWatched(cmd, model.Watchdog, &stdout); !stalled {
+			break
+		}
+
+		log.Printf(">>> llamafile '%s' was stuck (no output and no CPU usage for %d seconds) and killed by watchdog (attempt %d/%d)", llamafilePath, model.Watchdog.StallSeconds, attempt, attempts)
+	}
+
+	if err == nil {
+		return sanitizeOutput(stdout.buf.String()), parseLlamafileTimings(stderr.String()), nil
+	} else {
+		// (stderr may echo the prompt, so it is logged with `log_user_content` of the current config)
+		log.Printf("Error: llamafile '%s' failed with stderr:\n%s", llamafilePath, loggable(liveConfig(config{}), stderr.String()))
+
+		class := classifyLlamafileFailure(stderr.String())
+		if stalled {
+			class = LlamafileFailureStalled
+		} else if limit, killed := killedByResourceLimit(err, model.ResourceLimits); killed {
+			log.Printf(">>> llamafile '%s' was killed by its resource limit: %s", llamafilePath, limit)
+
+			class = fmt.Sprintf("%s: %s", LlamafileFailureResourceLimit, limit)
+		} else if class == LlamafileFailureOutOfMemory && model.ResourceLimits != nil && model.ResourceLimits.MemoryMB > 0 {
+			class = fmt.Sprintf("%s: %s", LlamafileFailureResourceLimit, "memory_mb")
+		}
+
+		return "", nil, &llamafileError{
+			llamafilePath: llamafilePath,
+			params:        params,
+			class:         class,
+			stderr:        stderr.String(),
+			err:           err,
+		}
+	}
+}
+
+// classes of llamafile failures
+const (
+	LlamafileFailureModelNotFound = "model not found"
+	LlamafileFailureOutOfMemory   = "out of memory"
+	LlamafileFailureBadFlag       = "bad flag"
+	LlamafileFailureResourceLimit = "resource limit"
+	LlamafileFailureStalled       = "stalled"
+	LlamafileFailureUnknown       = "unknown"
+)
+
+// max length of stderr excerpts in error replies
+const LlamafileStderrExcerptLength = 500
+
+// error from a failed llamafile execution
+type llamafileError struct {
+	llamafilePath string
+	params        []string
+
+	class  string
+	stderr string
+	err    error
+}
+
+// Error implements error interface
+func (e *llamafileError) Error() string {
+	return fmt.Sprintf("Failed to run '%s' with params %+v: %s (%s)", e.llamafilePath, e.params, e.err, e.class)
+}
+
+// Unwrap returns the underlying error
+func (e *llamafileError) Unwrap() error {
+	return e.err
+}
+
+// returns the trimmed tail of stderr
+func (e *llamafileError) stderrExcerpt() string {
+	excerpt := strings.TrimSpace(e.stderr)
+	if len(excerpt) > LlamafileStderrExcerptLength {
+		start := len(excerpt) - LlamafileStderrExcerptLength
+		for start < len(excerpt) && !utf8.RuneStart(excerpt[start]) { // (cut on a rune boundary)
+			start++
+		}
+		excerpt = "..." + excerpt[start:]
+	}
+	return excerpt
+}
+
+// classify a llamafile failure with its stderr
+func classifyLlamafileFailure(stderr string) string {
+	lowered := strings.ToLower(stderr)
+
+	for _, failure := range []struct {
+		class    string
+		patterns []string
+	}{
+		{LlamafileFailureOutOfMemory, []string{"out of memory", "failed to allocate", "cannot allocate memory", "bad_alloc"}},
+		{LlamafileFailureModelNotFound, []string{"failed to load model", "unable to load model", "no such file or directory"}},
+		{LlamafileFailureBadFlag, []string{"unknown argument", "invalid argument", "unrecognized option", "error: invalid parameter"}},
+	} {
+		for _, pattern := range failure.patterns {
+			if strings.Contains(lowered, pattern) {
+				return failure.class
+			}
+		}
+	}
+
+	return LlamafileFailureUnknown
+}
+
+// generate an additional info about the generation
+func additionalGenerationInfo(request request, model string, timings *llamafileTimings) string {
+	fields := DefaultGenerationInfoFields
+	if len(request.model.GenerationInfoFields) > 0 {
+		fields = request.model.GenerationInfoFields
+	}
+
+	infos := []string{}
+	for _, field := range fields {
+		switch field {
+		case GenerationInfoModel:
+			infos = append(infos, fmt.Sprintf("processed by <strong>%s</strong>", model))
+		case GenerationInfoElapsed:
+			infos = append(infos, fmt.Sprintf("in %s seconds", msecsToString(time.Since(request.startedProcessingAt).Milliseconds())))
+		case GenerationInfoQueueWait:
+			infos = append(infos, fmt.Sprintf("waited %s seconds in queue", msecsToString(request.startedProcessingAt.Sub(request.enqueuedAt).Milliseconds())))
+		case GenerationInfoSeed:
+			if request.options.seed != nil {
+				infos = append(infos, fmt.Sprintf("with seed: <code>%d</code>", *request.options.seed))
+			}
+		case GenerationInfoTokens:
+			if timings != nil {
+				infos = append(infos, fmt.Sprintf("%d tokens generated at %.2f tokens/s (prompt: %d tokens)", timings.generatedTokens, timings.tokensPerSecond(), timings.promptTokens))
+			}
+		default:
+			log.Printf("Error: unknown field of generation info: %s", field)
+		}
+	}
+	if len(infos) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`<em>(%s)</em>`, strings.Join(infos, ", "))
+}
+
+// check if generation info should be shown for given request: chat's > model's > true
+func showGenerationInfo(request request) bool {
+	if show := getChatSettings(request.targetChatID).ShowGenerationInfo; show != nil {
+		return *show
+	}
+	if show := request.model.ShowGenerationInfo; show != nil {
+		return *show
+	}
+	return true
 }
 
 // for converting msecs into "0.000" format
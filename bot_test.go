@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+func TestReadModelsDir(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"1-mistral.json": `{"name": "Mistral", "llamafile_path": "/models/mistral.llamafile"}`,
+		"2-others.yaml": `- name: Phi
+  llamafile_path: /models/phi.llamafile
+  aliases: [phi]
+- name: Gemma
+  llamafile_path: /models/gemma.llamafile
+`,
+		"3-llama.yml": `name: Llama
+llamafile_path: /models/llama.llamafile
+llamafile_other_parameters:
+  - --temp
+  - "0.7"
+`,
+		"README.txt": `not a model`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+
+	models, err := readModelsDir(dir, false)
+	if err != nil {
+		t.Fatalf("failed to read models: %s", err)
+	}
+
+	names := []string{}
+	for _, m := range models {
+		names = append(names, *m.Name)
+	}
+	if strings.Join(names, ",") != "Mistral,Phi,Gemma,Llama" {
+		t.Errorf("models should be read in order of file names: %v", names)
+	}
+	if len(models) == 4 {
+		if len(models[1].Aliases) != 1 || models[1].Aliases[0] != "phi" {
+			t.Errorf("aliases in YAML should be read: %v", models[1].Aliases)
+		}
+		if strings.Join(models[3].LlamafileOtherParameters, " ") != "--temp 0.7" {
+			t.Errorf("parameters in YAML should be read: %v", models[3].LlamafileOtherParameters)
+		}
+	}
+}
+
+func TestParseYAMLModels(t *testing.T) {
+	tests := []struct {
+		yaml               string
+		allowUnknownFields bool
+		models             int
+		err                string
+	}{
+		{yaml: "name: Phi\nllamafile_path: /models/phi.llamafile\n", models: 1},
+		{yaml: "- name: Phi\n- name: Gemma\n", models: 2},
+		{yaml: "name: Phi\nllamafile_path_typo: /models/phi.llamafile\n", err: `unknown field "llamafile_path_typo"`},
+		{yaml: "name: Phi\nllamafile_path_typo: /models/phi.llamafile\n", allowUnknownFields: true, models: 1},
+		{yaml: "name: [Phi\n", err: "yaml:"},
+		{yaml: "", err: "no model"},
+	}
+
+	for _, test := range tests {
+		models, err := parseYAMLModels([]byte(test.yaml), test.allowUnknownFields)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error '%s' for %q, got: %v", test.err, test.yaml, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("failed to parse %q: %s", test.yaml, err)
+		} else if len(models) != test.models {
+			t.Errorf("expected %d model(s) for %q, got %d", test.models, test.yaml, len(models))
+		}
+	}
+}
+
+func TestStderrExcerpt(t *testing.T) {
+	tests := []struct {
+		stderr   string
+		expected string
+	}{
+		{stderr: "  error: out of memory\n", expected: "error: out of memory"},
+		{stderr: strings.Repeat("x", LlamafileStderrExcerptLength+10), expected: "..." + strings.Repeat("x", LlamafileStderrExcerptLength)},
+		{stderr: "x" + strings.Repeat("가", LlamafileStderrExcerptLength/3+1), expected: "..." + strings.Repeat("가", LlamafileStderrExcerptLength/3)}, // (3 bytes each)
+	}
+
+	for _, test := range tests {
+		excerpt := (&llamafileError{stderr: test.stderr}).stderrExcerpt()
+		if !utf8.ValidString(excerpt) {
+			t.Errorf("excerpt should be valid UTF-8: %q", excerpt)
+		}
+		if excerpt != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, excerpt)
+		}
+	}
+}
+
+func TestHasPriority(t *testing.T) {
+	username := func(s string) *string { return &s }
+	conf := config{
+		AdminTelegramUserIDs:      []int64{1},
+		PriorityTelegramUsernames: []string{"vip"},
+	}
+
+	tests := []struct {
+		user     *tg.User
+		expected bool
+	}{
+		{user: &tg.User{ID: 1}, expected: true}, // (admin without username)
+		{user: &tg.User{ID: 2, Username: username("vip")}, expected: true},
+		{user: &tg.User{ID: 3, Username: username("someone")}, expected: false},
+		{user: &tg.User{ID: 4}, expected: false},
+		{user: nil, expected: false},
+	}
+
+	for _, test := range tests {
+		if priority := hasPriority(conf, test.user); priority != test.expected {
+			t.Errorf("expected priority %t for %+v, got %t", test.expected, test.user, priority)
+		}
+	}
+}
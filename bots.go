@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+)
+
+// config of an additional bot, which runs in the same process with its own polling loop
+// (sharing the queue and backends of models with the main bot)
+type botConfig struct {
+	Name string `json:"name"` // (for logs, and for persisting the last update id of each bot)
+
+	TelegramBotToken        string   `json:"telegram_bot_token,omitempty"`
+	TelegramBotTokenFile    string   `json:"telegram_bot_token_file,omitempty"`
+	TelegramBotTokenCommand []string `json:"telegram_bot_token_command,omitempty"`
+
+	AllowedTelegramUsernames []string `json:"allowed_telegram_usernames,omitempty"` // (default: the ones of the main bot)
+	AdminTelegramUsernames   []string `json:"admin_telegram_usernames,omitempty"`   // (default: the ones of the main bot)
+	AdminTelegramUserIDs     []int64  `json:"admin_telegram_user_ids,omitempty"`    // (default: the ones of the main bot)
+
+	Models []string `json:"models,omitempty"` // indices or names of models available to this bot (default: all)
+}
+
+// returns the config for given additional bot, derived from the main one
+//
+// (models which are not available to the bot are disabled, so their indices stay the same)
+func configForBot(conf config, b botConfig) config {
+	c := conf
+	c.botName = b.Name
+	c.Bots = nil
+
+	c.TelegramBotToken = b.TelegramBotToken
+	c.TelegramBotTokenFile = b.TelegramBotTokenFile
+	c.TelegramBotTokenCommand = b.TelegramBotTokenCommand
+
+	if b.AllowedTelegramUsernames != nil {
+		c.AllowedTelegramUsernames = b.AllowedTelegramUsernames
+	}
+	if b.AdminTelegramUsernames != nil {
+		c.AdminTelegramUsernames = b.AdminTelegramUsernames
+	}
+	if b.AdminTelegramUserIDs != nil {
+		c.AdminTelegramUserIDs = b.AdminTelegramUserIDs
+	}
+
+	if len(b.Models) > 0 {
+		c.Models = make([]model, len(conf.Models))
+		for i, m := range conf.Models {
+			if !slices.Contains(b.Models, strconv.Itoa(i)) && !slices.ContainsFunc(b.Models, m.isNamed) {
+				m.Disabled = true
+			}
+			c.Models[i] = m
+		}
+	}
+
+	return c
+}
+
+// start additional bots in background, with the shared request queue
+func startAdditionalBots(conf config, reqQueue *priorityQueue) {
+	for _, b := range conf.Bots {
+		c := configForBot(conf, b)
+
+		token, err := telegramBotToken(c)
+		if err != nil {
+			log.Printf("Error: failed to get the token of bot '%s': %s", b.Name, err)
+			continue
+		}
+		bot, err := newTelegramClient(c, token)
+		if err != nil {
+			log.Printf("Error: failed to create a client of bot '%s': %s", b.Name, err)
+			continue
+		}
+
+		if me := bot.GetMe(); me.Ok {
+			log.Printf(">>> starting bot '%s' (@%s)", b.Name, *me.Result.Username)
+
+			go pollUpdates(c, bot, reqQueue)
+		} else {
+			log.Printf("Error: failed to get info about bot '%s': %s", b.Name, *me.Description)
+		}
+	}
+}
+
+// validate additional bots in given config, and return found problems
+func validateBots(conf config) (problems []string) {
+	names := map[string]bool{}
+	for i, b := range conf.Bots {
+		if b.Name == "" {
+			problems = append(problems, fmt.Sprintf("`name` of bot #%d is empty", i))
+		} else if names[b.Name] {
+			problems = append(problems, fmt.Sprintf("name of bot #%d is duplicated: %s", i, b.Name))
+		}
+		names[b.Name] = true
+
+		if b.TelegramBotToken == "" && b.TelegramBotTokenFile == "" && len(b.TelegramBotTokenCommand) == 0 {
+			problems = append(problems, fmt.Sprintf("bot #%d (%s) has no `telegram_bot_token` (or `telegram_bot_token_file`, `telegram_bot_token_command`)", i, b.Name))
+		}
+
+		for _, name := range b.Models {
+			if _, found := modelByIndexOrName(conf, name); !found || name == "" {
+				problems = append(problems, fmt.Sprintf("bot #%d (%s) has an unknown model: '%s'", i, b.Name, name))
+			}
+		}
+	}
+
+	return problems
+}
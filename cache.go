@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// default values of semantic cache configs
+const (
+	DefaultSemanticCacheSimilarityThreshold = 0.95
+	DefaultSemanticCacheTTLSeconds          = 60 * 60
+	DefaultSemanticCacheMaxEntries          = 1000
+)
+
+// configs for caching responses of similar prompts
+type semanticCacheConfig struct {
+	EmbeddingModelIndex int     `json:"embedding_model_index"`          // index of the model in `models` for generating embeddings
+	SimilarityThreshold float64 `json:"similarity_threshold,omitempty"` // min cosine similarity of prompts for a cache hit
+	TTLSeconds          int     `json:"ttl_seconds,omitempty"`          // cached responses expire after this
+	MaxEntries          int     `json:"max_entries,omitempty"`          // max number of cached responses, older ones are dropped (default: 1000)
+}
+
+// a cached response
+type cachedResponse struct {
+	model     string
+	chatID    int64
+	embedding []float64
+	generated string
+	cachedAt  time.Time
+}
+
+// global semantic cache
+var semanticCache = struct {
+	sync.Mutex
+
+	responses []cachedResponse
+}{}
+
+// returns the ttl of cached responses
+func (c semanticCacheConfig) ttl() time.Duration {
+	if c.TTLSeconds > 0 {
+		return time.Duration(c.TTLSeconds) * time.Second
+	}
+	return DefaultSemanticCacheTTLSeconds * time.Second
+}
+
+// returns the max number of cached responses
+func (c semanticCacheConfig) maxEntries() int {
+	if c.MaxEntries > 0 {
+		return c.MaxEntries
+	}
+	return DefaultSemanticCacheMaxEntries
+}
+
+// embed given prompt for the semantic cache, returns nil if it should not be cached
+func embedForCache(ctx context.Context, conf config, request request, prompt string) []float64 {
+	if conf.SemanticCache == nil {
+		return nil
+	}
+
+	// (generations with explicit seeds or temperatures are not cached)
+	if request.options.seed != nil || request.options.temperature != nil {
+		return nil
+	}
+
+	// (generations depending on more than the prompt are not cached either:
+	// the ones with conversation history, retrieved documents, or chunks of documents)
+	if len(request.history) > 0 || len(request.extra.sources) > 0 || len(request.extra.documentChunks) > 0 {
+		return nil
+	}
+
+	index := conf.SemanticCache.EmbeddingModelIndex
+	if index < 0 || index >= len(conf.Models) {
+		log.Printf("Error: invalid `embedding_model_index` of semantic cache: %d", index)
+		return nil
+	}
+
+	embedding, err := embed(ctx, conf.Models[index], prompt)
+	if err != nil {
+		log.Printf("Error: failed to embed prompt for semantic cache: %s", err)
+		return nil
+	}
+
+	return embedding
+}
+
+// returns a cached response of given model in given chat, for a prompt similar to the embedded one
+func cachedResponseFor(conf config, model model, chatID int64, embedding []float64) (generated string, found bool) {
+	if embedding == nil {
+		return "", false
+	}
+
+	threshold := DefaultSemanticCacheSimilarityThreshold
+	if conf.SemanticCache.SimilarityThreshold > 0 {
+		threshold = conf.SemanticCache.SimilarityThreshold
+	}
+	ttl := conf.SemanticCache.ttl()
+	name := model.String()
+
+	semanticCache.Lock()
+	defer semanticCache.Unlock()
+
+	// remove expired responses
+	valid := []cachedResponse{}
+	for _, r := range semanticCache.responses {
+		if time.Since(r.cachedAt) < ttl {
+			valid = append(valid, r)
+		}
+	}
+	semanticCache.responses = valid
+
+	best := threshold
+	for _, r := range semanticCache.responses {
+		if r.model != name || r.chatID != chatID {
+			continue
+		}
+		if similarity := cosineSimilarity(embedding, r.embedding); similarity >= best {
+			best, generated, found = similarity, r.generated, true
+		}
+	}
+
+	return generated, found
+}
+
+// cache a generated response of given model in given chat for the embedded prompt
+func cacheResponse(conf config, model model, chatID int64, embedding []float64, generated string) {
+	if embedding == nil {
+		return
+	}
+
+	semanticCache.Lock()
+	defer semanticCache.Unlock()
+
+	semanticCache.responses = append(semanticCache.responses, cachedResponse{
+		model:     model.String(),
+		chatID:    chatID,
+		embedding: embedding,
+		generated: generated,
+		cachedAt:  time.Now(),
+	})
+	if over := len(semanticCache.responses) - conf.SemanticCache.maxEntries(); over > 0 {
+		semanticCache.responses = semanticCache.responses[over:]
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSemanticCache(t *testing.T) {
+	path := "/models/test.llamafile"
+	m := model{LlamafilePath: &path}
+	conf := config{SemanticCache: &semanticCacheConfig{MaxEntries: 2}}
+
+	semanticCache.responses = nil
+	defer func() { semanticCache.responses = nil }()
+
+	cacheResponse(conf, m, 1, []float64{1, 0}, "first")
+
+	if generated, found := cachedResponseFor(conf, m, 1, []float64{1, 0}); !found || generated != "first" {
+		t.Errorf("cached response should be found in the same chat: '%s'", generated)
+	}
+	if _, found := cachedResponseFor(conf, m, 2, []float64{1, 0}); found {
+		t.Errorf("cached response should not be found in other chats")
+	}
+	if _, found := cachedResponseFor(conf, m, 1, []float64{0, 1}); found {
+		t.Errorf("cached response should not be found for a dissimilar prompt")
+	}
+
+	// (older ones are dropped over `max_entries`)
+	cacheResponse(conf, m, 1, []float64{0, 1}, "second")
+	cacheResponse(conf, m, 1, []float64{1, 1}, "third")
+	if _, found := cachedResponseFor(conf, m, 1, []float64{1, 0}); found {
+		t.Errorf("the oldest cached response should be dropped")
+	}
+	if len(semanticCache.responses) != 2 {
+		t.Errorf("unexpected number of cached responses: %d", len(semanticCache.responses))
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// evaluate an arithmetic expression (with `+`, `-`, `*`, `/`, `%`, `^`, and parentheses)
+func calculate(expression string) (float64, error) {
+	p := &calculatorParser{input: strings.ReplaceAll(expression, " ", "")}
+
+	value, err := p.expression()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos < len(p.input) {
+		return 0, fmt.Errorf("unexpected '%c' at %d", p.input[p.pos], p.pos)
+	}
+
+	return value, nil
+}
+
+// recursive descent parser for arithmetic expressions
+type calculatorParser struct {
+	input string
+	pos   int
+}
+
+// returns the next byte, or 0 if there is none
+func (p *calculatorParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// expression = term { ("+" | "-") term }
+func (p *calculatorParser) expression() (float64, error) {
+	value, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		switch p.peek() {
+		case '+':
+			p.pos++
+			rhs, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			value += rhs
+		case '-':
+			p.pos++
+			rhs, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			value -= rhs
+		default:
+			return value, nil
+		}
+	}
+}
+
+// term = power { ("*" | "/" | "%") power }
+func (p *calculatorParser) term() (float64, error) {
+	value, err := p.power()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' && op != '%' {
+			return value, nil
+		}
+		p.pos++
+
+		rhs, err := p.power()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case '*':
+			value *= rhs
+		case '/':
+			if rhs == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			value /= rhs
+		case '%':
+			if rhs == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			value = math.Mod(value, rhs)
+		}
+	}
+}
+
+// power = unary [ "^" power ]
+func (p *calculatorParser) power() (float64, error) {
+	base, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+
+	if p.peek() == '^' {
+		p.pos++
+		exponent, err := p.power()
+		if err != nil {
+			return 0, err
+		}
+		return math.Pow(base, exponent), nil
+	}
+
+	return base, nil
+}
+
+// unary = [ "-" | "+" ] unary | primary
+func (p *calculatorParser) unary() (float64, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		value, err := p.unary()
+		return -value, err
+	case '+':
+		p.pos++
+		return p.unary()
+	}
+
+	return p.primary()
+}
+
+// primary = number | "(" expression ")"
+func (p *calculatorParser) primary() (float64, error) {
+	if p.peek() == '(' {
+		p.pos++
+		value, err := p.expression()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("missing ')' at %d", p.pos)
+		}
+		p.pos++
+		return value, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos < len(p.input) {
+			return 0, fmt.Errorf("unexpected '%c' at %d", p.input[p.pos], p.pos)
+		}
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
+
+	return strconv.ParseFloat(p.input[start:p.pos], 64)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// prefixes of callback data
+const (
+	callbackDataVotePrefix  = "vote/"
+	callbackDataModelPrefix = "model/"
+)
+
+// handle callback query (from inline keyboard buttons)
+func handleCallbackQuery(conf config, bot *tg.Bot, query tg.CallbackQuery) {
+	if query.Data == nil {
+		return
+	}
+
+	var answer string
+	if data, found := strings.CutPrefix(*query.Data, callbackDataVotePrefix); found {
+		answer = handleVote(query, data)
+	} else if data, found := strings.CutPrefix(*query.Data, callbackDataModelPrefix); found {
+		if query.Message == nil {
+			return
+		}
+		answer = selectModelByID(conf, query.Message.Chat.ID, data)
+	} else {
+		log.Printf("Error: unknown callback data: %s", *query.Data)
+		return
+	}
+
+	if answered := bot.AnswerCallbackQuery(query.ID, tg.OptionsAnswerCallbackQuery{}.SetText(answer)); !answered.Ok {
+		log.Printf("Error: failed to answer callback query: %s", *answered.Description)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// default timeout of handling each request (including its steps, eg. retrieval, tools, and hooks)
+const DefaultRequestTimeoutSeconds = 900
+
+// cancellations of requests with `/cancel`
+var cancellations = struct {
+	sync.Mutex
+
+	inFlight map[uint64]context.CancelFunc // cancel functions of in-flight requests, keyed by their ids
+	queued   map[uint64]bool               // ids of queued requests which were canceled (they are skipped when dequeued)
+}{
+	inFlight: map[uint64]context.CancelFunc{},
+	queued:   map[uint64]bool{},
+}
+
+// returns a context for handling given request, which is done when it is canceled with `/cancel` or times out
+//
+// (the returned function should be called when the request is finished)
+func requestContext(conf config, request request) (context.Context, context.CancelFunc) {
+	timeout := DefaultRequestTimeoutSeconds * time.Second
+	if conf.RequestTimeoutSeconds > 0 {
+		timeout = time.Duration(conf.RequestTimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	cancellations.Lock()
+	cancellations.inFlight[request.id] = cancel
+	cancellations.Unlock()
+
+	return ctx, func() {
+		cancellations.Lock()
+		delete(cancellations.inFlight, request.id)
+		cancellations.Unlock()
+
+		cancel()
+	}
+}
+
+// check if given (dequeued) request was canceled while it was queued
+func wasCanceled(request request) bool {
+	cancellations.Lock()
+	defer cancellations.Unlock()
+
+	canceled := cancellations.queued[request.id]
+	delete(cancellations.queued, request.id)
+
+	return canceled
+}
+
+// cancel queued and in-flight requests of given user, and return the number of them
+func cancelRequests(userID int64) (count int) {
+	queued, inFlight := tracker.snapshot()
+
+	cancellations.Lock()
+	defer cancellations.Unlock()
+
+	for _, r := range queued {
+		if r.userID == userID {
+			cancellations.queued[r.id] = true
+			count++
+		}
+	}
+	for _, r := range inFlight {
+		if cancel, exists := cancellations.inFlight[r.id]; exists && r.userID == userID {
+			cancel()
+			count++
+		}
+	}
+
+	return count
+}
+
+// returns the reply for a request whose context is done (canceled or timed out), or false if it is not done
+func requestContextReply(ctx context.Context) (string, bool) {
+	switch ctx.Err() {
+	case context.Canceled:
+		return "Your request was canceled.", true
+	case context.DeadlineExceeded:
+		return "Your request timed out.", true
+	}
+	return "", false
+}
+
+// handle `/cancel` command: cancel pending requests of given user, and return a message about it
+func cancelMessage(userID int64) string {
+	if count := cancelRequests(userID); count > 0 {
+		return fmt.Sprintf("Canceled %d pending request(s).", count)
+	}
+	return "You have no pending requests."
+}
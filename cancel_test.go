@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCancelRequests(t *testing.T) {
+	const userID = 42
+
+	queued, inFlight, others := request{userID: userID}, request{userID: userID}, request{userID: userID + 1}
+	for _, r := range []*request{&queued, &inFlight, &others} {
+		tracker.add(r)
+	}
+	defer func() {
+		tracker.drop(queued)
+		tracker.drop(others)
+		tracker.finish(inFlight)
+	}()
+
+	tracker.start(inFlight)
+	ctx, cancel := requestContext(config{}, inFlight)
+	defer cancel()
+
+	if count := cancelRequests(userID); count != 2 {
+		t.Errorf("2 requests should be canceled: %d", count)
+	}
+
+	if reply, done := requestContextReply(ctx); !done || ctx.Err() != context.Canceled {
+		t.Errorf("in-flight request should be canceled: %s", reply)
+	}
+	if !wasCanceled(queued) {
+		t.Errorf("queued request should be canceled")
+	}
+	if wasCanceled(queued) {
+		t.Errorf("queued request should be canceled only once")
+	}
+	if wasCanceled(others) {
+		t.Errorf("requests of other users should not be canceled")
+	}
+}
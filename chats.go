@@ -0,0 +1,181 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// settings of a chat (persisted in the database)
+type chatSettings struct {
+	MaxTokens *int    `json:"max_tokens,omitempty"`
+	Model     *string `json:"model,omitempty"` // name of the selected model
+
+	ShowGenerationInfo *bool `json:"show_generation_info,omitempty"`
+	Voice              *bool `json:"voice,omitempty"`  // reply with synthesized voice
+	Merge              *bool `json:"merge,omitempty"`  // merge consecutive messages into one prompt (until `/go`)
+	Digest             *bool `json:"digest,omitempty"` // keep messages of the group chat for its digests
+
+	FilterStrictness *string `json:"filter_strictness,omitempty"` // strictness of the content filter
+}
+
+// returns settings of given chat
+func getChatSettings(chatID int64) (settings chatSettings) {
+	db.read(func(data dbData) {
+		settings = data.Chats[chatID]
+	})
+
+	return settings
+}
+
+// update settings of given chat with given function
+func updateChatSettings(chatID int64, fn func(settings *chatSettings)) {
+	if err := db.update(func(data *dbData) {
+		if data.Chats == nil {
+			data.Chats = map[int64]chatSettings{}
+		}
+
+		settings := data.Chats[chatID]
+		fn(&settings)
+		data.Chats[chatID] = settings
+	}); err != nil {
+		log.Printf("Error: failed to save settings of chat %d: %s", chatID, err)
+	}
+}
+
+// check if given model is available in given chat
+//
+// NOTE: if the chat is not in `chat_models`, every model is available
+func (m model) availableInChat(conf config, chatID int64) bool {
+	available, restricted := conf.ChatModels[chatID]
+	if !restricted {
+		return true
+	}
+
+	for _, name := range available {
+		if m.isNamed(name) || (m.Pool != nil && name == *m.Pool) {
+			return true
+		}
+		if index, err := strconv.Atoi(name); err == nil && index >= 0 && index < len(conf.Models) && conf.Models[index].String() == m.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// returns enabled models which are available in given chat
+func enabledModelsInChat(conf config, chatID int64) (models []model) {
+	for _, m := range enabledModels(conf) {
+		if m.availableInChat(conf, chatID) {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+// returns models for given chat: the selected one if it is available, or all enabled models of the chat
+func modelsForChat(conf config, chatID int64) []model {
+	models := enabledModelsInChat(conf, chatID)
+
+	if selected := getChatSettings(chatID).Model; selected != nil {
+		for _, m := range models {
+			if m.String() == *selected {
+				return []model{m}
+			}
+		}
+	}
+
+	return models
+}
+
+// argument for selecting all enabled models
+const selectAllModels = "all"
+
+// select a model of given chat with given argument (1-based index, name or alias of the model, or "all"), and return a message about it
+func selectModel(conf config, chatID int64, arg string) string {
+	if arg == selectAllModels {
+		updateChatSettings(chatID, func(settings *chatSettings) {
+			settings.Model = nil
+		})
+		return "All enabled models will be used in this chat."
+	}
+
+	models := enabledModelsInChat(conf, chatID)
+	for i, model := range models {
+		name := model.String()
+		if arg == strconv.Itoa(i+1) || model.isNamed(arg) {
+			updateChatSettings(chatID, func(settings *chatSettings) {
+				settings.Model = &name
+			})
+			return fmt.Sprintf("Selected model of this chat: %s", name)
+		}
+	}
+
+	return fmt.Sprintf("No such model: %s", arg)
+}
+
+// returns a stable id of the model, for callback data (which is limited to 64 bytes)
+//
+// (unlike indices, it does not change when models are reordered, added, or disabled)
+func (m model) id() string {
+	hash := sha256.Sum256([]byte(m.String()))
+	return hex.EncodeToString(hash[:6])
+}
+
+// select a model of given chat with the id in callback data (or "all"), and return a message about it
+func selectModelByID(conf config, chatID int64, id string) string {
+	if id == selectAllModels {
+		return selectModel(conf, chatID, id)
+	}
+
+	for _, m := range enabledModelsInChat(conf, chatID) {
+		if m.id() == id {
+			return selectModel(conf, chatID, m.String())
+		}
+	}
+
+	return "This model is no longer available."
+}
+
+// handle `/model` command: select a model of the chat with given argument, or show a keyboard for selecting one
+func handleModelCommand(conf config, bot *tg.Bot, args string, message tg.Message) {
+	if args != "" {
+		sendReply(conf, bot, message, escapeForHTML(selectModel(conf, message.Chat.ID, args)))
+		return
+	}
+
+	current := "all enabled models"
+	if selected := getChatSettings(message.Chat.ID).Model; selected != nil {
+		current = *selected
+	}
+
+	keyboard := [][]tg.InlineKeyboardButton{}
+	for _, model := range enabledModelsInChat(conf, message.Chat.ID) {
+		data := callbackDataModelPrefix + model.id()
+		keyboard = append(keyboard, []tg.InlineKeyboardButton{{
+			Text:         model.String(),
+			CallbackData: &data,
+		}})
+	}
+	data := callbackDataModelPrefix + selectAllModels
+	keyboard = append(keyboard, []tg.InlineKeyboardButton{{
+		Text:         "All enabled models",
+		CallbackData: &data,
+	}})
+
+	limiter.wait(conf)
+
+	options := tg.OptionsSendMessage{}.
+		SetReplyParameters(tg.ReplyParameters{MessageID: message.MessageID}).
+		SetParseMode(tg.ParseModeHTML).
+		SetReplyMarkup(tg.InlineKeyboardMarkup{InlineKeyboard: keyboard})
+	if sent := bot.SendMessage(message.Chat.ID, fmt.Sprintf("Current model: <b>%s</b>\nSelect a model for this chat:", escapeForHTML(current)), options); !sent.Ok {
+		limiter.pauseIfNeeded(sent.Parameters)
+
+		log.Printf("Error: failed to send model keyboard: %s", *sent.Description)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSelectModelByID(t *testing.T) {
+	mistral, phi, gemma := "Mistral", "Phi", "Gemma"
+	conf := config{Models: []model{{Name: &mistral}, {Name: &phi}, {Name: &gemma}}}
+	id := conf.Models[1].id()
+
+	var chatID int64 = 12345
+	defer updateChatSettings(chatID, func(settings *chatSettings) {
+		settings.Model = nil
+	})
+
+	// (ids are kept when models are reordered or removed)
+	conf.Models = []model{{Name: &gemma}, {Name: &phi}}
+
+	selectModelByID(conf, chatID, id)
+	if selected := getChatSettings(chatID).Model; selected == nil || *selected != phi {
+		t.Errorf("model should be selected by its id: %v", selected)
+	}
+
+	if answer := selectModelByID(conf, chatID, conf.Models[0].id()+"0"); answer != "This model is no longer available." {
+		t.Errorf("unknown ids should not select any model: %s", answer)
+	}
+
+	selectModelByID(conf, chatID, selectAllModels)
+	if selected := getChatSettings(chatID).Model; selected != nil {
+		t.Errorf("all models should be selected: %s", *selected)
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// for the REPL
+const (
+	REPLChatID                   = -1 // (pseudo chat for remembering conversation turns)
+	REPLCommandExit              = "/exit"
+	DefaultREPLConversationTurns = 10
+)
+
+// CLI subcommands
+const (
+	SubcommandGenerate = "generate"
+	SubcommandValidate = "validate"
+	SubcommandREPL     = "repl"
+	SubcommandSchema   = "schema"
+)
+
+// run given CLI subcommand with its arguments, and return the exit code (or false if it is not a subcommand)
+func runSubcommand(name string, args []string) (exitCode int, isSubcommand bool) {
+	switch name {
+	case SubcommandGenerate:
+		return runGenerate(args), true
+	case SubcommandValidate:
+		return runValidate(args), true
+	case SubcommandREPL:
+		return runREPL(args), true
+	case SubcommandSchema:
+		return runSchema(args), true
+	case SubcommandEncrypt, SubcommandDecrypt:
+		return runCrypt(name, args), true
+	}
+	return 0, false
+}
+
+// generate text with a model in config, without Telegram:
+//
+//	$ bot generate --config config.json --model <name> "prompt"
+//
+// it runs the same prompt-templating (with leading directives and hooks) and backend path as the bot, and prints the output
+func runGenerate(args []string) int {
+	flags := flag.NewFlagSet(SubcommandGenerate, flag.ContinueOnError)
+	configPath := flags.String("config", "config.json", "path of the config file")
+	modelName := flags.String("model", "", "index or name of the model (default: the first enabled one)")
+	verbose := flags.Bool("verbose", false, "print the built prompt and timings to stderr")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage:\n\n  $ %s %s [FLAGS] \"PROMPT\"\n\nFlags:\n\n", os.Args[0], SubcommandGenerate)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	conf, model, err := loadModelForCLI(*configPath, *modelName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+	defer stopLlamafileServers()
+
+	text, options := parseDirectives(conf, strings.Join(flags.Args(), " "))
+	text = escapeForShell(text)
+	request := request{
+		model:        model,
+		originalText: &text,
+		options:      options,
+		date:         time.Now(),
+	}
+
+	generated, err := generateForCLI(conf, request, *verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	fmt.Println(generated)
+
+	return 0
+}
+
+// read config from given path, and return it with the (valid) model of given index or name
+func loadModelForCLI(configPath, modelName string) (conf config, m model, err error) {
+	if conf, err = readConfig(configPath); err != nil {
+		return conf, m, fmt.Errorf("failed to read config file: %s", err)
+	}
+	applyChatTemplates(&conf)
+	fixExecutePermissions(conf)
+
+	var found bool
+	if m, found = modelByIndexOrName(conf, modelName); !found {
+		return conf, m, fmt.Errorf("no such model: %s", modelName)
+	}
+	if problems := validateModel(m); len(problems) > 0 {
+		return conf, m, fmt.Errorf("model %s is misconfigured:\n  - %s", m, strings.Join(problems, "\n  - "))
+	}
+
+	return conf, m, nil
+}
+
+// generate text for given request through the same prompt-templating (with hooks) and backend path as the bot
+//
+// (the built prompt and timings are printed to stderr if `verbose` is true)
+func generateForCLI(conf config, request request, verbose bool) (generated string, err error) {
+	gen, err := generatorFor(request.model)
+	if err != nil {
+		return "", err
+	}
+
+	request, prompt, truncatedTokens, err := promptFor(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to build a prompt: %s", err)
+	}
+	if prompt.text, err = runHooks(context.Background(), conf.Hooks.Pre, request, prompt.text); err != nil {
+		return "", fmt.Errorf("failed to pre-process the prompt: %s", err)
+	}
+	if verbose {
+		fmt.Fprintf(os.Stderr, "--- prompt for %s (truncated tokens: %d):\n%s\n---\n", request.model, truncatedTokens, prompt.text)
+	}
+
+	started := time.Now()
+	result, err := gen.Generate(context.Background(), prompt, resolvedOptions(request))
+	if err == nil {
+		result.text, err = runHooks(context.Background(), conf.Hooks.Post, request, result.text)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to generate: %s", err)
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "--- generated in %ss\n", msecsToString(time.Since(started).Milliseconds()))
+		if t := result.timings; t != nil {
+			fmt.Fprintf(os.Stderr, "--- %d prompt tokens, %d generated tokens\n", t.promptTokens, t.generatedTokens)
+		}
+	}
+
+	return result.text, nil
+}
+
+// chat with a model in config on the terminal, without Telegram:
+//
+//	$ bot repl --config config.json --model <name>
+//
+// previous turns are remembered (and summarized) as in Telegram chats, so prompt patterns can be tuned before going live
+func runREPL(args []string) int {
+	flags := flag.NewFlagSet(SubcommandREPL, flag.ContinueOnError)
+	configPath := flags.String("config", "config.json", "path of the config file")
+	modelName := flags.String("model", "", "index or name of the model (default: the first enabled one)")
+	turns := flags.Int("turns", 0, "number of recent turns to remember (default: `conversation_turns` in config, or 10)")
+	verbose := flags.Bool("verbose", false, "print logs, built prompts, and timings to stderr")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage:\n\n  $ %s %s [FLAGS]\n\nFlags:\n\n", os.Args[0], SubcommandREPL)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	conf, model, err := loadModelForCLI(*configPath, *modelName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+	defer stopLlamafileServers()
+
+	if *turns > 0 {
+		conf.ConversationTurns = *turns
+	} else if conf.ConversationTurns <= 0 {
+		conf.ConversationTurns = DefaultREPLConversationTurns
+	}
+
+	fmt.Printf("Chatting with %s (%s to forget the conversation, %s or Ctrl-D to quit)\n", model, CommandReset, REPLCommandExit)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		switch line {
+		case "":
+			continue
+		case REPLCommandExit:
+			return 0
+		case CommandReset:
+			_ = resetConversation(REPLChatID)
+			fmt.Println("(conversation was reset)")
+			continue
+		}
+
+		text, options := parseDirectives(conf, line)
+		text = escapeForShell(text)
+		request := request{
+			model:        model,
+			originalText: &text,
+			options:      options,
+			date:         time.Now(),
+			targetChatID: REPLChatID,
+			history:      compressedConversationHistory(context.Background(), conf, REPLChatID, model),
+		}
+
+		generated, err := generateForCLI(conf, request, *verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			continue
+		}
+
+		fmt.Println(generated)
+
+		appendConversationTurn(context.Background(), conf, request, generated)
+	}
+
+	return 0
+}
+
+// lint given config file, and print a report of found problems:
+//
+//	$ bot validate config.json
+//
+// it checks JSON syntax, required fields, files of models, placeholders in patterns, duplicates, and usernames
+func runValidate(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage:\n\n  $ %s %s CONFIG_FILEPATH\n", os.Args[0], SubcommandValidate)
+		return 2
+	}
+	path := args[0]
+
+	conf, err := readConfig(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: failed to read: %s\n", path, err)
+		return 1
+	}
+	applyChatTemplates(&conf)
+
+	problems := validateConfig(conf)
+	for i, model := range conf.Models {
+		if model.Disabled {
+			continue
+		}
+		for _, problem := range validateModel(model) {
+			problems = append(problems, fmt.Sprintf("model #%d (%s): %s", i, model, problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "%s: %d problem(s) found\n  - %s\n", path, len(problems), strings.Join(problems, "\n  - "))
+		return 1
+	}
+
+	fmt.Printf("%s: ok\n", path)
+
+	return 0
+}
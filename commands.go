@@ -0,0 +1,331 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// commands
+const (
+	CommandStart   = "/start"
+	CommandStatus  = "/status"
+	CommandQueue   = "/queue"
+	CommandCancel  = "/cancel"
+	CommandVersion = "/version"
+
+	CommandMaxTokens = "/maxtokens"
+	CommandModel     = "/model"
+	CommandModelInfo = "/modelinfo"
+	CommandReset     = "/reset"
+	CommandInfo      = "/info"
+	CommandVoice     = "/voice"
+	CommandMerge     = "/merge"
+	CommandGo        = "/go"
+	CommandDigest    = "/digest"
+	CommandBalance   = "/balance"
+	CommandTopup     = "/topup"
+
+	CommandAsk     = "/ask"
+	CommandURL     = "/url"
+	CommandYouTube = "/yt"
+	CommandImagine = "/imagine"
+
+	CommandCompare     = "/compare"
+	CommandLeaderboard = "/leaderboard"
+
+	// for admins only (others get `NotPermittedMessage`)
+	CommandResend   = "/resend"
+	CommandFilter   = "/filter"
+	CommandStats    = "/stats"
+	CommandEnable   = "/enable"
+	CommandDisable  = "/disable"
+	CommandAddModel = "/addmodel"
+	CommandReload   = "/reload"
+)
+
+// reply to non-admins' commands which are for admins only
+const NotPermittedMessage = "This command is not permitted to you."
+
+// handle built-in commands, returns true if given message was handled as a command
+func handleCommand(conf config, bot *tg.Bot, reqQueue *priorityQueue, message tg.Message) bool {
+	command, args, _ := strings.Cut(*message.Text, " ")
+	command, _, _ = strings.Cut(command, "@") // strip bot name (eg. "/status@my_bot")
+	args = strings.TrimSpace(args)
+
+	switch command {
+	case CommandStart:
+		// ignore it
+	case CommandStatus:
+		sendReply(conf, bot, message, statusMessage(conf, isAdmin(conf, message.From)))
+	case CommandVersion:
+		sendReply(conf, bot, message, versionMessage(conf))
+	case CommandQueue:
+		if message.From != nil {
+			sendReply(conf, bot, message, queueMessage(message.From.ID))
+		}
+	case CommandCancel:
+		if message.From != nil {
+			sendReply(conf, bot, message, cancelMessage(message.From.ID))
+		}
+	case CommandMaxTokens:
+		sendReply(conf, bot, message, maxTokensMessage(conf, message.Chat.ID, args))
+	case CommandReset:
+		if err := resetConversation(message.Chat.ID); err != nil {
+			log.Printf("Error: failed to reset conversation of chat %d: %s", message.Chat.ID, err)
+		}
+		sendReply(conf, bot, message, "Conversation of this chat was reset.")
+	case CommandInfo:
+		sendReply(conf, bot, message, generationInfoMessage(message.Chat.ID, args))
+	case CommandVoice:
+		sendReply(conf, bot, message, voiceMessage(conf, message.Chat.ID, args))
+	case CommandMerge:
+		sendReply(conf, bot, message, mergeMessage(conf, message.Chat.ID, args))
+	case CommandDigest:
+		sendReply(conf, bot, message, digestMessage(conf, message, args))
+	case CommandGo:
+		if !flushDebouncedMessages(conf, bot, reqQueue, message) {
+			sendReply(conf, bot, message, "There are no messages to merge.")
+		}
+	case CommandBalance:
+		if message.From != nil {
+			sendReply(conf, bot, message, balanceMessage(conf, message.From.ID))
+		}
+	case CommandTopup:
+		handleTopupCommand(conf, bot, args, message)
+	case CommandModel:
+		handleModelCommand(conf, bot, args, message)
+	case CommandModelInfo:
+		sendReply(conf, bot, message, modelInfoMessage(conf, args))
+	case CommandAsk:
+		handleAskCommand(conf, bot, reqQueue, args, message)
+	case CommandURL:
+		handleURLCommand(conf, bot, reqQueue, args, message)
+	case CommandYouTube:
+		handleYouTubeCommand(conf, bot, reqQueue, args, message)
+	case CommandImagine:
+		handleImagineCommand(conf, bot, reqQueue, args, message)
+	case CommandCompare:
+		handleCompareCommand(conf, bot, reqQueue, args, message)
+	case CommandLeaderboard:
+		sendReply(conf, bot, message, leaderboardMessage())
+	case CommandResend:
+		if !isAdmin(conf, message.From) {
+			sendReply(conf, bot, message, NotPermittedMessage)
+			break
+		}
+		succeeded, failed := resendUndelivered(conf, bot)
+		sendReply(conf, bot, message, fmt.Sprintf("Re-sent undelivered results: %d succeeded, %d failed.", succeeded, failed))
+	case CommandFilter:
+		if !isAdmin(conf, message.From) {
+			sendReply(conf, bot, message, NotPermittedMessage)
+			break
+		}
+		sendReply(conf, bot, message, filterMessage(conf, message.Chat.ID, args))
+	case CommandStats:
+		if !isAdmin(conf, message.From) {
+			sendReply(conf, bot, message, NotPermittedMessage)
+			break
+		}
+		sendReply(conf, bot, message, usageStatsMessage(args))
+	case CommandEnable, CommandDisable:
+		if !isAdmin(conf, message.From) {
+			sendReply(conf, bot, message, NotPermittedMessage)
+			break
+		}
+		sendReply(conf, bot, message, toggleModelMessage(conf, args, command == CommandDisable))
+	case CommandAddModel:
+		if !isAdminByID(conf, message.From) { // (it can run executables, so usernames are not trusted)
+			sendReply(conf, bot, message, NotPermittedMessage)
+			break
+		}
+		sendReply(conf, bot, message, addModelMessage(conf, args))
+	case CommandReload:
+		if !isAdmin(conf, message.From) {
+			sendReply(conf, bot, message, NotPermittedMessage)
+			break
+		}
+		sendReply(conf, bot, message, reloadMessage())
+	default:
+		return false
+	}
+
+	return true
+}
+
+// send a reply (in HTML parse mode) to given message
+func sendReply(conf config, bot *tg.Bot, message tg.Message, text string) {
+	limiter.wait(conf)
+
+	options := tg.OptionsSendMessage{}.
+		SetReplyParameters(tg.ReplyParameters{MessageID: message.MessageID}).
+		SetParseMode(tg.ParseModeHTML)
+	if sent := bot.SendMessage(message.Chat.ID, text, options); !sent.Ok {
+		limiter.pauseIfNeeded(sent.Parameters)
+
+		log.Printf("Error: failed to send message: %s", *sent.Description)
+	}
+}
+
+// generate a status message
+//
+// (models and their statistics, which can have paths and errors, are shown only to admins)
+func statusMessage(conf config, forAdmin bool) string {
+	stats.Lock()
+	uptime := time.Since(stats.startedAt).Round(time.Second)
+	overflows := stats.overflows
+	stats.Unlock()
+
+	queued, inFlight := tracker.counts()
+
+	lines := []string{
+		fmt.Sprintf("<b>Uptime</b>: %s", uptime),
+		fmt.Sprintf("<b>Requests</b>: %d queued, %d in-flight (queue overflowed %d time(s))", queued, inFlight, overflows),
+	}
+	if !forAdmin {
+		return strings.Join(lines, "\n")
+	}
+	lines = append(lines, "")
+
+	for _, model := range conf.Models {
+		if model.isDisabled() {
+			lines = append(lines, fmt.Sprintf("<b>%s</b>: <i>disabled</i>", escapeForHTML(model.String())))
+			continue
+		}
+
+		stats.Lock()
+		ms := *stats.model(model)
+		stats.Unlock()
+
+		avg := "-"
+		if duration, exists := stats.averageDuration(model); exists {
+			avg = msecsToString(duration.Milliseconds()) + "s"
+		}
+		lines = append(lines, fmt.Sprintf("<b>%s</b>: %d generated, %d failed, %s on average", escapeForHTML(model.String()), ms.generations, ms.failures, avg))
+
+		if tps, exists := stats.tokensPerSecond(model); exists {
+			lines = append(lines, fmt.Sprintf("  - %d prompt tokens, %d generated tokens (%.2f tokens/s on average)", ms.promptTokens, ms.generatedTokens, tps))
+		}
+
+		if ms.lastError != nil {
+			lines = append(lines, fmt.Sprintf("  - last error (%s): <i>%s</i>", ms.lastErrorAt.Format(time.DateTime), escapeForHTML(*ms.lastError)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// generate a message about the pending requests of given user
+func queueMessage(userID int64) string {
+	queued, inFlight := tracker.snapshot()
+
+	// estimate the remaining time of in-flight requests
+	var wait time.Duration
+	estimated := true
+	for _, r := range inFlight {
+		if avg, exists := stats.averageDuration(r.model); exists {
+			if remaining := avg - time.Since(r.startedProcessingAt); remaining > 0 {
+				wait += remaining
+			}
+		} else {
+			estimated = false
+		}
+	}
+
+	lines := []string{}
+	for i, r := range queued {
+		if r.userID == userID {
+			estimation := "unknown"
+			if estimated {
+				estimation = fmt.Sprintf("~%s", wait.Round(time.Second))
+			}
+
+			lines = append(lines, fmt.Sprintf("%s: <b>%s</b> (position: %d/%d, estimated wait: %s)", r, escapeForHTML(r.model.String()), i+1, len(queued), estimation))
+		}
+
+		if avg, exists := stats.averageDuration(r.model); exists {
+			wait += avg
+		} else {
+			estimated = false
+		}
+	}
+
+	for _, r := range inFlight {
+		if r.userID == userID {
+			lines = append(lines, fmt.Sprintf("%s: <b>%s</b> (processing for %s)", r, escapeForHTML(r.model.String()), time.Since(r.startedProcessingAt).Round(time.Second)))
+		}
+	}
+
+	if len(lines) == 0 {
+		return "You have no pending requests."
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// set (or reset, or show) the max number of tokens of given chat, and generate a message about it
+func maxTokensMessage(conf config, chatID int64, args string) string {
+	switch args {
+	case "":
+		if settings := getChatSettings(chatID); settings.MaxTokens != nil {
+			return fmt.Sprintf("Max tokens of this chat: <b>%d</b>", *settings.MaxTokens)
+		}
+		return "Max tokens of this chat is not set, so each model's <code>max_tokens</code> will be used."
+	case "reset":
+		updateChatSettings(chatID, func(settings *chatSettings) {
+			settings.MaxTokens = nil
+		})
+		return "Max tokens of this chat was reset."
+	}
+
+	tokens, err := strconv.Atoi(args)
+	if err != nil || tokens <= 0 {
+		return fmt.Sprintf("Usage: %s [NUMBER_OF_TOKENS | reset]", CommandMaxTokens)
+	}
+
+	maxTokens := DefaultDirectiveMaxTokens
+	if conf.DirectiveBounds.MaxTokens != nil {
+		maxTokens = *conf.DirectiveBounds.MaxTokens
+	}
+	tokens = min(tokens, maxTokens)
+
+	updateChatSettings(chatID, func(settings *chatSettings) {
+		settings.MaxTokens = &tokens
+	})
+
+	return fmt.Sprintf("Max tokens of this chat was set to <b>%d</b>.", tokens)
+}
+
+// set (or reset, or show) whether to show generation info in given chat, and generate a message about it
+func generationInfoMessage(chatID int64, args string) string {
+	on, off := true, false
+
+	var show *bool
+	switch args {
+	case "":
+		if show := getChatSettings(chatID).ShowGenerationInfo; show != nil {
+			return fmt.Sprintf("Generation info of this chat: <b>%t</b>", *show)
+		}
+		return "Generation info of this chat is not set, so each model's <code>show_generation_info</code> will be used."
+	case "on":
+		show = &on
+	case "off":
+		show = &off
+	case "reset":
+	default:
+		return fmt.Sprintf("Usage: %s [on | off | reset]", CommandInfo)
+	}
+
+	updateChatSettings(chatID, func(settings *chatSettings) {
+		settings.ShowGenerationInfo = show
+	})
+
+	if show == nil {
+		return "Generation info of this chat was reset."
+	}
+	return fmt.Sprintf("Generation info of this chat was turned <b>%s</b>.", args)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// max number of comparisons kept in the database (older ones are pruned, and cannot be voted anymore)
+const MaxComparisons = 100
+
+// a side-by-side comparison of models (persisted in the database)
+type comparison struct {
+	Models    []string          `json:"models"`               // model names in the order of labels (A, B, C, ...)
+	Votes     map[string]string `json:"votes"`                // voted labels, keyed by user id
+	CreatedAt time.Time         `json:"created_at,omitempty"` // (for pruning old ones)
+}
+
+// returns the label of given index (0 => "A", 1 => "B", ...)
+func comparisonLabel(index int) string {
+	return string(rune('A' + index))
+}
+
+// handle `/compare` command: run the prompt on all enabled models, and post anonymized outputs for voting
+func handleCompareCommand(conf config, bot *tg.Bot, reqQueue *priorityQueue, args string, message tg.Message) {
+	target := args
+	if message.HasReplyTo() && message.ReplyToMessage.HasText() {
+		target = *message.ReplyToMessage.Text
+	}
+	if target == "" {
+		sendReply(conf, bot, message, fmt.Sprintf("Usage: %s [PROMPT] (or reply to a message)", CommandCompare))
+		return
+	}
+
+	// (models which are not allowed to the user are excluded, without revealing them)
+	models := []model{}
+	for _, m := range enabledModelsInChat(conf, message.Chat.ID) {
+		if m.allowsUser(conf, message.From) {
+			models = append(models, m)
+		}
+	}
+	if len(models) < 2 {
+		sendReply(conf, bot, message, "At least 2 enabled models are needed for comparison.")
+		return
+	}
+	rand.Shuffle(len(models), func(i, j int) {
+		models[i], models[j] = models[j], models[i]
+	})
+
+	comparisonID := strconv.FormatInt(time.Now().UnixNano(), 36)
+	names := []string{}
+	for _, model := range models {
+		names = append(names, model.String())
+	}
+	if err := db.update(func(data *dbData) {
+		if data.Comparisons == nil {
+			data.Comparisons = map[string]comparison{}
+		}
+		data.Comparisons[comparisonID] = comparison{
+			Models:    names,
+			Votes:     map[string]string{},
+			CreatedAt: time.Now(),
+		}
+		pruneComparisons(data.Comparisons)
+	}); err != nil {
+		log.Printf("Error: failed to save comparison: %s", err)
+	}
+
+	text, options := parseDirectives(conf, target)
+	originalText := escapeForShell(text)
+
+	group := &requestGroup{
+		expected:     len(models),
+		results:      map[int]string{},
+		comparisonID: comparisonID,
+	}
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, &originalText, nil, options, group, nil, message)
+	}
+}
+
+// remove the oldest comparisons over `MaxComparisons` (votes for them are kept in the leaderboard)
+func pruneComparisons(comparisons map[string]comparison) {
+	for len(comparisons) > MaxComparisons {
+		oldest := ""
+		for id, c := range comparisons {
+			if oldest == "" || c.CreatedAt.Before(comparisons[oldest].CreatedAt) {
+				oldest = id
+			}
+		}
+		delete(comparisons, oldest)
+	}
+}
+
+// deliver the anonymized outputs of a comparison (one message per labelled output), with vote buttons on the last one
+func deliverComparison(conf config, bot *tg.Bot, request request, sections []string, labels []string) {
+	buttons := []tg.InlineKeyboardButton{}
+	for _, label := range labels {
+		data := callbackDataVotePrefix + request.group.comparisonID + "/" + label
+		buttons = append(buttons, tg.InlineKeyboardButton{
+			Text:         "👍 " + label,
+			CallbackData: &data,
+		})
+	}
+
+	messages := []string{}
+	for _, section := range sections {
+		messages = append(messages, splitMessage(section, MaxMessageLength)...)
+	}
+
+	deliverMessages(conf, bot, request, messages, &tg.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg.InlineKeyboardButton{buttons},
+	})
+}
+
+// record a vote of given callback query (with data: "vote/COMPARISON_ID/LABEL"), and return the answer text
+func handleVote(query tg.CallbackQuery, data string) (answer string) {
+	comparisonID, label, _ := strings.Cut(data, "/")
+	userID := strconv.FormatInt(query.From.ID, 10)
+
+	if err := db.update(func(data *dbData) {
+		c, exists := data.Comparisons[comparisonID]
+		if !exists {
+			answer = "This comparison is no longer available."
+			return
+		}
+
+		if len(label) != 1 || label[0] < 'A' || int(label[0]-'A') >= len(c.Models) {
+			answer = "Invalid vote."
+			return
+		}
+		index := int(label[0] - 'A')
+
+		if data.Leaderboard == nil {
+			data.Leaderboard = map[string]int{}
+		}
+
+		// cancel the previous vote of this user
+		if previous, voted := c.Votes[userID]; voted {
+			data.Leaderboard[c.Models[int(previous[0]-'A')]]--
+		}
+
+		c.Votes[userID] = label
+		data.Leaderboard[c.Models[index]]++
+
+		answer = fmt.Sprintf("You voted for %s: %s", label, c.Models[index])
+	}); err != nil {
+		log.Printf("Error: failed to save vote: %s", err)
+	}
+
+	return answer
+}
+
+// generate a leaderboard message of compared models
+func leaderboardMessage() string {
+	type entry struct {
+		model string
+		votes int
+	}
+
+	entries := []entry{}
+	db.read(func(data dbData) {
+		for model, votes := range data.Leaderboard {
+			entries = append(entries, entry{model, votes})
+		}
+	})
+	if len(entries) == 0 {
+		return fmt.Sprintf("No votes yet. Compare models with %s.", CommandCompare)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].votes == entries[j].votes {
+			return entries[i].model < entries[j].model
+		}
+		return entries[i].votes > entries[j].votes
+	})
+
+	lines := []string{"<b>Leaderboard</b>"}
+	for i, e := range entries {
+		lines = append(lines, fmt.Sprintf("%d. %s: %d vote(s)", i+1, escapeForHTML(e.model), e.votes))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPruneComparisons(t *testing.T) {
+	comparisons := map[string]comparison{}
+	createdAt := time.Now()
+	for i := range MaxComparisons + 5 {
+		comparisons[strconv.Itoa(i)] = comparison{CreatedAt: createdAt.Add(time.Duration(i) * time.Second)}
+	}
+
+	pruneComparisons(comparisons)
+
+	if len(comparisons) != MaxComparisons {
+		t.Errorf("comparisons should be pruned to %d, got %d", MaxComparisons, len(comparisons))
+	}
+	for i := range 5 {
+		if _, exists := comparisons[strconv.Itoa(i)]; exists {
+			t.Errorf("the oldest comparison #%d should be pruned", i)
+		}
+	}
+	if _, exists := comparisons[strconv.Itoa(MaxComparisons+4)]; !exists {
+		t.Errorf("the newest comparison should be kept")
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// a turn of conversation (persisted in the database)
+type conversationTurn struct {
+	Model     string `json:"model"` // name of the model which generated the reply
+	User      string `json:"user"`
+	Assistant string `json:"assistant"`
+
+	Summary bool `json:"summary,omitempty"` // whether it is a summary of older turns
+}
+
+// returns recent conversation turns of given chat with given model
+func conversationHistory(conf config, chatID int64, model model) (turns []conversationTurn) {
+	if conf.ConversationTurns <= 0 || chatID == 0 {
+		return nil
+	}
+
+	name := model.String()
+	var summary *conversationTurn
+	db.read(func(data dbData) {
+		for _, turn := range data.Conversations[chatID] {
+			if turn.Model == name {
+				if turn.Summary {
+					summarized := turn
+					summary = &summarized
+				} else {
+					turns = append(turns, turn)
+				}
+			}
+		}
+	})
+
+	if len(turns) > conf.ConversationTurns {
+		turns = turns[len(turns)-conf.ConversationTurns:]
+	}
+
+	// (summary of older turns comes first)
+	if summary != nil {
+		turns = append([]conversationTurn{*summary}, turns...)
+	}
+
+	return turns
+}
+
+// append a conversation turn of given request and its generated reply
+func appendConversationTurn(ctx context.Context, conf config, request request, generated string) {
+	// (comparisons, answers with retrieved documents, summaries of documents, and inline queries are not remembered)
+	if conf.ConversationTurns <= 0 || request.targetChatID == 0 || request.group.isComparison() || len(request.extra.sources) > 0 || len(request.extra.documentChunks) > 0 || request.extra.inlineQueryID != "" {
+		return
+	}
+
+	var user string
+	if request.originalText != nil && request.commentText != nil {
+		user = fmt.Sprintf("%s\n\n%s", *request.commentText, *request.originalText)
+	} else if request.originalText != nil {
+		user = *request.originalText
+	} else if request.commentText != nil {
+		user = *request.commentText
+	}
+
+	chatID, model := request.targetChatID, request.model
+	if err := db.update(func(data *dbData) {
+		if data.Conversations == nil {
+			data.Conversations = map[int64][]conversationTurn{}
+		}
+
+		data.Conversations[chatID] = append(data.Conversations[chatID], conversationTurn{
+			Model:     model.String(),
+			User:      user,
+			Assistant: escapeForShell(generated),
+		})
+	}); err != nil {
+		log.Printf("Error: failed to save conversation of chat %d: %s", chatID, err)
+		return
+	}
+
+	// when the turns exceed `conversation_turns`, summarize older ones before dropping them
+	// (all but the recent ones, so that it does not happen on every turn)
+	turns := allConversationTurns(chatID, model)
+	count := 0
+	for _, turn := range turns {
+		if !turn.Summary {
+			count++
+		}
+	}
+	if count <= conf.ConversationTurns {
+		return
+	}
+
+	log.Printf(">>> conversation of chat %d exceeded %d turn(s) with model: %s", chatID, conf.ConversationTurns, model)
+
+	older := turns[:len(turns)-min(unsummarizedTurns, conf.ConversationTurns)]
+	if err := summarizeConversationTurns(ctx, conf, chatID, model, older); err != nil {
+		log.Printf("Error: failed to summarize conversation, dropping older turns: %s", err)
+
+		dropConversationTurns(chatID, model, conf.ConversationTurns)
+	}
+}
+
+// returns all conversation turns of given chat with given model, in order (its summary comes first, if any)
+func allConversationTurns(chatID int64, model model) (turns []conversationTurn) {
+	name := model.String()
+	db.read(func(data dbData) {
+		for _, turn := range data.Conversations[chatID] {
+			if turn.Model == name {
+				turns = append(turns, turn)
+			}
+		}
+	})
+
+	return turns
+}
+
+// drop older turns of given chat with given model, keeping only `count` recent ones (and its summary)
+func dropConversationTurns(chatID int64, model model, count int) {
+	name := model.String()
+	if err := db.update(func(data *dbData) {
+		turns := data.Conversations[chatID]
+
+		kept := []conversationTurn{}
+		recent := 0
+		for i := len(turns) - 1; i >= 0; i-- {
+			if turns[i].Model == name && !turns[i].Summary {
+				if recent >= count {
+					continue
+				}
+				recent++
+			}
+			kept = append([]conversationTurn{turns[i]}, kept...)
+		}
+
+		data.Conversations[chatID] = kept
+	}); err != nil {
+		log.Printf("Error: failed to save conversation of chat %d: %s", chatID, err)
+	}
+}
+
+// replace the oldest `count` turns of given chat with given model, with a (summary) turn
+func replaceConversationTurns(chatID int64, model model, count int, replacement conversationTurn) {
+	name := model.String()
+	if err := db.update(func(data *dbData) {
+		kept := []conversationTurn{}
+		replaced := 0
+		for _, turn := range data.Conversations[chatID] {
+			if turn.Model == name && replaced < count {
+				if replaced == 0 {
+					kept = append(kept, replacement)
+				}
+				replaced++
+				continue
+			}
+			kept = append(kept, turn)
+		}
+
+		data.Conversations[chatID] = kept
+	}); err != nil {
+		log.Printf("Error: failed to save conversation of chat %d: %s", chatID, err)
+	}
+}
+
+// clear conversation history of given chat
+func resetConversation(chatID int64) error {
+	return db.update(func(data *dbData) {
+		delete(data.Conversations, chatID)
+	})
+}
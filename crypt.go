@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// environment variables for the key of encrypted config files
+const (
+	EnvConfigKeyFile    = "LLAMAFILES_BOT_CONFIG_KEY_FILE"
+	EnvConfigPassphrase = "LLAMAFILES_BOT_CONFIG_PASSPHRASE"
+)
+
+// for encrypted config files
+//
+// files are encrypted with AES-256-GCM, and keys are derived with PBKDF2-HMAC-SHA256:
+//
+//	magic (8 bytes) + salt (16 bytes) + nonce (12 bytes) + ciphertext
+const (
+	encryptedConfigMagic   = "LFBENC1\n"
+	encryptedConfigSaltLen = 16
+	pbkdf2Iterations       = 600000
+)
+
+// CLI subcommands for encrypted config files
+const (
+	SubcommandEncrypt = "encrypt"
+	SubcommandDecrypt = "decrypt"
+)
+
+// read given file, and decrypt it if it is encrypted
+func readMaybeEncryptedFile(path string) ([]byte, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil || !isEncrypted(bytes) {
+		return bytes, err
+	}
+
+	secret, err := configSecret("")
+	if err != nil {
+		return nil, fmt.Errorf("%s is encrypted: %s", path, err)
+	}
+	if bytes, err = decryptConfig(bytes, secret); err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %s", path, err)
+	}
+
+	return bytes, nil
+}
+
+// check if given bytes are encrypted with `encryptConfig`
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedConfigMagic))
+}
+
+// returns the secret from given key file, or environment variables
+func configSecret(keyFile string) ([]byte, error) {
+	if keyFile == "" {
+		keyFile = os.Getenv(EnvConfigKeyFile)
+	}
+	if keyFile != "" {
+		secret, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %s", err)
+		}
+		if secret = bytes.TrimSpace(secret); len(secret) == 0 {
+			return nil, fmt.Errorf("key file %s is empty", keyFile)
+		}
+		return secret, nil
+	}
+
+	if passphrase := os.Getenv(EnvConfigPassphrase); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	return nil, fmt.Errorf("no key was given (set `%s` or `%s`)", EnvConfigKeyFile, EnvConfigPassphrase)
+}
+
+// encrypt given plaintext with a key derived from given secret
+func encryptConfig(plaintext, secret []byte) ([]byte, error) {
+	salt := make([]byte, encryptedConfigSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := configAEAD(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := append(append([]byte(encryptedConfigMagic), salt...), nonce...)
+
+	return aead.Seal(header, nonce, plaintext, header[:len(encryptedConfigMagic)]), nil
+}
+
+// decrypt given data (encrypted with `encryptConfig`) with given secret
+func decryptConfig(data, secret []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return nil, fmt.Errorf("not encrypted")
+	}
+	data = data[len(encryptedConfigMagic):]
+
+	if len(data) < encryptedConfigSaltLen {
+		return nil, fmt.Errorf("data is too short")
+	}
+	salt, data := data[:encryptedConfigSaltLen], data[encryptedConfigSaltLen:]
+
+	aead, err := configAEAD(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("data is too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(encryptedConfigMagic))
+	if err != nil {
+		return nil, fmt.Errorf("wrong key, or corrupted data")
+	}
+
+	return plaintext, nil
+}
+
+// returns an AES-256-GCM cipher with a key derived from given secret and salt
+func configAEAD(secret, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2SHA256(secret, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// PBKDF2 (RFC 8018) with HMAC-SHA256
+func pbkdf2SHA256(secret, salt []byte, iterations, keyLen int) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, string(secret), salt, iterations, keyLen)
+}
+
+// encrypt or decrypt a config file:
+//
+//	$ bot encrypt --key-file key.txt config.json > config.json.enc
+//	$ bot decrypt --key-file key.txt config.json.enc > config.json
+//
+// (without `--key-file`, the key is read from environment variables)
+func runCrypt(name string, args []string) int {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	keyFile := flags.String("key-file", "", fmt.Sprintf("file which contains the key (default: `%s`, or `%s`)", EnvConfigKeyFile, EnvConfigPassphrase))
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage:\n\n  $ %s %s [FLAGS] FILEPATH\n\nFlags:\n\n", os.Args[0], name)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	path := flags.Arg(0)
+
+	secret, err := configSecret(*keyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	input, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %s\n", path, err)
+		return 1
+	}
+
+	var output []byte
+	if name == SubcommandEncrypt {
+		if isEncrypted(input) {
+			fmt.Fprintf(os.Stderr, "%s is already encrypted\n", path)
+			return 1
+		}
+		output, err = encryptConfig(input, secret)
+	} else {
+		output, err = decryptConfig(input, secret)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to %s %s: %s\n", name, path, err)
+		return 1
+	}
+
+	if _, err := os.Stdout.Write(output); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
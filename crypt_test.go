@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// (test vectors of RFC 7914, and the ones of RFC 6070 for HMAC-SHA256)
+	tests := []struct {
+		secret     string
+		salt       string
+		iterations int
+		keyLen     int
+		expected   string
+	}{
+		{"password", "salt", 1, 32, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, 32, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, 32, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwd", "salt", 1, 64, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, 64, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+	}
+
+	for _, test := range tests {
+		key, err := pbkdf2SHA256([]byte(test.secret), []byte(test.salt), test.iterations, test.keyLen)
+		if err != nil {
+			t.Errorf("failed to derive key for '%s': %s", test.secret, err)
+		} else if hex.EncodeToString(key) != test.expected {
+			t.Errorf("wrong key for '%s' (%d iterations): %x", test.secret, test.iterations, key)
+		}
+	}
+}
+
+func TestEncryptConfig(t *testing.T) {
+	plaintext := []byte(`{"telegram_bot_token": "123456:abcdefg"}`)
+	secret := []byte("some secret")
+
+	encrypted, err := encryptConfig(plaintext, secret)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %s", err)
+	}
+	if !isEncrypted(encrypted) {
+		t.Errorf("encrypted data should start with the magic")
+	}
+	if bytes.Contains(encrypted, plaintext) {
+		t.Errorf("encrypted data should not contain the plaintext")
+	}
+
+	if decrypted, err := decryptConfig(encrypted, secret); err != nil {
+		t.Errorf("failed to decrypt: %s", err)
+	} else if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("decrypted data differs from the plaintext: %s", decrypted)
+	}
+
+	if _, err := decryptConfig(encrypted, []byte("wrong secret")); err == nil {
+		t.Errorf("decrypting with a wrong secret should fail")
+	}
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 0xff
+	if _, err := decryptConfig(tampered, secret); err == nil {
+		t.Errorf("decrypting tampered data should fail")
+	}
+
+	if _, err := decryptConfig(plaintext, secret); err == nil {
+		t.Errorf("decrypting unencrypted data should fail")
+	}
+	if _, err := decryptConfig([]byte(encryptedConfigMagic+"short"), secret); err == nil {
+		t.Errorf("decrypting truncated data should fail")
+	}
+}
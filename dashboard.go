@@ -0,0 +1,315 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaults of the web dashboard
+const (
+	DefaultDashboardListenAddress = "127.0.0.1:8080"
+	DashboardRecentRequests       = 20
+	DashboardRefreshSeconds       = 5
+	MaxRecentLogLines             = 500
+)
+
+// config for the web dashboard
+type dashboardConfig struct {
+	ListenAddress string `json:"listen_address,omitempty"` // (default: "127.0.0.1:8080")
+	Username      string `json:"username"`                 // for basic authentication
+	Password      string `json:"password"`                 // for basic authentication
+}
+
+// writer which keeps recent lines of logs (for tailing them in the dashboard)
+type recentLogWriter struct {
+	sync.Mutex
+
+	lines   []string
+	partial string
+}
+
+// recent lines of logs
+var recentLogs = &recentLogWriter{}
+
+// keep lines of given bytes
+func (w *recentLogWriter) Write(p []byte) (n int, err error) {
+	w.Lock()
+	defer w.Unlock()
+
+	lines := strings.Split(w.partial+string(p), "\n")
+	w.partial = lines[len(lines)-1]
+
+	w.lines = append(w.lines, lines[:len(lines)-1]...)
+	if len(w.lines) > MaxRecentLogLines {
+		w.lines = w.lines[len(w.lines)-MaxRecentLogLines:]
+	}
+
+	return len(p), nil
+}
+
+// returns recent lines of logs
+func (w *recentLogWriter) tail() []string {
+	w.Lock()
+	defer w.Unlock()
+
+	return append([]string{}, w.lines...)
+}
+
+// a row of models in the dashboard
+type dashboardModel struct {
+	Index    int
+	Name     string
+	Disabled bool
+	Toggle   bool // whether it can be toggled (models disabled in config cannot)
+
+	Generations int
+	Failures    int
+	Average     string
+	LastError   string
+}
+
+// a row of requests in the dashboard
+type dashboardRequest struct {
+	ID     uint64
+	Model  string
+	User   string
+	Status string
+	Since  string
+}
+
+// data for the dashboard template
+type dashboardData struct {
+	Uptime    string
+	Overflows int
+
+	Queued   []dashboardRequest
+	InFlight []dashboardRequest
+	Recent   []usageRecord
+
+	Models []dashboardModel
+
+	RefreshSeconds int
+}
+
+// template of the dashboard
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.RefreshSeconds}}">
+<title>telegram-llamafiles-bot</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+.disabled { color: #999; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>telegram-llamafiles-bot</h1>
+<p>Uptime: {{.Uptime}}, queue overflowed {{.Overflows}} time(s) | <a href="/logs">logs</a></p>
+
+<h2>Models</h2>
+<table>
+<tr><th>#</th><th>Model</th><th>Generated</th><th>Failed</th><th>Average</th><th>Last error</th><th></th></tr>
+{{range .Models}}<tr{{if .Disabled}} class="disabled"{{end}}>
+<td>{{.Index}}</td><td>{{.Name}}</td><td>{{.Generations}}</td><td>{{.Failures}}</td><td>{{.Average}}</td><td class="error">{{.LastError}}</td>
+<td>{{if .Toggle}}<form method="post" action="/toggle"><input type="hidden" name="index" value="{{.Index}}"><button>{{if .Disabled}}Enable{{else}}Disable{{end}}</button></form>{{else}}disabled in config{{end}}</td>
+</tr>
+{{end}}</table>
+
+<h2>Queue</h2>
+<table>
+<tr><th>#</th><th>Model</th><th>User</th><th>Status</th><th>Since</th></tr>
+{{range .InFlight}}<tr><td>{{.ID}}</td><td>{{.Model}}</td><td>{{.User}}</td><td>{{.Status}}</td><td>{{.Since}}</td></tr>
+{{end}}{{range .Queued}}<tr><td>{{.ID}}</td><td>{{.Model}}</td><td>{{.User}}</td><td>{{.Status}}</td><td>{{.Since}}</td></tr>
+{{else}}<tr><td colspan="5">(no queued requests)</td></tr>
+{{end}}</table>
+
+<h2>Recent Requests</h2>
+<table>
+<tr><th>Time</th><th>Model</th><th>User</th><th>Chat</th><th>Duration</th><th>Result</th></tr>
+{{range .Recent}}<tr><td>{{.At.Format "2006-01-02 15:04:05"}}</td><td>{{.Model}}</td><td>{{if .Username}}{{.Username}}{{else}}{{.UserID}}{{end}}</td><td>{{.ChatID}}</td><td>{{.DurationMillis}}ms</td><td>{{if .Failed}}<span class="error">failed</span>{{else}}ok{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// start the web dashboard in background, if configured
+func startDashboard(conf config) {
+	if conf.Dashboard == nil {
+		return
+	}
+
+	if conf.Dashboard.Username == "" || conf.Dashboard.Password == "" {
+		log.Printf("Error: `username` and `password` of dashboard are required, not starting it")
+		return
+	}
+
+	// (keep recent logs for tailing them)
+	addLogOutput(recentLogs)
+
+	addr := dashboardListenAddress(conf)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dashboardTemplate.Execute(w, dashboardDataOf(liveConfig(conf))); err != nil {
+			log.Printf("Error: failed to render dashboard: %s", err)
+		}
+	})
+	mux.HandleFunc("/toggle", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !sameOrigin(r) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		conf := liveConfig(conf)
+
+		index, err := strconv.Atoi(r.FormValue("index"))
+		if err != nil || index < 0 || index >= len(conf.Models) || conf.Models[index].Disabled {
+			http.Error(w, "invalid model index", http.StatusBadRequest)
+			return
+		}
+
+		model := conf.Models[index]
+		if err := setModelDisabled(model, !model.isDisabled()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+	})
+	mux.HandleFunc("/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Refresh", strconv.Itoa(DashboardRefreshSeconds))
+		for _, line := range recentLogs.tail() {
+			fmt.Fprintln(w, line)
+		}
+	})
+
+	log.Printf(">>> starting dashboard on %s", addr)
+
+	go func() {
+		if err := http.ListenAndServe(addr, basicAuth(conf.Dashboard.Username, conf.Dashboard.Password, mux)); err != nil {
+			log.Printf("Error: dashboard stopped: %s", err)
+		}
+	}()
+}
+
+// wrap given handler with basic authentication
+func basicAuth(username, password string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(u), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="dashboard"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// check if given request was sent from the dashboard itself (against cross-site requests)
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		origin = r.Header.Get("Referer")
+	}
+	if origin == "" {
+		return true // (not sent by a browser)
+	}
+
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// build data for the dashboard
+func dashboardDataOf(conf config) (data dashboardData) {
+	stats.Lock()
+	data.Uptime = time.Since(stats.startedAt).Round(time.Second).String()
+	data.Overflows = stats.overflows
+	stats.Unlock()
+
+	queued, inFlight := tracker.snapshot()
+	for _, r := range inFlight {
+		data.InFlight = append(data.InFlight, dashboardRequestOf(r, "processing", r.startedProcessingAt))
+	}
+	for _, r := range queued {
+		data.Queued = append(data.Queued, dashboardRequestOf(r, "queued", r.enqueuedAt))
+	}
+
+	db.read(func(d dbData) {
+		for i := len(d.Usage) - 1; i >= 0 && len(data.Recent) < DashboardRecentRequests; i-- {
+			data.Recent = append(data.Recent, d.Usage[i])
+		}
+	})
+
+	for i, model := range conf.Models {
+		stats.Lock()
+		ms := *stats.model(model)
+		stats.Unlock()
+
+		row := dashboardModel{
+			Index:       i,
+			Name:        model.String(),
+			Disabled:    model.isDisabled(),
+			Toggle:      !model.Disabled,
+			Generations: ms.generations,
+			Failures:    ms.failures,
+			Average:     "-",
+		}
+		if duration, exists := stats.averageDuration(model); exists {
+			row.Average = msecsToString(duration.Milliseconds()) + "s"
+		}
+		if ms.lastError != nil {
+			row.LastError = fmt.Sprintf("(%s) %s", ms.lastErrorAt.Format(time.DateTime), *ms.lastError)
+		}
+		data.Models = append(data.Models, row)
+	}
+
+	data.RefreshSeconds = DashboardRefreshSeconds
+
+	return data
+}
+
+// build a row of given request in the dashboard
+func dashboardRequestOf(r request, status string, since time.Time) dashboardRequest {
+	user := strconv.FormatInt(r.userID, 10)
+	if r.username != nil {
+		user = *r.username
+	}
+
+	return dashboardRequest{
+		ID:     r.id,
+		Model:  r.model.String(),
+		User:   user,
+		Status: status,
+		Since:  time.Since(since).Round(time.Second).String(),
+	}
+}
+
+// returns the listen address of the dashboard in given config
+func dashboardListenAddress(conf config) string {
+	if conf.Dashboard == nil || conf.Dashboard.ListenAddress == "" {
+		return DefaultDashboardListenAddress
+	}
+	return conf.Dashboard.ListenAddress
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// data stored in the database file
+type dbData struct {
+	LastUpdateID  int64            `json:"last_update_id,omitempty"`
+	LastUpdateIDs map[string]int64 `json:"last_update_ids,omitempty"` // of additional bots, keyed by their names
+
+	Undelivered []undeliveredMessage `json:"undelivered,omitempty"`
+
+	Chats         map[int64]chatSettings       `json:"chats,omitempty"`         // keyed by chat id
+	Conversations map[int64][]conversationTurn `json:"conversations,omitempty"` // keyed by chat id
+
+	Comparisons map[string]comparison `json:"comparisons,omitempty"` // keyed by comparison id
+	Leaderboard map[string]int        `json:"leaderboard,omitempty"` // votes, keyed by model name
+
+	SentReplies []sentReply `json:"sent_replies,omitempty"` // recent replies, for regenerating them
+
+	Usage []usageRecord `json:"usage,omitempty"` // recent generations, for `/stats`
+
+	Quotas  map[int64]quotaUsage `json:"quotas,omitempty"`  // keyed by user id
+	Credits map[int64]int64      `json:"credits,omitempty"` // purchased generation time in milliseconds, keyed by user id
+
+	Payments map[string]int64 `json:"payments,omitempty"` // unix times of processed payments, keyed by their telegram payment charge ids (for not adding credits twice)
+
+	DisabledModels []string `json:"disabled_models,omitempty"` // names of models disabled at runtime
+}
+
+// a generated message which failed to be delivered
+type undeliveredMessage struct {
+	Bot       string `json:"bot,omitempty"` // name of the additional bot (empty for the main one)
+	ChatID    int64  `json:"chat_id"`
+	MessageID int64  `json:"message_id"` // id of the message to reply to
+	Text      string `json:"text"`       // in HTML parse mode
+
+	ReplyMarkup *tg.InlineKeyboardMarkup `json:"reply_markup,omitempty"` // (eg. vote buttons of comparisons)
+}
+
+// database which persists data in a JSON file
+//
+// NOTE: if its path is empty, data will be kept only in memory
+type database struct {
+	sync.Mutex
+
+	path string
+	data dbData
+}
+
+// global database
+var db = &database{}
+
+// open (or create) the database file at given path
+func openDatabase(path string) error {
+	db.Lock()
+	defer db.Unlock()
+
+	db.path = path
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // will be created on the first save
+		}
+		return err
+	}
+
+	return json.Unmarshal(bytes, &db.data)
+}
+
+// read data with given function
+func (d *database) read(fn func(data dbData)) {
+	d.Lock()
+	defer d.Unlock()
+
+	fn(d.data)
+}
+
+// update data with given function, and save it to the file
+func (d *database) update(fn func(data *dbData)) error {
+	d.Lock()
+	defer d.Unlock()
+
+	fn(&d.data)
+
+	return d.save()
+}
+
+// save data to the file atomically
+//
+// NOTE: should be called while locked
+func (d *database) save() error {
+	if d.path == "" {
+		return nil
+	}
+
+	bytes, err := json.MarshalIndent(d.data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(d.path), filepath.Base(d.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), d.path)
+}
+
+// save the id of a handled update of given bot (empty name for the main one), if it is the latest one
+func saveLastUpdateID(botName string, updateID int64) {
+	var last int64
+	db.read(func(data dbData) {
+		last = lastUpdateIDOf(data, botName)
+	})
+	if updateID <= last {
+		return
+	}
+
+	if err := db.update(func(data *dbData) {
+		if botName == "" {
+			data.LastUpdateID = max(data.LastUpdateID, updateID)
+		} else {
+			if data.LastUpdateIDs == nil {
+				data.LastUpdateIDs = map[string]int64{}
+			}
+			data.LastUpdateIDs[botName] = max(data.LastUpdateIDs[botName], updateID)
+		}
+	}); err != nil {
+		log.Printf("Error: failed to save the last update id: %s", err)
+	}
+}
+
+// returns the offset of given bot for polling updates, next to the last handled one
+func nextUpdateOffset(botName string) (offset int64) {
+	db.read(func(data dbData) {
+		if last := lastUpdateIDOf(data, botName); last > 0 {
+			offset = last + 1
+		}
+	})
+	return offset
+}
+
+// returns the last handled update id of given bot (empty name for the main one)
+func lastUpdateIDOf(data dbData, botName string) int64 {
+	if botName == "" {
+		return data.LastUpdateID
+	}
+	return data.LastUpdateIDs[botName]
+}
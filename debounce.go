@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// debounce policies
+const (
+	DebounceLatest   = "latest"   // only the latest message is handled (default)
+	DebounceCoalesce = "coalesce" // all messages are joined into one
+)
+
+// message which is waiting for the debounce window to pass
+type debouncedMessage struct {
+	repliedTo *string // text of the replied-to message (nil if none; the text is a comment on it)
+	text      string
+	options   generationOptions
+	message   tg.Message // (the latest one, for replying to)
+	timer     *time.Timer
+	count     int
+}
+
+// debounced messages, keyed by chat and user ids
+var debouncedMessages = map[string]*debouncedMessage{}
+var debouncedMessagesLock sync.Mutex
+
+// default window of merge mode (`/merge`)
+const DefaultMergeWindowSeconds = 60
+
+// returns the key of debounced messages for given message
+func debounceKey(message tg.Message) string {
+	return fmt.Sprintf("%d/%d", message.Chat.ID, message.From.ID)
+}
+
+// enqueue requests of given message (or comment on `repliedTo`) after `debounce_window_seconds`,
+// ignoring (or coalescing with) earlier messages from the same user in the same chat within the window
+//
+// in merge mode (`/merge`), messages are always coalesced within `merge_window_seconds` (or until `/go`)
+//
+// (when coalesced, the latest replied-to text is kept; if neither is configured, requests are enqueued immediately)
+func debounceRequests(conf config, bot *tg.Bot, reqQueue *priorityQueue, repliedTo *string, text string, options generationOptions, message tg.Message) {
+	window := time.Duration(conf.DebounceWindowSeconds) * time.Second
+	coalesce := conf.DebouncePolicy == DebounceCoalesce
+	if merge := getChatSettings(message.Chat.ID).Merge; merge != nil && *merge {
+		window, coalesce = mergeWindow(conf), true
+	}
+
+	if window <= 0 || message.From == nil {
+		enqueueDebouncedRequests(conf, bot, reqQueue, repliedTo, text, options, message)
+		return
+	}
+
+	key := debounceKey(message)
+
+	debouncedMessagesLock.Lock()
+	defer debouncedMessagesLock.Unlock()
+
+	if pending, exists := debouncedMessages[key]; exists {
+		if coalesce {
+			pending.text += "\n\n" + text
+			if repliedTo != nil {
+				pending.repliedTo = repliedTo
+			}
+		} else {
+			pending.repliedTo, pending.text = repliedTo, text
+		}
+		pending.options = options
+		pending.message = message
+		pending.count++
+
+		pending.timer.Reset(window)
+		return
+	}
+
+	pending := &debouncedMessage{
+		repliedTo: repliedTo,
+		text:      text,
+		options:   options,
+		message:   message,
+		count:     1,
+	}
+	pending.timer = time.AfterFunc(window, func() {
+		defer recoverPanic(conf, bot, "flushing debounced messages")
+
+		flushDebouncedMessage(conf, bot, reqQueue, key, pending)
+	})
+	debouncedMessages[key] = pending
+}
+
+// enqueue requests of given debounced message, if it is not handled yet
+func flushDebouncedMessage(conf config, bot *tg.Bot, reqQueue *priorityQueue, key string, pending *debouncedMessage) {
+	debouncedMessagesLock.Lock()
+	if debouncedMessages[key] != pending { // (already handled, eg. when the timer was reset while firing)
+		debouncedMessagesLock.Unlock()
+		return
+	}
+	delete(debouncedMessages, key)
+	pending.timer.Stop()
+	repliedTo, text, options, message, count := pending.repliedTo, pending.text, pending.options, pending.message, pending.count
+	debouncedMessagesLock.Unlock()
+
+	if count > 1 {
+		log.Printf(">>> merged %d messages from user %d in chat %d", count, message.From.ID, message.Chat.ID)
+	}
+
+	enqueueDebouncedRequests(conf, bot, reqQueue, repliedTo, text, options, message)
+}
+
+// enqueue requests of given (debounced) text, as a comment if it has a replied-to text
+func enqueueDebouncedRequests(conf config, bot *tg.Bot, reqQueue *priorityQueue, repliedTo *string, text string, options generationOptions, message tg.Message) {
+	if repliedTo != nil {
+		enqueueRequests(conf, bot, reqQueue, repliedTo, &text, options, message)
+	} else {
+		enqueueRequests(conf, bot, reqQueue, &text, nil, options, message)
+	}
+}
+
+// enqueue requests of the messages held for given message's sender right away (for `/go`),
+// and return false if there is none
+func flushDebouncedMessages(conf config, bot *tg.Bot, reqQueue *priorityQueue, message tg.Message) bool {
+	if message.From == nil {
+		return false
+	}
+
+	key := debounceKey(message)
+
+	debouncedMessagesLock.Lock()
+	pending, exists := debouncedMessages[key]
+	debouncedMessagesLock.Unlock()
+
+	if !exists {
+		return false
+	}
+
+	flushDebouncedMessage(conf, bot, reqQueue, key, pending)
+
+	return true
+}
+
+// returns the window of merge mode
+func mergeWindow(conf config) time.Duration {
+	if conf.MergeWindowSeconds > 0 {
+		return time.Duration(conf.MergeWindowSeconds) * time.Second
+	}
+	return DefaultMergeWindowSeconds * time.Second
+}
+
+// turn on/off (or show) merge mode of given chat, and generate a message about it
+func mergeMessage(conf config, chatID int64, args string) string {
+	var merge bool
+	switch args {
+	case "":
+		current := getChatSettings(chatID).Merge
+		return fmt.Sprintf("Merge mode of this chat: <b>%t</b>", current != nil && *current)
+	case "on":
+		merge = true
+	case "off":
+		merge = false
+	default:
+		return fmt.Sprintf("Usage: %s [on | off]", CommandMerge)
+	}
+
+	updateChatSettings(chatID, func(settings *chatSettings) {
+		settings.Merge = &merge
+	})
+
+	if merge {
+		return fmt.Sprintf("Merge mode of this chat was turned <b>on</b>: messages will be merged into one prompt until %s (or for %s after the last one).", CommandGo, mergeWindow(conf))
+	}
+	return "Merge mode of this chat was turned <b>off</b>."
+}
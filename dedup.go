@@ -0,0 +1,47 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// recently received messages, keyed by hashes of their senders and contents
+var recentMessages = map[string]time.Time{}
+var recentMessagesLock sync.Mutex
+
+// check if given message is a duplicate of a recent one (same sender, same text) within `dedup_window_seconds`
+func isDuplicate(conf config, message tg.Message) bool {
+	if conf.DedupWindowSeconds <= 0 || message.From == nil || !message.HasText() {
+		return false
+	}
+
+	var replyToID int64
+	if message.HasReplyTo() {
+		replyToID = message.ReplyToMessage.MessageID
+	}
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%d/%d/%d/%s", message.Chat.ID, message.From.ID, replyToID, *message.Text))))
+
+	window := time.Duration(conf.DedupWindowSeconds) * time.Second
+	now := time.Now()
+
+	recentMessagesLock.Lock()
+	defer recentMessagesLock.Unlock()
+
+	// remove expired ones
+	for k, receivedAt := range recentMessages {
+		if now.Sub(receivedAt) > window {
+			delete(recentMessages, k)
+		}
+	}
+
+	if _, exists := recentMessages[key]; exists {
+		return true
+	}
+	recentMessages[key] = now
+
+	return false
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// default retry policy of sending messages
+const (
+	DefaultSendMaxAttempts                = 3
+	DefaultSendInitialBackoffMilliseconds = 1000
+)
+
+// default interval between outgoing messages
+const DefaultSendIntervalMilliseconds = 50
+
+// global limiter of outgoing messages
+//
+// it spaces outgoing sends/edits with an interval, and pauses all of them
+// when telegram responds with 429 (Too Many Requests) and `retry_after`
+type floodLimiter struct {
+	sync.Mutex
+
+	next time.Time // next time when a message can be sent
+}
+
+// global flood limiter
+var limiter = floodLimiter{}
+
+// wait until a message can be sent
+func (l *floodLimiter) wait(conf config) {
+	interval := DefaultSendIntervalMilliseconds * time.Millisecond
+	if conf.SendIntervalMilliseconds > 0 {
+		interval = time.Duration(conf.SendIntervalMilliseconds) * time.Millisecond
+	}
+
+	l.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(interval)
+	l.Unlock()
+
+	time.Sleep(wait)
+}
+
+// pause all outgoing messages if given response parameters has `retry_after`, and return the duration
+func (l *floodLimiter) pauseIfNeeded(params *tg.APIResponseParameters) (retryAfter time.Duration, paused bool) {
+	if params == nil || params.RetryAfter <= 0 {
+		return 0, false
+	}
+
+	retryAfter = time.Duration(params.RetryAfter) * time.Second
+
+	l.Lock()
+	defer l.Unlock()
+
+	if until := time.Now().Add(retryAfter); l.next.Before(until) {
+		l.next = until
+	}
+
+	log.Printf(">>> flood limit reached, pausing outgoing messages for %s", retryAfter)
+
+	return retryAfter, true
+}
+
+// retry policy of sending messages in config
+type retryPolicy struct {
+	MaxAttempts                int `json:"max_attempts,omitempty"`
+	InitialBackoffMilliseconds int `json:"initial_backoff_milliseconds,omitempty"`
+}
+
+// prefixes of descriptions of permanent errors from the Bot API, which will fail again when retried
+// (400 for invalid requests, eg. a deleted message to reply to, and 403 for blocked bots)
+var permanentSendErrorPrefixes = []string{"Bad Request:", "Forbidden:"}
+
+// error of sending a message
+type sendError struct {
+	description string
+	permanent   bool // (not retried, nor kept for re-sending)
+}
+
+// returns the description of the error
+func (e sendError) Error() string {
+	return e.description
+}
+
+// create an error of sending a message with given description from the Bot API
+func newSendError(description string) sendError {
+	for _, prefix := range permanentSendErrorPrefixes {
+		if strings.HasPrefix(description, prefix) {
+			return sendError{description: description, permanent: true}
+		}
+	}
+	return sendError{description: description}
+}
+
+// check if given error of sending a message is permanent
+func isPermanentSendError(err error) bool {
+	var se sendError
+	return errors.As(err, &se) && se.permanent
+}
+
+// check if given error of sending a message is for a too-long message
+//
+// (it can be sent after being split)
+func isMessageTooLongError(err error) bool {
+	var se sendError
+	return errors.As(err, &se) && strings.Contains(se.description, "message is too long")
+}
+
+// send a message (in HTML parse mode) with retries and exponential backoff, and return the id of the sent message
+//
+// (permanent errors, eg. 400 Bad Request, are not retried, but too-long messages are split and sent as multiple ones)
+//
+// given reply markup (if not nil) is attached to the message (or the last one of split messages)
+func sendMessageWithRetry(conf config, bot *tg.Bot, chatID, replyToMessageID int64, text string, markup *tg.InlineKeyboardMarkup) (messageID int64, err error) {
+	maxAttempts := DefaultSendMaxAttempts
+	if conf.SendRetryPolicy.MaxAttempts > 0 {
+		maxAttempts = conf.SendRetryPolicy.MaxAttempts
+	}
+	backoff := DefaultSendInitialBackoffMilliseconds * time.Millisecond
+	if conf.SendRetryPolicy.InitialBackoffMilliseconds > 0 {
+		backoff = time.Duration(conf.SendRetryPolicy.InitialBackoffMilliseconds) * time.Millisecond
+	}
+
+	options := tg.OptionsSendMessage{}.
+		SetParseMode(tg.ParseModeHTML)
+	if replyToMessageID != 0 { // (0 for messages not replying to any, eg. results of schedules)
+		options = options.SetReplyParameters(tg.ReplyParameters{MessageID: replyToMessageID})
+	}
+	if markup != nil {
+		options = options.SetReplyMarkup(*markup)
+	}
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		limiter.wait(conf)
+
+		sent := bot.SendMessage(chatID, text, options)
+		if sent.Ok {
+			return sent.Result.MessageID, nil
+		}
+
+		err = newSendError(*sent.Description)
+		if isMessageTooLongError(err) {
+			if parts := splitMessage(text, messageLength(text)/2); len(parts) > 1 {
+				log.Printf(">>> message is too long, sending it as %d messages", len(parts))
+
+				for i, part := range parts {
+					partMarkup := markup
+					if i < len(parts)-1 {
+						partMarkup = nil
+					}
+					if messageID, err = sendMessageWithRetry(conf, bot, chatID, replyToMessageID, part, partMarkup); err != nil {
+						return 0, err
+					}
+				}
+				return messageID, nil
+			}
+		}
+		if isPermanentSendError(err) {
+			break
+		}
+
+		if attempt < maxAttempts {
+			if retryAfter, paused := limiter.pauseIfNeeded(sent.Parameters); paused {
+				// (next `limiter.wait` will wait for `retry_after`)
+				log.Printf("Error: failed to send message (attempt %d/%d), retrying after %s: %s", attempt, maxAttempts, retryAfter, err)
+			} else {
+				log.Printf("Error: failed to send message (attempt %d/%d), retrying in %s: %s", attempt, maxAttempts, backoff, err)
+
+				time.Sleep(backoff)
+				backoff *= 2
+			}
+		}
+	}
+
+	collectError("failed to send a message to chat %d: %s", chatID, err)
+
+	return 0, err
+}
+
+// edit a previously sent message with given text (in HTML parse mode)
+func editMessage(conf config, bot *tg.Bot, chatID, messageID int64, text string) error {
+	limiter.wait(conf)
+
+	options := tg.OptionsEditMessageText{}.
+		SetIDs(chatID, messageID).
+		SetParseMode(tg.ParseModeHTML)
+	if edited := bot.EditMessageText(text, options); !edited.Ok {
+		limiter.pauseIfNeeded(edited.Parameters)
+
+		return fmt.Errorf("%s", *edited.Description)
+	}
+
+	return nil
+}
+
+// deliver the generated result of given request (split into multiple messages if it is too long),
+// and keep it in the database if it fails to be delivered (so that it can be re-sent later with `/resend`)
+//
+// if the request is for regenerating a previously sent reply, the reply will be edited in place
+func deliverResult(conf config, bot *tg.Bot, request request, text string) {
+	deliverMessages(conf, bot, request, splitMessage(text, MaxMessageLength), nil)
+}
+
+// deliver given messages of the generated result of given request (with given reply markup on the last one),
+// and keep the ones which fail to be delivered in the database
+//
+// (only the first message is edited in place when regenerating, and the others are sent as new ones)
+func deliverMessages(conf config, bot *tg.Bot, request request, messages []string, markup *tg.InlineKeyboardMarkup) {
+	recorded := false // (only the first message is recorded for regenerating)
+
+	if replyID := request.extra.replaceMessageID; replyID != 0 && len(messages) > 0 {
+		if err := editMessage(conf, bot, request.targetChatID, replyID, messages[0]); err == nil {
+			recordSentReply(request, replyID)
+			recorded = true
+			messages = messages[1:]
+		} else {
+			log.Printf("Error: failed to edit reply %d, sending a new one: %s", replyID, err)
+		}
+	}
+
+	// returns the reply markup of the message with given index
+	markupOf := func(index int) *tg.InlineKeyboardMarkup {
+		if index == len(messages)-1 {
+			return markup
+		}
+		return nil
+	}
+
+	for i, text := range messages {
+		replyID, err := sendMessageWithRetry(conf, bot, request.targetChatID, request.targetMessageID, text, markupOf(i))
+		if err == nil {
+			if !recorded {
+				recordSentReply(request, replyID)
+				recorded = true
+			}
+			continue
+		}
+
+		if isPermanentSendError(err) {
+			request.logger().Printf("Error: failed to deliver the result of request %s, dropping it: %s", request, err)
+		} else {
+			request.logger().Printf("Error: failed to deliver the result of request %s, keeping it for re-sending: %s", request, err)
+
+			if err := db.update(func(data *dbData) {
+				for j, text := range messages[i:] {
+					data.Undelivered = append(data.Undelivered, undeliveredMessage{
+						Bot:         request.botName,
+						ChatID:      request.targetChatID,
+						MessageID:   request.targetMessageID,
+						Text:        text,
+						ReplyMarkup: markupOf(i + j),
+					})
+				}
+			}); err != nil {
+				log.Printf("Error: failed to save undelivered result: %s", err)
+			}
+		}
+		return
+	}
+}
+
+// re-send undelivered messages of given bot, and return the numbers of succeeded and failed ones
+//
+// messages are taken out of the database while being re-sent (so concurrent re-sends will not send them twice),
+// and failed ones are put back, except for the ones which failed permanently
+func resendUndelivered(conf config, bot *tg.Bot) (succeeded, failed int) {
+	var undelivered []undeliveredMessage
+	if err := db.update(func(data *dbData) {
+		// (messages of other bots are kept as they are)
+		remaining := []undeliveredMessage{}
+		for _, message := range data.Undelivered {
+			if message.Bot == conf.botName {
+				undelivered = append(undelivered, message)
+			} else {
+				remaining = append(remaining, message)
+			}
+		}
+		data.Undelivered = remaining
+	}); err != nil {
+		log.Printf("Error: failed to take undelivered results: %s", err)
+		return 0, 0
+	}
+
+	failures := []undeliveredMessage{}
+	for _, message := range undelivered {
+		if _, err := sendMessageWithRetry(conf, bot, message.ChatID, message.MessageID, message.Text, message.ReplyMarkup); err == nil {
+			succeeded++
+		} else {
+			failed++
+			if isPermanentSendError(err) {
+				log.Printf("Error: failed to re-send a result to chat %d, dropping it: %s", message.ChatID, err)
+			} else {
+				failures = append(failures, message)
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		if err := db.update(func(data *dbData) {
+			data.Undelivered = append(data.Undelivered, failures...)
+		}); err != nil {
+			log.Printf("Error: failed to save undelivered results: %s", err)
+		}
+	}
+
+	return succeeded, failed
+}
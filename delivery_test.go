@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPermanentSendError(t *testing.T) {
+	for _, test := range []struct {
+		description string
+		permanent   bool
+	}{
+		{"Bad Request: message to be replied not found", true},
+		{"Forbidden: bot was blocked by the user", true},
+		{"Too Many Requests: retry after 5", false},
+		{"Internal Server Error", false},
+	} {
+		if permanent := isPermanentSendError(newSendError(test.description)); permanent != test.permanent {
+			t.Errorf("'%s' should be permanent: %t", test.description, test.permanent)
+		}
+	}
+
+	if !isPermanentSendError(fmt.Errorf("wrapped: %w", newSendError("Bad Request: chat not found"))) {
+		t.Errorf("wrapped permanent error should be permanent")
+	}
+}
+
+func TestMessageTooLongError(t *testing.T) {
+	if !isMessageTooLongError(newSendError("Bad Request: message is too long")) {
+		t.Errorf("'message is too long' error should be detected")
+	}
+	if isMessageTooLongError(newSendError("Bad Request: chat not found")) {
+		t.Errorf("other errors should not be detected as 'message is too long'")
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// default values of digests
+const (
+	DefaultDigestCron           = "0 21 * * *"
+	DefaultDigestMaxMessages    = 500
+	DefaultDigestRetentionHours = 24
+
+	DefaultDigestPrompt = "Write a short digest of the following discussion in a group chat, with its main topics, decisions, and open questions:"
+)
+
+// type of supergroup chats (not defined in telegram-bot-go)
+const ChatTypeSupergroup tg.ChatType = "supergroup"
+
+// config of daily digests, which summarize messages of group chats (only of the ones which opted in with `/digest`)
+type digestConfig struct {
+	Cron     string `json:"cron,omitempty"`      // when to post digests (default: "0 21 * * *")
+	TimeZone string `json:"time_zone,omitempty"` // eg. "Asia/Seoul" (default: local time zone)
+
+	Model  string `json:"model,omitempty"`  // index or name (or alias) of the model (default: the first enabled one)
+	Prompt string `json:"prompt,omitempty"` // prompt followed by the messages (default: `DefaultDigestPrompt`)
+
+	MaxMessages    int `json:"max_messages,omitempty"`    // max number of buffered messages of each chat, older ones are dropped (default: 500)
+	RetentionHours int `json:"retention_hours,omitempty"` // buffered messages older than this are dropped (default: 24)
+}
+
+// a message kept for the digest
+type digestEntry struct {
+	sender string
+	text   string
+	date   time.Time
+}
+
+// messages of group chats, buffered for digests (only in memory, so they are lost on restart)
+var digestBuffers struct {
+	sync.Mutex
+
+	chats map[int64][]digestEntry
+}
+
+// returns the max number of buffered messages of each chat
+func (d digestConfig) maxMessages() int {
+	if d.MaxMessages > 0 {
+		return d.MaxMessages
+	}
+	return DefaultDigestMaxMessages
+}
+
+// returns how long buffered messages are kept
+func (d digestConfig) retention() time.Duration {
+	if d.RetentionHours > 0 {
+		return time.Duration(d.RetentionHours) * time.Hour
+	}
+	return DefaultDigestRetentionHours * time.Hour
+}
+
+// returns the parsed cron expression and the time zone of digests
+func (d digestConfig) parse() (cronExpression, *time.Location, error) {
+	expr := d.Cron
+	if expr == "" {
+		expr = DefaultDigestCron
+	}
+	return parseCronInTimeZone(expr, d.TimeZone)
+}
+
+// check if digests are enabled in given chat
+func digestEnabled(conf config, chatID int64) bool {
+	if conf.Digest == nil {
+		return false
+	}
+
+	enabled := getChatSettings(chatID).Digest
+	return enabled != nil && *enabled
+}
+
+// buffer given message for the digest of its chat, if it is a group chat which opted in
+//
+// (messages of all members are buffered, including the ones who are not allowed to use the bot)
+func bufferForDigest(conf config, message tg.Message) {
+	if message.Chat.Type != tg.ChatTypeGroup && message.Chat.Type != ChatTypeSupergroup {
+		return
+	}
+	if !message.HasText() || strings.HasPrefix(*message.Text, "/") { // (commands are not a part of the discussion)
+		return
+	}
+	if !digestEnabled(conf, message.Chat.ID) {
+		return
+	}
+
+	sender := "unknown"
+	if message.From != nil {
+		sender = message.From.FirstName
+		if message.From.Username != nil {
+			sender = "@" + *message.From.Username
+		}
+	}
+
+	digestBuffers.Lock()
+	defer digestBuffers.Unlock()
+
+	if digestBuffers.chats == nil {
+		digestBuffers.chats = map[int64][]digestEntry{}
+	}
+	messages := append(digestBuffers.chats[message.Chat.ID], digestEntry{
+		sender: sender,
+		text:   *message.Text,
+		date:   time.Unix(int64(message.Date), 0),
+	})
+	if over := len(messages) - conf.Digest.maxMessages(); over > 0 {
+		messages = messages[over:]
+	}
+	digestBuffers.chats[message.Chat.ID] = messages
+}
+
+// remove and return buffered messages of each chat (except expired ones)
+func takeDigestMessages(retention time.Duration) map[int64][]digestEntry {
+	digestBuffers.Lock()
+	defer digestBuffers.Unlock()
+
+	taken := map[int64][]digestEntry{}
+	for chatID, messages := range digestBuffers.chats {
+		for len(messages) > 0 && time.Since(messages[0].date) > retention {
+			messages = messages[1:]
+		}
+		if len(messages) > 0 {
+			taken[chatID] = messages
+		}
+	}
+	digestBuffers.chats = nil
+
+	return taken
+}
+
+// drop buffered messages of given chat
+func dropDigestMessages(chatID int64) {
+	digestBuffers.Lock()
+	defer digestBuffers.Unlock()
+
+	delete(digestBuffers.chats, chatID)
+}
+
+// post digests in background, at the time of `digest.cron`
+func startDigests(conf config, bot *tg.Bot, reqQueue *priorityQueue) {
+	runEveryMinute(conf, bot, "posting digests", func(c config, now time.Time) {
+		if c.Digest == nil {
+			return
+		}
+
+		cron, location, err := c.Digest.parse()
+		if err != nil {
+			log.Printf("Error: failed to parse the schedule of digests: %s", err)
+			return
+		}
+		if cron.matches(now.In(location)) {
+			postDigests(c, bot, reqQueue, location)
+		}
+	})
+}
+
+// enqueue requests for digests of buffered messages of each chat (with times of messages in given location)
+func postDigests(conf config, bot *tg.Bot, reqQueue *priorityQueue, location *time.Location) {
+	m, found := modelByIndexOrName(conf, conf.Digest.Model)
+	if !found || m.isDisabled() || m.isImageGenerator() {
+		log.Printf("Error: no model for digests: '%s'", conf.Digest.Model)
+		return
+	}
+
+	prompt := conf.Digest.Prompt
+	if prompt == "" {
+		prompt = DefaultDigestPrompt
+	}
+
+	for chatID, messages := range takeDigestMessages(conf.Digest.retention()) {
+		if !digestEnabled(conf, chatID) { // (opted out after the messages were buffered)
+			continue
+		}
+
+		lines := []string{}
+		for _, message := range messages {
+			lines = append(lines, fmt.Sprintf("[%s] %s: %s", message.date.In(location).Format("15:04"), message.sender, message.text))
+		}
+		text := escapeForShell(prompt + "\n\n" + strings.Join(lines, "\n"))
+
+		log.Printf(">>> posting a digest of %d message(s) to chat %d with model: %s", len(messages), chatID, m)
+
+		message := tg.Message{ // (not replying to any message)
+			Chat: tg.Chat{ID: chatID},
+			Date: int(time.Now().Unix()),
+		}
+		enqueueRequest(conf, bot, reqQueue, m, &text, nil, generationOptions{}, nil, &requestExtra{schedule: "digest"}, message)
+	}
+}
+
+// handle `/digest` command: turn digests of the chat on or off, and return a message about it
+func digestMessage(conf config, message tg.Message, args string) string {
+	if conf.Digest == nil {
+		return "Digests are not configured."
+	}
+	if message.Chat.Type != tg.ChatTypeGroup && message.Chat.Type != ChatTypeSupergroup {
+		return "Digests are only for group chats."
+	}
+
+	var digest bool
+	switch args {
+	case "":
+		return fmt.Sprintf("Digests of this chat: <b>%t</b>", digestEnabled(conf, message.Chat.ID))
+	case "on":
+		digest = true
+	case "off":
+		digest = false
+	default:
+		return fmt.Sprintf("Usage: %s [on | off]", CommandDigest)
+	}
+
+	updateChatSettings(message.Chat.ID, func(settings *chatSettings) {
+		settings.Digest = &digest
+	})
+
+	if digest {
+		return fmt.Sprintf("Digests of this chat were turned <b>on</b>: messages will be kept (for up to %s) and summarized periodically.", conf.Digest.retention())
+	}
+	dropDigestMessages(message.Chat.ID)
+	return "Digests of this chat were turned <b>off</b> (and kept messages were dropped)."
+}
+
+// validate digests in given config, and return found problems
+func validateDigest(conf config) (problems []string) {
+	if conf.Digest == nil {
+		return nil
+	}
+
+	if _, _, err := conf.Digest.parse(); err != nil {
+		problems = append(problems, fmt.Sprintf("`digest` is invalid: %s", err))
+	}
+	if m, found := modelByIndexOrName(conf, conf.Digest.Model); !found {
+		problems = append(problems, fmt.Sprintf("`digest` has an unknown model: '%s'", conf.Digest.Model))
+	} else if m.isImageGenerator() {
+		problems = append(problems, fmt.Sprintf("`digest` has an image generator as its model: '%s'", conf.Digest.Model))
+	}
+	if conf.Digest.MaxMessages < 0 || conf.Digest.RetentionHours < 0 {
+		problems = append(problems, "`max_messages` and `retention_hours` of `digest` should not be negative")
+	}
+
+	return problems
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// default bounds of directives
+const (
+	DefaultDirectiveMaxTemperature = 2.0
+	DefaultDirectiveMaxTokens      = 1024
+)
+
+// bounds of directives in config
+type directiveBounds struct {
+	MaxTemperature *float64 `json:"max_temperature,omitempty"`
+	MaxTokens      *int     `json:"max_tokens,omitempty"`
+}
+
+// generation options for each request
+type generationOptions struct {
+	seed        *int
+	temperature *float64
+	maxTokens   *int
+
+	grammar *string // (only for llamafiles, eg. for tool calls)
+}
+
+// returns the bounds of directives in config (or their defaults)
+func directiveLimits(conf config) (maxTemperature float64, maxTokens int) {
+	maxTemperature = DefaultDirectiveMaxTemperature
+	if conf.DirectiveBounds.MaxTemperature != nil {
+		maxTemperature = *conf.DirectiveBounds.MaxTemperature
+	}
+	maxTokens = DefaultDirectiveMaxTokens
+	if conf.DirectiveBounds.MaxTokens != nil {
+		maxTokens = *conf.DirectiveBounds.MaxTokens
+	}
+	return maxTemperature, maxTokens
+}
+
+// parse leading directives (eg. "@temp=0.2 @n=256 @seed=42 ...") from given text,
+// and return the rest of the text with the parsed options
+//
+// values of directives are clamped within the bounds in config (`directive_bounds`)
+//
+// NOTE: unknown or malformed directives (eg. "@temp=NaN") are left as they are
+func parseDirectives(conf config, text string) (rest string, options generationOptions) {
+	maxTemperature, maxTokens := directiveLimits(conf)
+
+	rest = strings.TrimSpace(text)
+
+	for strings.HasPrefix(rest, "@") {
+		// (directives are separated by any whitespace, including newlines)
+		directive, remaining := rest, ""
+		if i := strings.IndexFunc(rest, unicode.IsSpace); i >= 0 {
+			directive, remaining = rest[:i], rest[i:]
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(directive, "@"), "=")
+
+		switch key {
+		case "seed":
+			if seed, err := strconv.Atoi(value); err == nil {
+				options.seed = &seed
+			} else {
+				return rest, options
+			}
+		case "temp":
+			if temperature, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(temperature) && !math.IsInf(temperature, 0) {
+				temperature = min(max(temperature, 0), maxTemperature)
+				options.temperature = &temperature
+			} else {
+				return rest, options
+			}
+		case "n":
+			if tokens, err := strconv.Atoi(value); err == nil {
+				tokens = min(max(tokens, 1), maxTokens)
+				options.maxTokens = &tokens
+			} else {
+				return rest, options
+			}
+		default:
+			return rest, options
+		}
+
+		rest = strings.TrimSpace(remaining)
+	}
+
+	return rest, options
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseDirectives(t *testing.T) {
+	maxTemperature, maxTokens := 1.5, 100
+	bounded := config{DirectiveBounds: directiveBounds{MaxTemperature: &maxTemperature, MaxTokens: &maxTokens}}
+
+	tests := []struct {
+		name        string
+		conf        config
+		text        string
+		rest        string
+		temperature *float64
+		maxTokens   *int
+		seed        *int
+	}{
+		{name: "no directives", text: "hello @temp=0.5", rest: "hello @temp=0.5"},
+		{name: "all directives", text: "@temp=0.2 @n=256 @seed=42 hello", rest: "hello", temperature: pointer(0.2), maxTokens: pointer(256), seed: pointer(42)},
+		{name: "only directives", text: "  @temp=0.3  ", rest: "", temperature: pointer(0.3)},
+		{name: "separated by newlines", text: "@temp=0.2\n@n=10\nhello\nworld", rest: "hello\nworld", temperature: pointer(0.2), maxTokens: pointer(10)},
+		{name: "separated by tabs", text: "@seed=7\thello", rest: "hello", seed: pointer(7)},
+		{name: "NaN temperature", text: "@temp=NaN hello", rest: "@temp=NaN hello"},
+		{name: "infinite temperature", text: "@temp=Inf hello", rest: "@temp=Inf hello"},
+		{name: "negative infinite temperature", text: "@temp=-inf hello", rest: "@temp=-inf hello"},
+		{name: "malformed temperature", text: "@temp=hot hello", rest: "@temp=hot hello"},
+		{name: "clamped temperature", text: "@temp=5 hello", rest: "hello", temperature: pointer(DefaultDirectiveMaxTemperature)},
+		{name: "negative temperature", text: "@temp=-1 hello", rest: "hello", temperature: pointer(0.0)},
+		{name: "clamped tokens", text: "@n=0 hello", rest: "hello", maxTokens: pointer(1)},
+		{name: "too many tokens", text: "@n=99999 hello", rest: "hello", maxTokens: pointer(DefaultDirectiveMaxTokens)},
+		{name: "bounds in config", conf: bounded, text: "@temp=1.9 @n=500 hello", rest: "hello", temperature: pointer(1.5), maxTokens: pointer(100)},
+		{name: "malformed seed", text: "@seed=abc hello", rest: "@seed=abc hello"},
+		{name: "unknown directive", text: "@temp=0.5 @foo hello", rest: "@foo hello", temperature: pointer(0.5)},
+	}
+
+	for _, test := range tests {
+		rest, options := parseDirectives(test.conf, test.text)
+
+		if rest != test.rest {
+			t.Errorf("[%s] expected rest '%s', got '%s'", test.name, test.rest, rest)
+		}
+		if !equalPointers(options.temperature, test.temperature) {
+			t.Errorf("[%s] expected temperature %v, got %v", test.name, deref(test.temperature), deref(options.temperature))
+		}
+		if !equalPointers(options.maxTokens, test.maxTokens) {
+			t.Errorf("[%s] expected max tokens %v, got %v", test.name, deref(test.maxTokens), deref(options.maxTokens))
+		}
+		if !equalPointers(options.seed, test.seed) {
+			t.Errorf("[%s] expected seed %v, got %v", test.name, deref(test.seed), deref(options.seed))
+		}
+	}
+}
+
+// check if given pointers are both nil, or point to the same value
+func equalPointers[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// returns the value of given pointer (or nil)
+func deref[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// returns a pointer to given value
+func pointer[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// default values of document summarization configs
+const (
+	DefaultDocumentChunkSize = 4000 // in characters
+
+	MaxDocumentFileSizeBytes = 20 * 1024 * 1024 // (max size of files which can be downloaded with bot API)
+)
+
+// prompts for summarizing documents
+const (
+	documentSummaryPrompt      = "Summarize the following document:"
+	documentChunkSummaryPrompt = "Summarize the following part of a document:"
+	documentReducePrompt       = "Combine the following summaries of the parts of a document into one summary:"
+)
+
+// configs for summarizing uploaded documents
+type documentSummarizationConfig struct {
+	ModelIndex          *int     `json:"model_index,omitempty"`           // index of the model in `models` for summarizing documents (if omitted, models of the chat will be used)
+	ChunkSize           int      `json:"chunk_size,omitempty"`            // size of each chunk in characters
+	PDFExtractorCommand []string `json:"pdf_extractor_command,omitempty"` // command for extracting text from a PDF file, with `%f` for its path (eg. ["pdftotext", "%f", "-"])
+}
+
+// handle a document message: download it, extract its text, and enqueue requests for summarizing it
+func handleDocument(conf config, bot *tg.Bot, reqQueue *priorityQueue, message tg.Message) {
+	if conf.DocumentSummarization == nil {
+		return
+	}
+	c := conf.DocumentSummarization
+
+	var filename string
+	if message.Document.FileName != nil {
+		filename = *message.Document.FileName
+	}
+
+	text, err := documentText(conf, bot, *message.Document, filename)
+	if err != nil {
+		log.Printf("Error: failed to read document '%s': %s", filename, err)
+
+		sendReply(conf, bot, message, fmt.Sprintf("Failed to read the document: <em>%s</em>", escapeForHTML(err.Error())))
+		return
+	}
+
+	chunkSize := DefaultDocumentChunkSize
+	if c.ChunkSize > 0 {
+		chunkSize = c.ChunkSize
+	}
+	chunks := chunkText(text, chunkSize)
+	if len(chunks) == 0 {
+		sendReply(conf, bot, message, "The document has no text.")
+		return
+	}
+
+	log.Printf(">>> summarizing document '%s' in %d chunk(s)", filename, len(chunks))
+
+	// (caption of the document is used as the comment)
+	var commentText *string
+	if message.HasCaption() {
+		caption := escapeForShell(*message.Caption)
+		commentText = &caption
+	}
+
+	models := modelsForChat(conf, message.Chat.ID)
+	if c.ModelIndex != nil && *c.ModelIndex >= 0 && *c.ModelIndex < len(conf.Models) {
+		models = []model{conf.Models[*c.ModelIndex]}
+	}
+
+	// (a document with only one chunk will be summarized at once)
+	originalText := escapeForShell(documentSummaryPrompt + "\n\n" + chunks[0])
+	extra := &requestExtra{documentChunks: chunks}
+
+	group := newRequestGroup(conf, len(models))
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, &originalText, commentText, generationOptions{}, group, extra, message)
+	}
+}
+
+// download given document, and extract its text
+func documentText(conf config, bot *tg.Bot, document tg.Document, filename string) (string, error) {
+	if document.FileSize > MaxDocumentFileSizeBytes {
+		return "", fmt.Errorf("file is too large: %d bytes", document.FileSize)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch ext {
+	case ".txt", ".md":
+		bytes, err := downloadFile(conf, bot, document.FileID)
+		if err != nil {
+			return "", err
+		}
+		return strings.ToValidUTF8(string(bytes), ""), nil
+	case ".pdf":
+		command := conf.DocumentSummarization.PDFExtractorCommand
+		if len(command) == 0 {
+			return "", fmt.Errorf("`pdf_extractor_command` is not configured")
+		}
+
+		bytes, err := downloadFile(conf, bot, document.FileID)
+		if err != nil {
+			return "", err
+		}
+
+		tmp, err := os.CreateTemp("", "document-*.pdf")
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(tmp.Name())
+		if _, err := tmp.Write(bytes); err != nil {
+			tmp.Close()
+			return "", err
+		}
+		if err := tmp.Close(); err != nil {
+			return "", err
+		}
+
+		args := []string{}
+		for _, arg := range command[1:] {
+			args = append(args, strings.ReplaceAll(arg, "%f", tmp.Name()))
+		}
+		out, err := exec.Command(command[0], args...).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to extract text: %s", err)
+		}
+		return strings.ToValidUTF8(string(out), ""), nil
+	}
+
+	return "", fmt.Errorf("unsupported file type: '%s'", ext)
+}
+
+// download a file with given id
+//
+// (files are read from the disk, when a self-hosted Bot API server in `--local` mode returns their absolute paths)
+func downloadFile(conf config, bot *tg.Bot, fileID string) ([]byte, error) {
+	file := bot.GetFile(fileID)
+	if !file.Ok || file.Result == nil || file.Result.FilePath == nil {
+		return nil, fmt.Errorf("failed to get file info")
+	}
+
+	if conf.TelegramAPIBaseURL != "" && filepath.IsAbs(*file.Result.FilePath) {
+		f, err := os.Open(*file.Result.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return io.ReadAll(io.LimitReader(f, MaxDocumentFileSizeBytes))
+	}
+
+	client, err := telegramHTTPClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Get(bot.GetFileURL(*file.Result))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file: HTTP %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, MaxDocumentFileSizeBytes))
+}
+
+// summarize each chunk of the document of given request (map),
+// and return the request for summarizing them all together (reduce)
+func mapDocumentChunks(ctx context.Context, request request) (reduce request, err error) {
+	if len(request.extra.documentChunks) <= 1 {
+		return request, nil
+	}
+
+	chunkPrompt := documentChunkSummaryPrompt
+	if request.extra.chunkPrompt != "" {
+		chunkPrompt = request.extra.chunkPrompt
+	}
+
+	summaries := []string{}
+	for i, chunk := range request.extra.documentChunks {
+		text := escapeForShell(chunkPrompt + "\n\n" + chunk)
+
+		part := request
+		part.originalText = &text
+		part.commentText = nil
+
+		log.Printf(">>> summarizing chunk %d/%d of a document with model: %s", i+1, len(request.extra.documentChunks), request.model)
+
+		var summary generationResult
+		if summary, err = generate(ctx, part); err != nil {
+			return request, fmt.Errorf("failed to summarize chunk %d: %w", i+1, err)
+		}
+		summaries = append(summaries, fmt.Sprintf("[%d] %s", i+1, strings.TrimSpace(summary.text)))
+	}
+
+	text := escapeForShell(documentReducePrompt + "\n\n" + strings.Join(summaries, "\n\n"))
+	request.originalText = &text
+
+	return request, nil
+}
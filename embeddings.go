@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// generate an embedding of given text with given model (in llamafile's embedding mode)
+func embed(ctx context.Context, model model, text string) (embedding []float64, err error) {
+	if !model.isLlamafile() {
+		return nil, fmt.Errorf("not a llamafile model: %s", model)
+	}
+
+	params := model.otherParameters()
+	params = append(params, "--embedding")
+
+	var output string
+	if output, _, err = generateFromLlamafile(ctx, model, escapeForShell(text), params...); err != nil {
+		return nil, err
+	}
+
+	return parseEmbedding(output)
+}
+
+// parse an embedding from the output of llamafile's embedding mode
+//
+// (eg. "embedding 0: 0.012345 -0.023456 ...")
+func parseEmbedding(output string) (embedding []float64, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		if _, values, found := strings.Cut(line, ":"); found && strings.HasPrefix(strings.TrimSpace(line), "embedding") {
+			line = values
+		}
+
+		parsed := []float64{}
+		for _, field := range strings.Fields(line) {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				parsed = nil
+				break
+			}
+			parsed = append(parsed, value)
+		}
+
+		// (take the longest line of numbers)
+		if len(parsed) > len(embedding) {
+			embedding = parsed
+		}
+	}
+
+	if len(embedding) == 0 {
+		return nil, fmt.Errorf("no embedding in the output")
+	}
+
+	return embedding, nil
+}
+
+// returns the cosine similarity of given embeddings
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
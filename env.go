@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// returns the environment for subprocesses of given model: the bot's own, with `env` of the model
+//
+// (nil if the model has no `env`, so that subprocesses just inherit the bot's one)
+func environFor(model model) []string {
+	if len(model.Env) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(model.Env))
+	for key := range model.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := os.Environ()
+	for _, key := range keys {
+		env = append(env, key+"="+model.Env[key]) // (later ones take precedence)
+	}
+	return env
+}
+
+// validate `env` of given model, and return found problems
+func validateEnv(model model) (problems []string) {
+	for key := range model.Env {
+		if key == "" || strings.ContainsAny(key, "=\x00") {
+			problems = append(problems, fmt.Sprintf("`env` has an invalid name: '%s'", key))
+		}
+	}
+	sort.Strings(problems)
+
+	return problems
+}
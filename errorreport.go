@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// default interval of sending batched error reports
+const DefaultErrorReportIntervalSeconds = 60
+
+// max number of distinct errors in a batched error report
+const MaxErrorsInReport = 20
+
+// errors collected for the next batched report
+var errorReports struct {
+	sync.Mutex
+
+	errors []string       // (distinct ones, in order of their first occurrences)
+	counts map[string]int // (keyed by errors)
+}
+
+// collect an error (eg. send failures, backend failures, and queue overflows) for the next batched report
+func collectError(format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+
+	errorReports.Lock()
+	defer errorReports.Unlock()
+
+	if errorReports.counts == nil {
+		errorReports.counts = map[string]int{}
+	}
+	if _, exists := errorReports.counts[text]; !exists {
+		if len(errorReports.errors) >= MaxErrorsInReport {
+			text = "(other errors)"
+		}
+		if _, exists := errorReports.counts[text]; !exists {
+			errorReports.errors = append(errorReports.errors, text)
+		}
+	}
+	errorReports.counts[text]++
+}
+
+// send collected errors to `error_report_chat_id` periodically, in background
+func startErrorReports(conf config, bot *tg.Bot) {
+	interval := DefaultErrorReportIntervalSeconds
+	if conf.ErrorReportIntervalSeconds > 0 {
+		interval = conf.ErrorReportIntervalSeconds
+	}
+
+	go func() {
+		for range time.Tick(time.Duration(interval) * time.Second) {
+			// (reloaded one, as `error_report_chat_id` may have been changed)
+			conf := liveConfig(conf)
+
+			withRecovery(conf, bot, "sending error reports", func() {
+				sendErrorReport(conf, bot, interval)
+			})
+		}
+	}()
+}
+
+// send collected errors as a report, and clear them
+func sendErrorReport(conf config, bot *tg.Bot, intervalSeconds int) {
+	errorReports.Lock()
+	errors, counts := errorReports.errors, errorReports.counts
+	errorReports.errors, errorReports.counts = nil, nil
+	errorReports.Unlock()
+
+	if len(errors) == 0 || conf.ErrorReportChatID == nil {
+		return
+	}
+
+	total := 0
+	lines := []string{}
+	for _, e := range errors {
+		total += counts[e]
+		if counts[e] > 1 {
+			lines = append(lines, fmt.Sprintf("- (%d×) %s", counts[e], e))
+		} else {
+			lines = append(lines, "- "+e)
+		}
+	}
+
+	log.Printf(">>> reporting %d error(s) to chat: %d", total, *conf.ErrorReportChatID)
+
+	reportError(conf, bot, fmt.Sprintf("%d error(s) in the last %d seconds:", total, intervalSeconds), strings.Join(lines, "\n"))
+}
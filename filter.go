@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// strictness levels of the content filter
+const (
+	FilterStrictnessOff    = "off"
+	FilterStrictnessLow    = "low"
+	FilterStrictnessMedium = "medium"
+	FilterStrictnessHigh   = "high"
+)
+
+// strictness levels in ascending order
+var filterStrictnessLevels = []string{FilterStrictnessOff, FilterStrictnessLow, FilterStrictnessMedium, FilterStrictnessHigh}
+
+// defaults of the content filter
+const (
+	DefaultFilterStrictness      = FilterStrictnessMedium
+	DefaultFilterRefusalMessage  = "Sorry, your request was blocked by the content filter."
+	DefaultFilterBlockedOutput   = "Sorry, the generated output was blocked by the content filter."
+	MaxRecentFilterMatches       = 20
+	FilterMatchExcerptCharacters = 50
+)
+
+// stages of the content filter
+const (
+	FilterStagePrompt = "prompt"
+	FilterStageOutput = "output"
+)
+
+// config for filtering prompts and outputs
+type contentFilterConfig struct {
+	Rules             []contentFilterRule `json:"rules"`
+	DefaultStrictness string              `json:"default_strictness,omitempty"` // strictness of chats which have none set with `/filter` (default: "medium")
+	RefusalMessage    string              `json:"refusal_message,omitempty"`    // reply to blocked prompts
+}
+
+// rule of the content filter
+type contentFilterRule struct {
+	Patterns []string `json:"patterns,omitempty"` // regular expressions
+	Words    []string `json:"words,omitempty"`    // banned words (matched case-insensitively, as whole words)
+	Level    string   `json:"level,omitempty"`    // min strictness of chats where this rule is applied (default: "low")
+}
+
+// compiled rule of the content filter
+type compiledFilterRule struct {
+	regexps []*regexp.Regexp
+	level   int
+}
+
+// a match of the content filter (for admins)
+type filterMatch struct {
+	at       time.Time
+	chatID   int64
+	username string
+	stage    string
+	match    string
+}
+
+// compiled rules and recent matches of the content filter
+var filterRules []compiledFilterRule
+var recentFilterMatches []filterMatch
+var recentFilterMatchesLock sync.Mutex
+
+// returns the level of given strictness (-1 if invalid)
+func filterStrictnessLevel(strictness string) int {
+	for i, s := range filterStrictnessLevels {
+		if s == strictness {
+			return i
+		}
+	}
+	return -1
+}
+
+// compile rules of the content filter in config
+func compileContentFilter(conf config) error {
+	filterRules = nil
+
+	if conf.ContentFilter == nil {
+		return nil
+	}
+
+	if s := conf.ContentFilter.DefaultStrictness; s != "" && filterStrictnessLevel(s) < 0 {
+		return fmt.Errorf("invalid `default_strictness` of content filter: %s", s)
+	}
+
+	for i, rule := range conf.ContentFilter.Rules {
+		compiled := compiledFilterRule{level: filterStrictnessLevel(FilterStrictnessLow)}
+		if rule.Level != "" {
+			if compiled.level = filterStrictnessLevel(rule.Level); compiled.level < 0 {
+				return fmt.Errorf("invalid `level` of content filter rule #%d: %s", i, rule.Level)
+			}
+		}
+
+		for _, pattern := range rule.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern of content filter rule #%d: %s", i, err)
+			}
+			compiled.regexps = append(compiled.regexps, re)
+		}
+		if len(rule.Words) > 0 {
+			words := []string{}
+			for _, word := range rule.Words {
+				words = append(words, regexp.QuoteMeta(word))
+			}
+			compiled.regexps = append(compiled.regexps, regexp.MustCompile(`(?i)\b(`+strings.Join(words, "|")+`)\b`))
+		}
+
+		filterRules = append(filterRules, compiled)
+	}
+
+	log.Printf(">>> compiled %d rule(s) of content filter", len(filterRules))
+
+	return nil
+}
+
+// returns the strictness of the content filter in given chat
+func filterStrictness(conf config, chatID int64) string {
+	if strictness := getChatSettings(chatID).FilterStrictness; strictness != nil {
+		return *strictness
+	}
+	if conf.ContentFilter != nil && conf.ContentFilter.DefaultStrictness != "" {
+		return conf.ContentFilter.DefaultStrictness
+	}
+	return DefaultFilterStrictness
+}
+
+// check given text of given request's chat at given stage with the content filter,
+// and return whether it is blocked (matches are logged, and kept for `/filter`)
+func filterBlocks(conf config, request request, stage, text string) bool {
+	if conf.ContentFilter == nil {
+		return false
+	}
+
+	level := filterStrictnessLevel(filterStrictness(conf, request.targetChatID))
+	for _, rule := range filterRules {
+		if level < rule.level {
+			continue
+		}
+
+		for _, re := range rule.regexps {
+			if match := re.FindString(text); match != "" {
+				recordFilterMatch(conf, request, stage, match)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// log and keep a match of the content filter
+func recordFilterMatch(conf config, request request, stage, match string) {
+	var username string
+	if request.username != nil {
+		username = *request.username
+	}
+
+	if runes := []rune(match); len(runes) > FilterMatchExcerptCharacters {
+		match = string(runes[:FilterMatchExcerptCharacters]) + "..."
+	}
+
+	request.logger().Printf(">>> content filter blocked the %s of request %s in chat %d (user: %s): %s", stage, request, request.targetChatID, username, loggable(conf, match))
+
+	recentFilterMatchesLock.Lock()
+	defer recentFilterMatchesLock.Unlock()
+
+	recentFilterMatches = append(recentFilterMatches, filterMatch{
+		at:       time.Now(),
+		chatID:   request.targetChatID,
+		username: username,
+		stage:    stage,
+		match:    match,
+	})
+	if len(recentFilterMatches) > MaxRecentFilterMatches {
+		recentFilterMatches = recentFilterMatches[len(recentFilterMatches)-MaxRecentFilterMatches:]
+	}
+}
+
+// returns the refusal message for blocked prompts
+func filterRefusalMessage(conf config) string {
+	if conf.ContentFilter != nil && conf.ContentFilter.RefusalMessage != "" {
+		return conf.ContentFilter.RefusalMessage
+	}
+	return DefaultFilterRefusalMessage
+}
+
+// set (or show) the strictness of the content filter in given chat with recent matches, and generate a message about it
+func filterMessage(conf config, chatID int64, args string) string {
+	if conf.ContentFilter == nil {
+		return "Content filter is not configured."
+	}
+
+	if args != "" {
+		if filterStrictnessLevel(args) < 0 {
+			return fmt.Sprintf("Usage: %s [%s]", CommandFilter, strings.Join(filterStrictnessLevels, " | "))
+		}
+
+		updateChatSettings(chatID, func(settings *chatSettings) {
+			settings.FilterStrictness = &args
+		})
+
+		return fmt.Sprintf("Strictness of the content filter in this chat was set to <b>%s</b>.", args)
+	}
+
+	lines := []string{fmt.Sprintf("Strictness of the content filter in this chat: <b>%s</b>", filterStrictness(conf, chatID))}
+
+	recentFilterMatchesLock.Lock()
+	matches := append([]filterMatch{}, recentFilterMatches...)
+	recentFilterMatchesLock.Unlock()
+
+	if len(matches) > 0 {
+		lines = append(lines, "", "<b>Recent matches</b>:")
+		for _, m := range matches {
+			lines = append(lines, fmt.Sprintf("- %s, chat %d (%s), %s: <code>%s</code>", m.at.Format(time.DateTime), m.chatID, escapeForHTML(m.username), m.stage, escapeForHTML(m.match)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// timeout of requests to remote backends
+const RemoteBackendTimeoutSeconds = 600
+
+// Generator generates text from a prompt with a backend
+//
+// new backends can be added by implementing this interface, and returning it from `generatorFor`
+type Generator interface {
+	Generate(ctx context.Context, prompt generationPrompt, opts generationOptions) (generationResult, error)
+}
+
+// prompt for generators
+type generationPrompt struct {
+	text    string             // prompt text (for llamafiles, built with the model's prompt pattern)
+	history []conversationTurn // conversation turns before the text (for chat APIs, which take them as separate messages)
+}
+
+// result of a generation
+type generationResult struct {
+	text    string
+	timings *llamafileTimings // (nil if not available)
+}
+
+// returns a generator for given model
+func generatorFor(model model) (Generator, error) {
+	if model.isLlamafile() {
+		if model.LlamafileServerPort != nil {
+			return llamafileServerGenerator{model: model}, nil
+		}
+		return llamafileExecGenerator{model: model}, nil
+	} else if model.isOpenAICompatible() {
+		return openAIGenerator{model: model}, nil
+	} else if model.isOllama() {
+		return ollamaGenerator{model: model}, nil
+	}
+
+	return nil, fmt.Errorf("no generator for %s", model)
+}
+
+// returns the label of given model, shown in generation info
+func (m model) label() string {
+	if m.Name != nil {
+		return *m.Name
+	} else if m.LlamafilePath != nil {
+		return filepath.Base(*m.LlamafilePath)
+	} else if m.ModelName != nil {
+		return *m.ModelName
+	} else if m.OllamaModel != nil {
+		return *m.OllamaModel
+	}
+	return m.String()
+}
+
+// returns the generation options of given request, resolved with the chat's and model's settings
+func resolvedOptions(request request) generationOptions {
+	opts := request.options
+	if opts.seed == nil {
+		opts.seed = request.model.Seed
+	}
+	opts.maxTokens = maxTokensOf(request)
+
+	return opts
+}
+
+// returns the user's input of given request (comment first)
+func userInput(request request) string {
+	if request.originalText != nil && request.commentText != nil {
+		return fmt.Sprintf("%s\n\n%s", *request.commentText, *request.originalText)
+	} else if request.originalText != nil {
+		return *request.originalText
+	} else if request.commentText != nil {
+		return *request.commentText
+	}
+	return ""
+}
+
+// build a prompt of given request for its model
+//
+// for llamafiles, it is built with the prompt pattern and truncated to fit in the context
+// (returned with the truncated request and the number of tokens of the truncated input),
+// and for chat APIs, the conversation history is passed along separately
+func promptFor(request request) (fitted request, prompt generationPrompt, truncatedTokens int, err error) {
+	if !request.model.isLlamafile() {
+		return request, generationPrompt{text: userInput(request), history: request.history}, 0, nil
+	}
+
+	var text string
+	if text, err = llamafilePrompt(request); err != nil {
+		return request, prompt, 0, err
+	}
+	if request, text, truncatedTokens, err = fitToContextLength(request, text); err != nil {
+		return request, prompt, 0, err
+	}
+
+	return request, generationPrompt{text: text}, truncatedTokens, nil
+}
+
+// generate text for given request with its model's generator
+func generate(ctx context.Context, request request) (generationResult, error) {
+	gen, err := generatorFor(request.model)
+	if err != nil {
+		return generationResult{}, err
+	}
+
+	_, prompt, _, err := promptFor(request)
+	if err != nil {
+		return generationResult{}, err
+	}
+
+	return gen.Generate(ctx, prompt, resolvedOptions(request))
+}
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// GGUF metadata value types
+//
+// https://github.com/ggerganov/ggml/blob/master/docs/gguf.md
+const (
+	ggufTypeUint8   = 0
+	ggufTypeInt8    = 1
+	ggufTypeUint16  = 2
+	ggufTypeInt16   = 3
+	ggufTypeUint32  = 4
+	ggufTypeInt32   = 5
+	ggufTypeFloat32 = 6
+	ggufTypeBool    = 7
+	ggufTypeString  = 8
+	ggufTypeArray   = 9
+	ggufTypeUint64  = 10
+	ggufTypeInt64   = 11
+	ggufTypeFloat64 = 12
+)
+
+const ggufMagic = "GGUF"
+
+// key of the tokenizer's vocabulary, which is kept as a string array
+const ggufKeyTokens = "tokenizer.ggml.tokens"
+
+// metadata read from a GGUF file
+//
+// NOTE: arrays are skipped, and only their lengths are kept (except `tokenizer.ggml.tokens`)
+type ggufMetadata map[string]any
+
+// returns the string value for given key
+func (m ggufMetadata) String(key string) (string, bool) {
+	if v, ok := m[key].(string); ok {
+		return v, true
+	}
+	return "", false
+}
+
+// returns the string array value for given key
+func (m ggufMetadata) Strings(key string) ([]string, bool) {
+	if v, ok := m[key].([]string); ok {
+		return v, true
+	}
+	return nil, false
+}
+
+// returns the unsigned integer value for given key
+func (m ggufMetadata) Uint(key string) (uint64, bool) {
+	switch v := m[key].(type) {
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case int8:
+		return uint64(v), v >= 0
+	case int16:
+		return uint64(v), v >= 0
+	case int32:
+		return uint64(v), v >= 0
+	case int64:
+		return uint64(v), v >= 0
+	}
+	return 0, false
+}
+
+// read GGUF metadata from given file
+//
+// `path` can be a .gguf file, or a llamafile (zip archive with an embedded .gguf file)
+func readGGUFMetadata(path string) (metadata ggufMetadata, err error) {
+	metadata, _, err = readGGUF(path, false)
+	return metadata, err
+}
+
+// read GGUF metadata (and the number of parameters, if `countParameters` is true) from given file
+//
+// `path` can be a .gguf file, or a llamafile (zip archive with an embedded .gguf file)
+func readGGUF(path string, countParameters bool) (metadata ggufMetadata, parameters uint64, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(ggufMagic))
+	if _, err = io.ReadFull(f, magic); err != nil {
+		return nil, 0, err
+	}
+
+	// plain GGUF file
+	if string(magic) == ggufMagic {
+		if _, err = f.Seek(0, io.SeekStart); err != nil {
+			return nil, 0, err
+		}
+		return parseGGUF(f, countParameters)
+	}
+
+	// llamafile (zip archive)
+	var stat os.FileInfo
+	if stat, err = f.Stat(); err != nil {
+		return nil, 0, err
+	}
+	var zr *zip.Reader
+	if zr, err = zip.NewReader(f, stat.Size()); err != nil {
+		return nil, 0, fmt.Errorf("not a GGUF file nor a llamafile: %s", err)
+	}
+	for _, zf := range zr.File {
+		if strings.HasSuffix(zf.Name, ".gguf") {
+			var rc io.ReadCloser
+			if rc, err = zf.Open(); err != nil {
+				return nil, 0, err
+			}
+			defer rc.Close()
+
+			return parseGGUF(rc, countParameters)
+		}
+	}
+
+	return nil, 0, fmt.Errorf("no embedded .gguf file in %s (weights may be passed with `-m` parameter)", path)
+}
+
+// parse GGUF metadata from given reader
+func parseGGUFMetadata(r io.Reader) (metadata ggufMetadata, err error) {
+	metadata, _, err = parseGGUF(r, false)
+	return metadata, err
+}
+
+// parse GGUF metadata (and the number of parameters from tensor infos, if `countParameters` is true) from given reader
+func parseGGUF(r io.Reader, countParameters bool) (metadata ggufMetadata, parameters uint64, err error) {
+	br := bufio.NewReaderSize(r, 1024*1024)
+
+	magic := make([]byte, len(ggufMagic))
+	if _, err = io.ReadFull(br, magic); err != nil {
+		return nil, 0, err
+	}
+	if string(magic) != ggufMagic {
+		return nil, 0, fmt.Errorf("invalid magic: %q", magic)
+	}
+
+	var version uint32
+	if err = binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return nil, 0, err
+	}
+	if version < 2 {
+		return nil, 0, fmt.Errorf("unsupported GGUF version: %d", version)
+	}
+
+	var tensorCount, kvCount uint64
+	if err = binary.Read(br, binary.LittleEndian, &tensorCount); err != nil {
+		return nil, 0, err
+	}
+	if err = binary.Read(br, binary.LittleEndian, &kvCount); err != nil {
+		return nil, 0, err
+	}
+
+	metadata = ggufMetadata{}
+	for i := uint64(0); i < kvCount; i++ {
+		var key string
+		if key, err = readGGUFString(br); err != nil {
+			return nil, 0, err
+		}
+
+		var valueType uint32
+		if err = binary.Read(br, binary.LittleEndian, &valueType); err != nil {
+			return nil, 0, err
+		}
+
+		var value any
+		if key == ggufKeyTokens && valueType == ggufTypeArray {
+			value, err = readGGUFStringArray(br)
+		} else {
+			value, err = readGGUFValue(br, valueType)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read value of '%s': %s", key, err)
+		}
+		metadata[key] = value
+	}
+
+	if !countParameters {
+		return metadata, 0, nil
+	}
+
+	// tensor infos: name, dimensions, type, and offset
+	for i := uint64(0); i < tensorCount; i++ {
+		if _, err = readGGUFString(br); err != nil {
+			return nil, 0, err
+		}
+
+		var dimensions uint32
+		if err = binary.Read(br, binary.LittleEndian, &dimensions); err != nil {
+			return nil, 0, err
+		}
+		elements := uint64(1)
+		for d := uint32(0); d < dimensions; d++ {
+			var size uint64
+			if err = binary.Read(br, binary.LittleEndian, &size); err != nil {
+				return nil, 0, err
+			}
+			elements *= size
+		}
+		parameters += elements
+
+		var tensorType uint32
+		var offset uint64
+		if err = binary.Read(br, binary.LittleEndian, &tensorType); err != nil {
+			return nil, 0, err
+		}
+		if err = binary.Read(br, binary.LittleEndian, &offset); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return metadata, parameters, nil
+}
+
+// read a GGUF string
+func readGGUFString(r *bufio.Reader) (string, error) {
+	var length uint64
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return "", err
+	}
+	if length > math.MaxInt32 {
+		return "", fmt.Errorf("string too long: %d", length)
+	}
+
+	bytes := make([]byte, length)
+	if _, err := io.ReadFull(r, bytes); err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// read a GGUF array of strings
+func readGGUFStringArray(r *bufio.Reader) ([]string, error) {
+	var elemType uint32
+	if err := binary.Read(r, binary.LittleEndian, &elemType); err != nil {
+		return nil, err
+	}
+	if elemType != ggufTypeString {
+		return nil, fmt.Errorf("not a string array: %d", elemType)
+	}
+	var count uint64
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	if count > math.MaxInt32 {
+		return nil, fmt.Errorf("array too long: %d", count)
+	}
+
+	strs := make([]string, 0, count)
+	for i := uint64(0); i < count; i++ {
+		str, err := readGGUFString(r)
+		if err != nil {
+			return nil, err
+		}
+		strs = append(strs, str)
+	}
+
+	return strs, nil
+}
+
+// read a GGUF value of given type
+//
+// NOTE: array values are skipped, and only their lengths are returned
+func readGGUFValue(r *bufio.Reader, valueType uint32) (value any, err error) {
+	switch valueType {
+	case ggufTypeUint8:
+		var v uint8
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeInt8:
+		var v int8
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeUint16:
+		var v uint16
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeInt16:
+		var v int16
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeUint32:
+		var v uint32
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeInt32:
+		var v int32
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeFloat32:
+		var v float32
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeBool:
+		var v uint8
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v != 0, err
+	case ggufTypeString:
+		return readGGUFString(r)
+	case ggufTypeUint64:
+		var v uint64
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeInt64:
+		var v int64
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeFloat64:
+		var v float64
+		err = binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	case ggufTypeArray:
+		var elemType uint32
+		if err = binary.Read(r, binary.LittleEndian, &elemType); err != nil {
+			return nil, err
+		}
+		var count uint64
+		if err = binary.Read(r, binary.LittleEndian, &count); err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < count; i++ {
+			if _, err = readGGUFValue(r, elemType); err != nil {
+				return nil, err
+			}
+		}
+		return count, nil
+	}
+
+	return nil, fmt.Errorf("unknown value type: %d", valueType)
+}
+
+// content of the user turn in chat templates
+const chatTemplateContent = "{{if .Comment}}{{.Comment}}\n\n{{end}}{{.Original}}"
+
+// known chat templates: name, identifying token, prompt pattern (in text/template format), and pattern of each previous turn
+var knownChatTemplates = []struct {
+	name    string
+	token   string
+	pattern string
+	turn    string
+}{
+	{"ChatML", "<|im_start|>", "<|im_start|>user\n" + chatTemplateContent + "<|im_end|>\n<|im_start|>assistant\n", "<|im_start|>user\n{{.User}}<|im_end|>\n<|im_start|>assistant\n{{.Assistant}}<|im_end|>\n"},
+	{"Llama-3", "<|start_header_id|>", "<|start_header_id|>user<|end_header_id|>\n\n" + chatTemplateContent + "<|eot_id|><|start_header_id|>assistant<|end_header_id|>\n\n", "<|start_header_id|>user<|end_header_id|>\n\n{{.User}}<|eot_id|><|start_header_id|>assistant<|end_header_id|>\n\n{{.Assistant}}<|eot_id|>"},
+	{"Gemma", "<start_of_turn>", "<start_of_turn>user\n" + chatTemplateContent + "<end_of_turn>\n<start_of_turn>model\n", "<start_of_turn>user\n{{.User}}<end_of_turn>\n<start_of_turn>model\n{{.Assistant}}<end_of_turn>\n"},
+	{"Phi-3", "<|end|>", "<|user|>\n" + chatTemplateContent + "<|end|>\n<|assistant|>\n", "<|user|>\n{{.User}}<|end|>\n<|assistant|>\n{{.Assistant}}<|end|>\n"},
+	{"Zephyr", "<|user|>", "<|user|>\n" + chatTemplateContent + "</s>\n<|assistant|>\n", "<|user|>\n{{.User}}</s>\n<|assistant|>\n{{.Assistant}}</s>\n"},
+	{"Mistral", "[INST]", "[INST] " + chatTemplateContent + " [/INST]", "[INST] {{.User}} [/INST]{{.Assistant}}</s>"},
+}
+
+// read the chat template embedded in GGUF metadata, and return a matching prompt pattern (in text/template format)
+func promptPatternFromGGUF(path string) (name, pattern string, err error) {
+	var metadata ggufMetadata
+	if metadata, err = readGGUFMetadata(path); err != nil {
+		return "", "", err
+	}
+
+	chatTemplate, exists := metadata.String("tokenizer.chat_template")
+	if !exists {
+		return "", "", fmt.Errorf("no chat template in GGUF metadata")
+	}
+
+	for _, known := range knownChatTemplates {
+		if strings.Contains(chatTemplate, known.token) {
+			return known.name, "{{range .History}}" + known.turn + "{{end}}" + known.pattern, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("unknown chat template: %s", chatTemplate)
+}
+
+// default context length of models, when it cannot be determined
+const DefaultContextLength = 2048
+
+// cached context lengths, keyed by llamafile path
+var contextLengths = map[string]int{}
+var contextLengthsLock sync.Mutex
+
+// returns the context length of given model:
+// `-c` (or `--ctx-size`) in `llamafile_other_parameters`, or `*.context_length` in GGUF metadata
+func contextLength(model model) int {
+	params := model.LlamafileOtherParameters
+	for i := 0; i < len(params)-1; i++ {
+		if params[i] == "-c" || params[i] == "--ctx-size" {
+			if length, err := strconv.Atoi(params[i+1]); err == nil && length > 0 {
+				return length
+			}
+		}
+	}
+
+	if model.LlamafilePath == nil {
+		return DefaultContextLength
+	}
+
+	contextLengthsLock.Lock()
+	defer contextLengthsLock.Unlock()
+
+	if length, exists := contextLengths[*model.LlamafilePath]; exists {
+		return length
+	}
+
+	length := DefaultContextLength
+	if metadata, err := readGGUFMetadata(*model.LlamafilePath); err == nil {
+		if arch, exists := metadata.String("general.architecture"); exists {
+			if l, exists := metadata.Uint(arch + ".context_length"); exists && l > 0 {
+				length = int(l)
+			}
+		}
+	}
+	contextLengths[*model.LlamafilePath] = length
+
+	return length
+}
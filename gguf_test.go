@@ -0,0 +1,219 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// builder of GGUF files for tests
+type ggufBuilder struct {
+	kvs     bytes.Buffer
+	kvCount uint64
+
+	tensors     bytes.Buffer
+	tensorCount uint64
+}
+
+// write given values in little endian
+func (b *ggufBuilder) write(buf *bytes.Buffer, values ...any) {
+	for _, v := range values {
+		if str, ok := v.(string); ok {
+			_ = binary.Write(buf, binary.LittleEndian, uint64(len(str)))
+			buf.WriteString(str)
+		} else {
+			_ = binary.Write(buf, binary.LittleEndian, v)
+		}
+	}
+}
+
+// add a key-value pair of given type
+func (b *ggufBuilder) kv(key string, valueType uint32, values ...any) *ggufBuilder {
+	b.write(&b.kvs, key, valueType)
+	b.write(&b.kvs, values...)
+	b.kvCount++
+	return b
+}
+
+// add a tensor info with given dimensions
+func (b *ggufBuilder) tensor(name string, dimensions ...uint64) *ggufBuilder {
+	b.write(&b.tensors, name, uint32(len(dimensions)))
+	for _, d := range dimensions {
+		b.write(&b.tensors, d)
+	}
+	b.write(&b.tensors, uint32(0), uint64(0))
+	b.tensorCount++
+	return b
+}
+
+// returns the bytes of the GGUF file with given version
+func (b *ggufBuilder) bytes(version uint32) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(ggufMagic)
+	b.write(&buf, version, b.tensorCount, b.kvCount)
+	buf.Write(b.kvs.Bytes())
+	buf.Write(b.tensors.Bytes())
+	return buf.Bytes()
+}
+
+// returns a GGUF file with a llama architecture, a vocabulary, and a chat template
+func testGGUF() []byte {
+	return (&ggufBuilder{}).
+		kv("general.architecture", ggufTypeString, "llama").
+		kv("llama.context_length", ggufTypeUint32, uint32(4096)).
+		kv("llama.rope.freq_base", ggufTypeFloat32, float32(10000)).
+		kv("general.quantized", ggufTypeBool, uint8(1)).
+		kv("llama.block_count", ggufTypeInt64, int64(32)).
+		kv("tokenizer.ggml.model", ggufTypeString, "llama").
+		kv(ggufKeyTokens, ggufTypeArray, uint32(ggufTypeString), uint64(4), "▁hello", "▁world", "▁", "!").
+		kv("tokenizer.ggml.scores", ggufTypeArray, uint32(ggufTypeFloat32), uint64(3), float32(0), float32(-1), float32(-2)).
+		kv("tokenizer.chat_template", ggufTypeString, "{% for message in messages %}<|im_start|>{{ message.role }}{% endfor %}").
+		tensor("token_embd.weight", 4096, 32000).
+		tensor("output_norm.weight", 4096).
+		bytes(3)
+}
+
+func TestParseGGUF(t *testing.T) {
+	metadata, parameters, err := parseGGUF(bytes.NewReader(testGGUF()), true)
+	if err != nil {
+		t.Fatalf("failed to parse GGUF: %s", err)
+	}
+
+	if arch, _ := metadata.String("general.architecture"); arch != "llama" {
+		t.Errorf("wrong architecture: '%s'", arch)
+	}
+	if length, _ := metadata.Uint("llama.context_length"); length != 4096 {
+		t.Errorf("wrong context length: %d", length)
+	}
+	if blocks, _ := metadata.Uint("llama.block_count"); blocks != 32 {
+		t.Errorf("wrong block count: %d", blocks)
+	}
+	if base, ok := metadata["llama.rope.freq_base"].(float32); !ok || base != 10000 {
+		t.Errorf("wrong float value: %v", metadata["llama.rope.freq_base"])
+	}
+	if quantized, ok := metadata["general.quantized"].(bool); !ok || !quantized {
+		t.Errorf("wrong bool value: %v", metadata["general.quantized"])
+	}
+	if tokens, _ := metadata.Strings(ggufKeyTokens); len(tokens) != 4 || tokens[1] != "▁world" {
+		t.Errorf("wrong tokens: %v", tokens)
+	}
+	if count, _ := metadata.Uint("tokenizer.ggml.scores"); count != 3 {
+		t.Errorf("only the length of other arrays should be kept: %v", metadata["tokenizer.ggml.scores"])
+	}
+	if parameters != 4096*32000+4096 {
+		t.Errorf("wrong number of parameters: %d", parameters)
+	}
+}
+
+func TestParseGGUFErrors(t *testing.T) {
+	valid := testGGUF()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"invalid magic", append([]byte("GGML"), valid[4:]...)},
+		{"version 1", (&ggufBuilder{}).kv("general.architecture", ggufTypeString, "llama").bytes(1)},
+		{"truncated", valid[:len(valid)/2]},
+		{"unknown value type", (&ggufBuilder{}).kv("unknown", 99, uint32(0)).bytes(3)},
+		{"tokens not strings", (&ggufBuilder{}).kv(ggufKeyTokens, ggufTypeArray, uint32(ggufTypeUint32), uint64(1), uint32(1)).bytes(3)},
+	}
+
+	for _, test := range tests {
+		if _, _, err := parseGGUF(bytes.NewReader(test.data), true); err == nil {
+			t.Errorf("[%s] parsing should fail", test.name)
+		}
+	}
+}
+
+func TestReadGGUF(t *testing.T) {
+	dir := t.TempDir()
+
+	// plain GGUF file
+	ggufPath := filepath.Join(dir, "model.gguf")
+	if err := os.WriteFile(ggufPath, testGGUF(), 0644); err != nil {
+		t.Fatalf("failed to write GGUF file: %s", err)
+	}
+
+	// llamafile (zip archive with an embedded GGUF file)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if w, err := zw.CreateHeader(&zip.FileHeader{Name: "model.gguf", Method: zip.Store}); err != nil {
+		t.Fatalf("failed to create zip entry: %s", err)
+	} else if _, err := w.Write(testGGUF()); err != nil {
+		t.Fatalf("failed to write zip entry: %s", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %s", err)
+	}
+	llamafilePath := filepath.Join(dir, "model.llamafile")
+	if err := os.WriteFile(llamafilePath, buf.Bytes(), 0755); err != nil {
+		t.Fatalf("failed to write llamafile: %s", err)
+	}
+
+	// neither of them
+	otherPath := filepath.Join(dir, "other.bin")
+	if err := os.WriteFile(otherPath, []byte("not a model file"), 0644); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+
+	tests := []struct {
+		path string
+		err  bool
+	}{
+		{path: ggufPath},
+		{path: llamafilePath},
+		{path: otherPath, err: true},
+		{path: filepath.Join(dir, "nonexistent.gguf"), err: true},
+	}
+
+	for _, test := range tests {
+		metadata, err := readGGUFMetadata(test.path)
+		if test.err {
+			if err == nil {
+				t.Errorf("reading %s should fail", filepath.Base(test.path))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("failed to read %s: %s", filepath.Base(test.path), err)
+			continue
+		}
+
+		if arch, _ := metadata.String("general.architecture"); arch != "llama" {
+			t.Errorf("wrong architecture in %s: '%s'", filepath.Base(test.path), arch)
+		}
+
+		m := model{LlamafilePath: &test.path}
+		if length := contextLength(m); length != 4096 {
+			t.Errorf("wrong context length of %s: %d", filepath.Base(test.path), length)
+		}
+		if name, _, err := promptPatternFromGGUF(test.path); err != nil || name != "ChatML" {
+			t.Errorf("chat template of %s should be ChatML: '%s' (%v)", filepath.Base(test.path), name, err)
+		}
+	}
+}
+
+func TestContextLengthFromParameters(t *testing.T) {
+	tests := []struct {
+		params   []string
+		expected int
+	}{
+		{params: nil, expected: DefaultContextLength},
+		{params: []string{"-c", "8192"}, expected: 8192},
+		{params: []string{"--temp", "0.7", "--ctx-size", "1024"}, expected: 1024},
+		{params: []string{"-c", "0"}, expected: DefaultContextLength},
+		{params: []string{"-c", "invalid"}, expected: DefaultContextLength},
+		{params: []string{"-c"}, expected: DefaultContextLength},
+	}
+
+	for _, test := range tests {
+		if length := contextLength(model{LlamafileOtherParameters: test.params}); length != test.expected {
+			t.Errorf("expected context length %d for %v, got %d", test.expected, test.params, length)
+		}
+	}
+}
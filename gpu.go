@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GPU offload of a model (instead of flags in `llamafile_other_parameters`)
+type gpuOffload struct {
+	Layers      *int      `json:"layers,omitempty"`       // number of layers to offload (`-ngl`), eg. 999 for all of them
+	Backend     string    `json:"backend,omitempty"`      // GPU backend (`--gpu`): "auto", "apple", "amd", "nvidia", or "disable"
+	MainGPU     *int      `json:"main_gpu,omitempty"`     // index of the main GPU (`--main-gpu`)
+	TensorSplit []float64 `json:"tensor_split,omitempty"` // proportions of layers split across GPUs (`--tensor-split`), eg. [3, 1]
+}
+
+// GPU backends of llamafile (`--gpu`)
+var gpuBackends = []string{"auto", "apple", "amd", "nvidia", "disable"}
+
+// flags in `llamafile_other_parameters` which are replaced by fields of `gpu`
+var gpuFlags = map[string][]string{
+	"layers":       {"-ngl", "--n-gpu-layers", "--gpu-layers"},
+	"backend":      {"--gpu"},
+	"main_gpu":     {"-mg", "--main-gpu"},
+	"tensor_split": {"-ts", "--tensor-split"},
+}
+
+// timeout of probing GPU offload (for loading a model and generating a token)
+const GPUProbeTimeoutSeconds = 300
+
+// regular expression for parsing offloaded layers (eg. "llm_load_tensors: offloaded 33/33 layers to GPU")
+var offloadedLayersRegex = regexp.MustCompile(`offloaded (\d+)/(\d+) layers to GPU`)
+
+// results of GPU offload probes, keyed by model names
+var gpuOffloads = map[string]string{}
+var gpuOffloadsLock sync.Mutex
+
+// returns `llamafile_other_parameters` of this model, with flags of `gpu`, `threads`, and `batch_size`
+func (m model) otherParameters() []string {
+	params := append([]string{}, m.LlamafileOtherParameters...)
+	params = append(params, tuningParams(m)...)
+
+	if g := m.GPU; g != nil {
+		if g.Backend != "" {
+			params = append(params, "--gpu", g.Backend)
+		}
+		if g.Layers != nil {
+			params = append(params, "-ngl", strconv.Itoa(*g.Layers))
+		}
+		if g.MainGPU != nil {
+			params = append(params, "--main-gpu", strconv.Itoa(*g.MainGPU))
+		}
+		if len(g.TensorSplit) > 0 {
+			proportions := []string{}
+			for _, p := range g.TensorSplit {
+				proportions = append(proportions, strconv.FormatFloat(p, 'f', -1, 64))
+			}
+			params = append(params, "--tensor-split", strings.Join(proportions, ","))
+		}
+	}
+
+	return params
+}
+
+// validate `gpu` of given model, and return found problems
+func validateGPU(model model) (problems []string) {
+	g := model.GPU
+	if g == nil {
+		return nil
+	}
+
+	if g.Layers != nil && *g.Layers < 0 {
+		problems = append(problems, fmt.Sprintf("`gpu.layers` is negative: %d", *g.Layers))
+	}
+	if g.Backend != "" && !slices.Contains(gpuBackends, g.Backend) {
+		problems = append(problems, fmt.Sprintf("`gpu.backend` is not one of %v: %s", gpuBackends, g.Backend))
+	}
+	if g.MainGPU != nil && *g.MainGPU < 0 {
+		problems = append(problems, fmt.Sprintf("`gpu.main_gpu` is negative: %d", *g.MainGPU))
+	}
+	if len(g.TensorSplit) > 0 && slices.ContainsFunc(g.TensorSplit, func(p float64) bool { return p < 0 }) {
+		problems = append(problems, fmt.Sprintf("`gpu.tensor_split` has a negative proportion: %v", g.TensorSplit))
+	}
+
+	// (flags given twice would be ambiguous)
+	for _, key := range []string{"layers", "backend", "main_gpu", "tensor_split"} {
+		for _, flag := range gpuFlags[key] {
+			if slices.Contains(model.LlamafileOtherParameters, flag) {
+				problems = append(problems, fmt.Sprintf("`%s` in `llamafile_other_parameters` conflicts with `gpu.%s`", flag, key))
+			}
+		}
+	}
+
+	return problems
+}
+
+// probe GPU offload of enabled models with `gpu.layers`, by loading each of them and generating a token,
+// and log whether the offload actually succeeded (parsed from stderr of llamafile)
+func probeGPUOffloads(conf config) {
+	for _, model := range conf.Models {
+		if model.Disabled || !model.isLlamafile() || model.GPU == nil || model.GPU.Layers == nil || *model.GPU.Layers == 0 || model.GPU.Backend == "disable" {
+			continue
+		}
+		if model.LlamafileServerPort != nil {
+			log.Printf(">>> not probing GPU offload of model %s (in server mode)", model)
+			continue
+		}
+
+		result, err := probeGPUOffload(model)
+		if err != nil {
+			log.Printf("Error: GPU offload of model %s was not confirmed: %s", model, err)
+			result = "not confirmed: " + err.Error()
+		} else {
+			log.Printf(">>> GPU offload of model %s: %s", model, result)
+		}
+
+		gpuOffloadsLock.Lock()
+		gpuOffloads[model.String()] = result
+		gpuOffloadsLock.Unlock()
+	}
+}
+
+// load given model and generate a token, then return the number of offloaded layers parsed from stderr
+func probeGPUOffload(model model) (string, error) {
+	if err := preflightCheck(model); err != nil {
+		return "", err
+	}
+
+	l, err := launcherFor(*model.LlamafilePath)
+	if err != nil {
+		return "", err
+	}
+
+	ps := append([]string{}, l.args...)
+	ps = append(ps, "-p", "Hello")
+	ps = append(ps, model.otherParameters()...)
+	ps = append(ps, "-n", "1", "--silent-prompt")
+
+	ctx, cancel := context.WithTimeout(context.Background(), GPUProbeTimeoutSeconds*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+
+	command, ps := wrapLlamafileCommand(model, l.command, ps)
+	cmd := exec.CommandContext(ctx, command, ps...)
+	sandboxCommand(cmd, model)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run llamafile: %s (%s)", err, classifyLlamafileFailure(stderr.String()))
+	}
+
+	matches := offloadedLayersRegex.FindStringSubmatch(stderr.String())
+	if matches == nil {
+		return "", fmt.Errorf("no layers were reported as offloaded (is the GPU backend available?)")
+	}
+	if matches[1] == "0" {
+		return "", fmt.Errorf("no layers were offloaded, out of %s", matches[2])
+	}
+
+	return fmt.Sprintf("%s/%s layers", matches[1], matches[2]), nil
+}
+
+// returns the result of GPU offload probe of given model
+func gpuOffloadOf(model model) (string, bool) {
+	gpuOffloadsLock.Lock()
+	defer gpuOffloadsLock.Unlock()
+
+	result, exists := gpuOffloads[model.String()]
+	return result, exists
+}
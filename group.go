@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// group of requests fanned out from the same incoming message,
+// for combining their outputs into one reply (`combine_model_outputs`)
+type requestGroup struct {
+	sync.Mutex
+
+	expected int
+	labels   []string       // labels of registered requests, in the order of registration
+	results  map[int]string // results, keyed by the index of registration
+
+	comparisonID string // for anonymized comparisons (`/compare`)
+}
+
+// create a new request group for given number of requests,
+// returns nil if outputs are not to be combined
+func newRequestGroup(conf config, count int) *requestGroup {
+	if !conf.CombineModelOutputs || count < 2 {
+		return nil
+	}
+
+	return &requestGroup{
+		expected: count,
+		results:  map[int]string{},
+	}
+}
+
+// register a request with given label, and return its index in the group
+func (g *requestGroup) register(label string) int {
+	g.Lock()
+	defer g.Unlock()
+
+	// anonymize labels of comparisons
+	if g.comparisonID != "" {
+		label = comparisonLabel(len(g.labels))
+	}
+
+	g.labels = append(g.labels, label)
+
+	return len(g.labels) - 1
+}
+
+// check if it is a group of an anonymized comparison
+func (g *requestGroup) isComparison() bool {
+	return g != nil && g.comparisonID != ""
+}
+
+// add a result of the request with given index,
+// and return the labelled results (in the order of registration) if all results are collected
+func (g *requestGroup) add(index int, result string) (sections []string, labels []string, complete bool) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.results[index] = result
+
+	if len(g.results) < g.expected {
+		return nil, nil, false
+	}
+
+	for i, label := range g.labels {
+		sections = append(sections, fmt.Sprintf("<b>[%s]</b>\n%s", escapeForHTML(label), g.results[i]))
+	}
+
+	return sections, g.labels, true
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/meinside/telegram-llamafiles-bot/proto"
+)
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/llamafiles.proto
+
+// default listen address of the gRPC service
+const DefaultGRPCListenAddress = "127.0.0.1:50051"
+
+// interval of streaming queue positions of requests from the gRPC service
+const GRPCQueuePositionIntervalSeconds = 1
+
+// config of the gRPC service (defined in proto/llamafiles.proto)
+type grpcConfig struct {
+	ListenAddress  string   `json:"listen_address,omitempty"`  // (default: "127.0.0.1:50051")
+	Tokens         []string `json:"tokens,omitempty"`          // if set, requests need one of them (or of `priority_tokens`) in `authorization: Bearer` metadata
+	PriorityTokens []string `json:"priority_tokens,omitempty"` // requests with these tokens can jump ahead in the queue with `priority`
+}
+
+// server of the gRPC service (generated from proto/llamafiles.proto)
+type grpcServer struct {
+	pb.UnimplementedLlamafilesServer
+
+	conf     config
+	bot      *tg.Bot
+	reqQueue *priorityQueue
+}
+
+// start the gRPC service in background, if configured
+//
+// as with the REST API, requests are enqueued into the same queue as the ones from Telegram
+func startGRPCServer(conf config, bot *tg.Bot, reqQueue *priorityQueue) {
+	if conf.GRPC == nil {
+		return
+	}
+
+	addr := grpcListenAddress(conf)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("Error: failed to listen for gRPC service on %s: %s", addr, err)
+		return
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterLlamafilesServer(server, &grpcServer{conf: conf, bot: bot, reqQueue: reqQueue})
+
+	log.Printf(">>> starting gRPC service on %s", addr)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Printf("Error: gRPC service stopped: %s", err)
+		}
+	}()
+}
+
+// returns the listen address of the gRPC service in given config
+func grpcListenAddress(conf config) string {
+	if conf.GRPC == nil || conf.GRPC.ListenAddress == "" {
+		return DefaultGRPCListenAddress
+	}
+	return conf.GRPC.ListenAddress
+}
+
+// Status implements `Status` of the gRPC service
+func (s *grpcServer) Status(ctx context.Context, _ *pb.StatusRequest) (*pb.StatusResponse, error) {
+	if _, err := authorizeGRPC(liveConfig(s.conf), ctx); err != nil {
+		return nil, err
+	}
+	return grpcStatus(), nil
+}
+
+// ListModels implements `ListModels` of the gRPC service
+func (s *grpcServer) ListModels(ctx context.Context, _ *pb.ListModelsRequest) (*pb.ListModelsResponse, error) {
+	c := liveConfig(s.conf)
+	if _, err := authorizeGRPC(c, ctx); err != nil {
+		return nil, err
+	}
+	return grpcModels(c), nil
+}
+
+// Generate implements `Generate` of the gRPC service
+func (s *grpcServer) Generate(req *pb.GenerateRequest, stream grpc.ServerStreamingServer[pb.GenerateResponse]) error {
+	return handleGRPCGenerate(liveConfig(s.conf), s.bot, s.reqQueue, req, stream)
+}
+
+// check the token in the metadata of given context, and return whether it has priority
+func authorizeGRPC(conf config, ctx context.Context) (priority bool, err error) {
+	if len(conf.GRPC.Tokens) == 0 && len(conf.GRPC.PriorityTokens) == 0 {
+		return false, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token, _ := strings.CutPrefix(value, "Bearer ")
+		if containsToken(conf.GRPC.PriorityTokens, token) {
+			return true, nil
+		}
+		if containsToken(conf.GRPC.Tokens, token) {
+			return false, nil
+		}
+	}
+	return false, status.Error(codes.Unauthenticated, "invalid token")
+}
+
+// check if given tokens contain given token (in constant time for each)
+func containsToken(tokens []string, token string) bool {
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// returns `StatusResponse` with the current state of the queue
+func grpcStatus() *pb.StatusResponse {
+	s := currentAPIStatus()
+
+	return &pb.StatusResponse{
+		UptimeSeconds: s.UptimeSeconds,
+		Queued:        uint32(s.Queued),
+		InFlight:      uint32(s.InFlight),
+		Overflows:     uint32(s.Overflows),
+	}
+}
+
+// returns `ListModelsResponse` with the enabled models
+func grpcModels(conf config) *pb.ListModelsResponse {
+	res := &pb.ListModelsResponse{}
+	for _, m := range enabledAPIModels(conf) {
+		res.Models = append(res.Models, &pb.Model{
+			Index:       uint32(m.Index),
+			Name:        m.Name,
+			Generations: uint32(m.Generations),
+			Failures:    uint32(m.Failures),
+		})
+	}
+	return res
+}
+
+// handle `Generate`: enqueue the request, stream its position while it is queued, and then its result
+func handleGRPCGenerate(conf config, bot *tg.Bot, reqQueue *priorityQueue, req *pb.GenerateRequest, stream grpc.ServerStreamingServer[pb.GenerateResponse]) error {
+	priority, err := authorizeGRPC(conf, stream.Context())
+	if err != nil {
+		return err
+	}
+
+	body := apiGenerateRequest{
+		Model:       req.Model,
+		Prompt:      req.Prompt,
+		Temperature: req.Temperature,
+	}
+	if req.MaxTokens != nil {
+		tokens := int(*req.MaxTokens)
+		body.MaxTokens = &tokens
+	}
+	if req.Seed != nil {
+		seed := int(*req.Seed)
+		body.Seed = &seed
+	}
+	if req.Priority && !priority {
+		return status.Error(codes.PermissionDenied, "`priority` is only for priority tokens")
+	}
+
+	var id uint64
+	response := make(chan apiResult, 1)
+	if err := enqueueAPIRequest(conf, bot, reqQueue, body, requestExtra{response: response, priority: req.Priority, enqueuedID: &id}); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ticker := time.NewTicker(GRPCQueuePositionIntervalSeconds * time.Second)
+	defer ticker.Stop()
+
+	lastPosition := 0
+	for {
+		if position, queued := tracker.position(id); queued && position != lastPosition {
+			if err := stream.Send(&pb.GenerateResponse{
+				Id:     id,
+				Result: &pb.GenerateResponse_QueuePosition{QueuePosition: uint32(position)},
+			}); err != nil {
+				return err
+			}
+			lastPosition = position
+		}
+
+		select {
+		case result := <-response:
+			res := &pb.GenerateResponse{
+				Id:    result.ID,
+				Model: result.Model,
+			}
+			if result.Error != "" {
+				res.Result = &pb.GenerateResponse_Error{Error: result.Error}
+			} else {
+				res.Result = &pb.GenerateResponse_Output{Output: result.Output}
+			}
+			return stream.Send(res)
+		case <-stream.Context().Done():
+			log.Printf(">>> client of gRPC service went away before the result")
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// validate the gRPC service in given config, and return found problems
+func validateGRPC(conf config) (problems []string) {
+	if conf.GRPC == nil {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(grpcListenAddress(conf)); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid listen address of gRPC service: %s", err))
+	}
+	for _, token := range slices.Concat(conf.GRPC.Tokens, conf.GRPC.PriorityTokens) {
+		if token == "" {
+			problems = append(problems, "`tokens` and `priority_tokens` of `grpc` should not be empty strings")
+			break
+		}
+	}
+
+	return problems
+}
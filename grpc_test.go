@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/meinside/telegram-llamafiles-bot/proto"
+)
+
+func TestGRPCService(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %s", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	path := "/models/test.llamafile"
+	conf := config{
+		Models: []model{{LlamafilePath: &path}},
+		GRPC:   &grpcConfig{ListenAddress: addr, Tokens: []string{"secret"}},
+	}
+	startGRPCServer(conf, nil, newPriorityQueue(1))
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %s", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewLlamafilesClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// without a token
+	if _, err := client.Status(ctx, &pb.StatusRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("should be unauthenticated without a token: %v", err)
+	}
+
+	// with a token
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	if _, err := client.Status(ctx, &pb.StatusRequest{}); err != nil {
+		t.Fatalf("failed to get status: %s", err)
+	}
+	models, err := client.ListModels(ctx, &pb.ListModelsRequest{})
+	if err != nil {
+		t.Fatalf("failed to list models: %s", err)
+	}
+	if len(models.Models) != 1 {
+		t.Errorf("unexpected number of models: %d", len(models.Models))
+	}
+
+	// priority without a priority token
+	stream, err := client.Generate(ctx, &pb.GenerateRequest{Prompt: "hello", Priority: true})
+	if err != nil {
+		t.Fatalf("failed to generate: %s", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("priority should be denied: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// default listen address of the health endpoints
+const DefaultHealthListenAddress = "127.0.0.1:8082"
+
+// numbers of consecutive failures, after which polling (or a model) is considered unhealthy
+const (
+	ReadinessPollingErrorThreshold = 3
+	ReadinessModelFailureThreshold = 3
+)
+
+// config of the health endpoints, for container orchestrators and uptime monitors
+type healthConfig struct {
+	ListenAddress string `json:"listen_address,omitempty"` // (default: "127.0.0.1:8082", eg. ":8082" for containers)
+}
+
+// start the health endpoints in background, if configured:
+//
+// `/healthz` for liveness (the process is alive), and `/readyz` for readiness
+// (Telegram is reachable, at least one model is healthy, and the queue is not full)
+func startHealthServer(conf config, reqQueue *priorityQueue) {
+	if conf.Health == nil {
+		return
+	}
+
+	addr := healthListenAddress(conf)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if problems := readinessProblems(liveConfig(conf), reqQueue); len(problems) > 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintf(w, "not ready:\n- %s\n", strings.Join(problems, "\n- "))
+			return
+		}
+		_, _ = fmt.Fprintln(w, "ok")
+	})
+
+	log.Printf(">>> starting health endpoints on http://%s/healthz and /readyz", addr)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Error: health endpoints stopped: %s", err)
+		}
+	}()
+}
+
+// returns the listen address of the health endpoints in given config
+func healthListenAddress(conf config) string {
+	if conf.Health == nil || conf.Health.ListenAddress == "" {
+		return DefaultHealthListenAddress
+	}
+	return conf.Health.ListenAddress
+}
+
+// returns the reasons why the bot is not ready for requests (empty if ready)
+func readinessProblems(conf config, reqQueue *priorityQueue) (problems []string) {
+	if count := consecutiveErrorCount("polling updates"); count >= ReadinessPollingErrorThreshold {
+		problems = append(problems, fmt.Sprintf("telegram is unreachable (polling failed %d times in a row)", count))
+	}
+
+	healthy := false
+	for _, m := range conf.Models {
+		if !m.isDisabled() && stats.consecutiveFailures(m) < ReadinessModelFailureThreshold {
+			healthy = true
+			break
+		}
+	}
+	if !healthy {
+		problems = append(problems, "no model is healthy (all of them are disabled, or failing)")
+	}
+
+	if length, capacity := reqQueue.length(), reqQueue.capacity; length >= capacity {
+		problems = append(problems, fmt.Sprintf("request queue is full (%d/%d)", length, capacity))
+	}
+
+	return problems
+}
+
+// check if given listen address of the health endpoints is valid
+func validateHealthListenAddress(addr string) error {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return fmt.Errorf("invalid listen address of health endpoints: %s", err)
+	}
+	return nil
+}
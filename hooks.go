@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeout of each hook command
+const HookTimeoutSeconds = 30
+
+// configs for pre/post-processing hooks
+//
+// each hook is a command which receives the text on stdin, and returns the transformed one on stdout
+type hooksConfig struct {
+	Pre  [][]string `json:"pre,omitempty"`  // hooks for prompts (before generation)
+	Post [][]string `json:"post,omitempty"` // hooks for generated outputs (after generation)
+}
+
+// run given hooks in order with given text, and return the transformed text
+//
+// NOTE: the model name, chat id, and username of the request are passed as environment variables
+// (`HOOK_MODEL`, `HOOK_CHAT_ID`, and `HOOK_USERNAME`)
+func runHooks(ctx context.Context, hooks [][]string, request request, text string) (string, error) {
+	env := append(os.Environ(),
+		"HOOK_MODEL="+request.model.String(),
+		"HOOK_CHAT_ID="+strconv.FormatInt(request.targetChatID, 10),
+	)
+	if request.username != nil {
+		env = append(env, "HOOK_USERNAME="+*request.username)
+	}
+
+	for _, hook := range hooks {
+		if len(hook) == 0 {
+			continue
+		}
+
+		hookCtx, cancel := context.WithTimeout(ctx, HookTimeoutSeconds*time.Second)
+
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(hookCtx, hook[0], hook[1:]...)
+		cmd.Env = env
+		cmd.Stdin = strings.NewReader(text)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+
+		cancel()
+
+		if err != nil {
+			return "", fmt.Errorf("hook '%s' failed: %s (%s)", strings.Join(hook, " "), err, strings.TrimSpace(stderr.String()))
+		}
+
+		text = string(out)
+	}
+
+	return text, nil
+}
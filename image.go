@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// constants for image generation
+const (
+	ImageGenerationTimeoutSeconds = 600
+
+	MaxPhotoCaptionLength = 1024
+)
+
+// returns enabled image generators
+func enabledImageModels(conf config) (models []model) {
+	for _, model := range conf.Models {
+		if model.isDisabled() || !model.isImageGenerator() {
+			continue
+		}
+
+		models = append(models, model)
+	}
+	return models
+}
+
+// generate an image with given image generator and prompt, and return the PNG bytes
+//
+// (the generator is killed when given context is done, or after `ImageGenerationTimeoutSeconds`)
+func generateImage(ctx context.Context, model model, prompt string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "image-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "generated.png")
+
+	params := []string{}
+	for _, param := range model.ImageGeneratorParameters {
+		param = strings.ReplaceAll(param, "%p", prompt)
+		param = strings.ReplaceAll(param, "%o", output)
+		params = append(params, param)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ImageGenerationTimeoutSeconds*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, *model.ImageGeneratorPath, params...)
+	cmd.Env = environFor(model)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %d seconds", ImageGenerationTimeoutSeconds)
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		log.Printf("Error: image generator '%s' failed with stderr:\n%s", *model.ImageGeneratorPath, stderr.String())
+
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return os.ReadFile(output)
+}
+
+// handle an image generation request, send the generated image, and finish the request
+func handleImageRequest(ctx context.Context, conf config, bot *tg.Bot, request request) {
+	model := request.model
+
+	if acted := bot.SendChatAction(request.targetChatID, tg.ChatActionUploadPhoto, tg.OptionsSendChatAction{}); !acted.Ok {
+		limiter.pauseIfNeeded(acted.Parameters)
+	}
+
+	image, err := generateImage(ctx, model, *request.originalText)
+	if err != nil {
+		stats.recordFailure(request, err)
+
+		finishRequest(conf, bot, request, fmt.Sprintf(`Failed to generate an image: <em>%s</em>`, escapeForHTML(err.Error())))
+		return
+	}
+
+	stats.recordSuccess(request, time.Since(request.startedProcessingAt), nil)
+
+	caption := *request.originalText
+	if len(caption) > MaxPhotoCaptionLength {
+		caption = strings.ToValidUTF8(caption[:MaxPhotoCaptionLength], "")
+	}
+
+	limiter.wait(conf)
+
+	options := tg.OptionsSendPhoto{}.
+		SetReplyParameters(tg.ReplyParameters{MessageID: request.targetMessageID}).
+		SetCaption(caption)
+	if sent := bot.SendPhoto(request.targetChatID, tg.InputFileFromBytes(image), options); !sent.Ok {
+		limiter.pauseIfNeeded(sent.Parameters)
+
+		log.Printf("Error: failed to send photo: %s", *sent.Description)
+
+		finishRequest(conf, bot, request, fmt.Sprintf(`Failed to send the generated image: <em>%s</em>`, escapeForHTML(*sent.Description)))
+		return
+	}
+
+	// (the image was sent, so it is only recorded)
+	request.delivered = true
+	finishRequest(conf, bot, request, caption)
+}
+
+// handle `/imagine` command: enqueue requests for enabled image generators
+func handleImagineCommand(conf config, bot *tg.Bot, reqQueue *priorityQueue, args string, message tg.Message) {
+	if args == "" {
+		sendReply(conf, bot, message, fmt.Sprintf("Usage: %s [PROMPT]", CommandImagine))
+		return
+	}
+
+	models := enabledImageModels(conf)
+	if len(models) == 0 {
+		sendReply(conf, bot, message, "No image generator is configured.")
+		return
+	}
+
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, &args, nil, generationOptions{}, nil, nil, message)
+	}
+}
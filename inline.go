@@ -0,0 +1,91 @@
+package main
+
+import (
+	"html"
+	"log"
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// latest inline query ids, keyed by user id
+//
+// (telegram sends a new inline query on every keystroke, so only the latest one will be processed)
+var latestInlineQueries = map[int64]string{}
+var latestInlineQueriesLock sync.Mutex
+
+// returns the model designated for inline queries, or nil if there is none
+func inlineQueryModel(conf config) *model {
+	for _, model := range conf.Models {
+		if model.UseForInlineQuery && !model.isDisabled() && !model.isImageGenerator() {
+			return &model
+		}
+	}
+
+	return nil
+}
+
+// handle inline query: enqueue a request for it (after debouncing)
+func handleInlineQuery(conf config, bot *tg.Bot, reqQueue *priorityQueue, inlineQuery tg.InlineQuery) {
+	if inlineQuery.Query == "" {
+		return
+	}
+
+	model := inlineQueryModel(conf)
+	if model == nil {
+		log.Printf("Error: no model is designated for inline queries")
+		return
+	}
+
+	// debounce: wait for a while and process it only when it is still the latest one
+	latestInlineQueriesLock.Lock()
+	latestInlineQueries[inlineQuery.From.ID] = inlineQuery.ID
+	latestInlineQueriesLock.Unlock()
+
+	time.Sleep(InlineQueryDebounceMilliseconds * time.Millisecond)
+
+	latestInlineQueriesLock.Lock()
+	latest := latestInlineQueries[inlineQuery.From.ID] == inlineQuery.ID
+	if latest {
+		delete(latestInlineQueries, inlineQuery.From.ID)
+	}
+	latestInlineQueriesLock.Unlock()
+
+	if !latest {
+		return
+	}
+
+	log.Printf(">>> enqueueing inline query for model: %s", model)
+
+	// (handled in the queue like other requests, and answered when finished)
+	text := escapeForShell(inlineQuery.Query)
+	message := tg.Message{
+		From: &inlineQuery.From,
+		Chat: tg.Chat{ID: inlineQuery.From.ID}, // (availability is checked for the private chat with the user, as the chat of the query is unknown)
+		Date: int(time.Now().Unix()),
+	}
+	enqueueRequest(conf, bot, reqQueue, *model, &text, nil, generationOptions{}, nil, &requestExtra{inlineQueryID: inlineQuery.ID}, message)
+}
+
+// check if given inline query request waited too long to be answered
+func inlineQueryExpired(request request) bool {
+	return request.extra.inlineQueryID != "" && time.Since(request.enqueuedAt) > InlineQueryTimeoutSeconds*time.Second
+}
+
+// answer the inline query of given request with its generated text (errors are only logged, as they cannot be shown)
+func answerInlineQuery(conf config, bot *tg.Bot, request request, reply string) {
+	if request.extra.output == "" {
+		request.logger().Printf(">>> not answering inline query of request %s: %s", request, loggable(conf, html.UnescapeString(htmlTagRegexp.ReplaceAllString(reply, ""))))
+		return
+	}
+	if inlineQueryExpired(request) {
+		request.logger().Printf(">>> not answering inline query of request %s: it expired", request)
+		return
+	}
+
+	article, _ := tg.NewInlineQueryResultArticle(request.model.label(), request.extra.output, request.extra.output)
+	if answered := bot.AnswerInlineQuery(request.extra.inlineQueryID, []any{article}, tg.OptionsAnswerInlineQuery{}.SetIsPersonal(true)); !answered.Ok {
+		request.logger().Printf("Error: failed to answer inline query of request %s: %s", request, *answered.Description)
+	}
+}
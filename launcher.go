@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// launcher for running llamafiles
+type launcher struct {
+	name    string
+	command string
+	args    []string // arguments to be prepended before llamafile's parameters
+	version string   // output of `--version`
+}
+
+// launchers which were verified for each llamafile, keyed by llamafile path
+var launchers = map[string]launcher{}
+var launchersLock sync.Mutex
+
+// returns a working launcher for given llamafile
+//
+// each candidate launcher is verified with `--version`, and the first working one is cached
+func launcherFor(llamafilePath string) (launcher, error) {
+	launchersLock.Lock()
+	defer launchersLock.Unlock()
+
+	if l, exists := launchers[llamafilePath]; exists {
+		return l, nil
+	}
+
+	for _, l := range candidateLaunchers(llamafilePath) {
+		if out, err := exec.Command(l.command, append(l.args, "--version")...).Output(); err == nil {
+			log.Printf(">>> using launcher '%s' for llamafile: %s", l.name, filepath.Base(llamafilePath))
+
+			l.version = strings.TrimSpace(string(out))
+
+			launchers[llamafilePath] = l
+			return l, nil
+		}
+	}
+
+	return launcher{}, fmt.Errorf("no working launcher for '%s'", llamafilePath)
+}
+
+// add execute permissions to llamafiles of enabled models
+func fixExecutePermissions(conf config) {
+	for _, model := range conf.Models {
+		if model.Disabled || model.LlamafilePath == nil {
+			continue
+		}
+
+		if err := fixExecutePermission(*model.LlamafilePath); err != nil {
+			log.Printf("Error: failed to fix execute permission of %s: %s", *model.LlamafilePath, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+)
+
+// candidate launchers of given llamafile, in the order of preference
+//
+// on Linux, llamafiles often fail to run directly due to binfmt_misc conflicts (eg. WINE or WSL),
+// so they are launched with `sh` or the `ape` loader as fallbacks
+func candidateLaunchers(llamafilePath string) (candidates []launcher) {
+	direct := launcher{name: "direct", command: llamafilePath}
+	sh := launcher{name: "sh", command: "sh", args: []string{llamafilePath}}
+	bash := launcher{name: "bash", command: "bash", args: []string{llamafilePath}}
+
+	if runtime.GOOS == "darwin" {
+		// FIXME: without `bash`, llamafile fails to run (on macOS)
+		return []launcher{bash, direct, sh}
+	}
+
+	candidates = []launcher{direct, sh}
+	for _, ape := range []string{"ape", "/usr/bin/ape"} {
+		if apePath, err := exec.LookPath(ape); err == nil {
+			candidates = append(candidates, launcher{name: "ape", command: apePath, args: []string{llamafilePath}})
+			break
+		}
+	}
+	return append(candidates, bash)
+}
+
+// add execute permissions to given llamafile if it has none
+//
+// (only where it has read permissions, just like `chmod +x`)
+func fixExecutePermission(llamafilePath string) error {
+	info, err := os.Stat(llamafilePath)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("not a regular file: %s", llamafilePath)
+	}
+
+	perm := info.Mode().Perm()
+	if perm&0111 != 0 {
+		return nil // already executable
+	}
+
+	fixed := perm | (perm&0444)>>2
+	if err := os.Chmod(llamafilePath, fixed); err != nil {
+		return err
+	}
+
+	log.Printf(">>> added execute permission to llamafile: %s (%s => %s)", llamafilePath, perm, fixed)
+
+	return nil
+}
+
+// check if given file is executable
+func isExecutable(info os.FileInfo) bool {
+	return info.Mode().Perm()&0111 != 0
+}
+
+// returns the name of the resource limit which killed the process of given error (if it was)
+func killedByResourceLimit(err error, limits *resourceLimits) (string, bool) {
+	var exitErr *exec.ExitError
+	if limits == nil || !errors.As(err, &exitErr) {
+		return "", false
+	}
+
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return "", false
+	}
+
+	var signal syscall.Signal
+	if status.Signaled() {
+		signal = status.Signal()
+	} else if code := status.ExitStatus(); code > 128 { // (killed process was launched through a shell)
+		signal = syscall.Signal(code - 128)
+	} else {
+		return "", false
+	}
+
+	switch signal {
+	case syscall.SIGXCPU:
+		return "cpu_seconds", true
+	case syscall.SIGKILL:
+		if limits.CPUSeconds > 0 { // (killed at the hard limit of CPU time)
+			return "cpu_seconds", true
+		}
+	case syscall.SIGXFSZ:
+		return "file_size_mb", true
+	}
+
+	return "", false
+}
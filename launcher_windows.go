@@ -0,0 +1,34 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// candidate launchers of given llamafile
+//
+// NOTE: on Windows, llamafiles should be renamed to have the `.exe` extension,
+// and then they can be launched directly without `bash`
+func candidateLaunchers(llamafilePath string) []launcher {
+	return []launcher{
+		{name: "direct", command: llamafilePath},
+	}
+}
+
+// execute permissions are not needed on Windows
+func fixExecutePermission(llamafilePath string) error {
+	return nil
+}
+
+// check if given file is executable (has the `.exe` extension)
+func isExecutable(info os.FileInfo) bool {
+	return strings.EqualFold(filepath.Ext(info.Name()), ".exe")
+}
+
+// resource limits are not supported on Windows
+func killedByResourceLimit(err error, limits *resourceLimits) (string, bool) {
+	return "", false
+}
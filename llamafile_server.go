@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// timeout of llamafile servers' startup (including the loading of weights)
+const LlamafileServerStartupTimeoutSeconds = 300
+
+// running llamafile servers, keyed by port
+var llamafileServers = map[int]*exec.Cmd{}
+var llamafileServersLock sync.Mutex
+
+// request body of llamafile server's `/completion`
+type llamafileCompletionRequest struct {
+	Prompt      string          `json:"prompt"`
+	NPredict    *int            `json:"n_predict,omitempty"`
+	Temperature *float64        `json:"temperature,omitempty"`
+	Seed        *int            `json:"seed,omitempty"`
+	Stop        []string        `json:"stop,omitempty"`
+	Grammar     *string         `json:"grammar,omitempty"`
+	JSONSchema  json.RawMessage `json:"json_schema,omitempty"`
+	CachePrompt bool            `json:"cache_prompt"`
+}
+
+// response body of llamafile server's `/completion`
+type llamafileCompletionResponse struct {
+	Content string `json:"content"`
+	Timings *struct {
+		PromptN     int     `json:"prompt_n"`
+		PromptMS    float64 `json:"prompt_ms"`
+		PredictedN  int     `json:"predicted_n"`
+		PredictedMS float64 `json:"predicted_ms"`
+	} `json:"timings,omitempty"`
+}
+
+// generator which requests to a llamafile running in server mode
+type llamafileServerGenerator struct {
+	model model
+}
+
+// Generate implements Generator interface
+//
+// NOTE: the llamafile server is started on the first generation (or on warmup)
+func (g llamafileServerGenerator) Generate(ctx context.Context, prompt generationPrompt, opts generationOptions) (result generationResult, err error) {
+	model := g.model
+
+	baseURL, err := startLlamafileServer(model)
+	if err != nil {
+		return result, err
+	}
+
+	completion := llamafileCompletionRequest{
+		Prompt:      prompt.text,
+		NPredict:    opts.maxTokens,
+		Temperature: opts.temperature,
+		Seed:        opts.seed,
+		Stop:        model.StopSequences,
+		Grammar:     opts.grammar,
+		CachePrompt: true,
+	}
+	if opts.grammar == nil {
+		if model.GrammarFile != nil {
+			var grammar []byte
+			if grammar, err = os.ReadFile(*model.GrammarFile); err != nil {
+				return result, fmt.Errorf("failed to read grammar file: %s", err)
+			}
+			text := string(grammar)
+			completion.Grammar = &text
+		} else if len(model.JSONSchema) > 0 {
+			completion.JSONSchema = model.JSONSchema
+		}
+	}
+
+	body, err := json.Marshal(completion)
+	if err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/completion", bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := http.Client{Timeout: RemoteBackendTimeoutSeconds * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(bytes))
+	}
+
+	var res llamafileCompletionResponse
+	if err := json.Unmarshal(bytes, &res); err != nil {
+		return result, fmt.Errorf("failed to parse response: %s", err)
+	}
+
+	if res.Timings != nil {
+		result.timings = &llamafileTimings{
+			promptTokens:       res.Timings.PromptN,
+			promptEvalDuration: time.Duration(res.Timings.PromptMS * float64(time.Millisecond)),
+			generatedTokens:    res.Timings.PredictedN,
+			evalDuration:       time.Duration(res.Timings.PredictedMS * float64(time.Millisecond)),
+		}
+	}
+	result.text = trimAtStopSequences(sanitizeOutput(res.Content), model.StopSequences)
+
+	return result, nil
+}
+
+// start the llamafile of given model in server mode (if it is not running yet), and return its base url
+//
+// the server keeps running until it exits by itself (or the bot is terminated)
+func startLlamafileServer(model model) (baseURL string, err error) {
+	llamafileServersLock.Lock()
+	defer llamafileServersLock.Unlock()
+
+	port := *model.LlamafileServerPort
+	baseURL = fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	if _, running := llamafileServers[port]; running {
+		return baseURL, nil
+	}
+
+	if err := preflightCheck(model); err != nil {
+		return "", err
+	}
+
+	l, err := launcherFor(*model.LlamafilePath)
+	if err != nil {
+		return "", err
+	}
+
+	ps := append([]string{}, l.args...)
+	ps = append(ps, model.otherParameters()...)
+	ps = append(ps, "--server", "--nobrowser", "--host", "127.0.0.1", "--port", strconv.Itoa(port))
+
+	log.Printf(">>> starting llamafile server on port %d: %s", port, filepath.Base(*model.LlamafilePath))
+
+	command, ps := wrapLlamafileCommand(model, l.command, ps)
+	cmd := exec.Command(command, ps...)
+	sandboxCommand(cmd, model)
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to start llamafile server: %s", err)
+	}
+	llamafileServers[port] = cmd
+
+	exited := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+
+		log.Printf(">>> llamafile server on port %d exited: %v", port, err)
+
+		exited <- err // (buffered, so it doesn't block even after the startup)
+
+		llamafileServersLock.Lock()
+		if llamafileServers[port] == cmd {
+			delete(llamafileServers, port)
+		}
+		llamafileServersLock.Unlock()
+	}()
+
+	// wait until it becomes healthy
+	client := http.Client{Timeout: time.Second}
+	deadline := time.Now().Add(LlamafileServerStartupTimeoutSeconds * time.Second)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			delete(llamafileServers, port)
+			return "", fmt.Errorf("llamafile server exited while starting: %v", err)
+		case <-time.After(time.Second):
+		}
+
+		if resp, err := client.Get(baseURL + "/health"); err == nil {
+			resp.Body.Close()
+
+			if resp.StatusCode == http.StatusOK {
+				log.Printf(">>> llamafile server on port %d is ready", port)
+
+				return baseURL, nil
+			}
+		}
+	}
+
+	_ = cmd.Process.Kill()
+	delete(llamafileServers, port)
+
+	return "", fmt.Errorf("llamafile server on port %d did not become ready in %d seconds", port, LlamafileServerStartupTimeoutSeconds)
+}
+
+// stop all running llamafile servers (eg. before exiting from CLI subcommands)
+func stopLlamafileServers() {
+	llamafileServersLock.Lock()
+	defer llamafileServersLock.Unlock()
+
+	for port, cmd := range llamafileServers {
+		log.Printf(">>> stopping llamafile server on port %d", port)
+
+		_ = cmd.Process.Kill()
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaults of log file rotation
+const (
+	DefaultLogFileMaxSizeMB  = 100
+	DefaultLogFileMaxBackups = 7
+)
+
+// layout of timestamps in names of rotated log files
+const rotatedLogFileTimeLayout = "20060102-150405.000"
+
+// config of logging to a file, with rotation
+type logFileConfig struct {
+	Path string `json:"path"`
+
+	MaxSizeMB   int  `json:"max_size_mb,omitempty"`   // rotate the file when it gets larger than this (default: 100)
+	MaxAgeHours int  `json:"max_age_hours,omitempty"` // rotate the file when it gets older than this (eg. 24 for daily, 0 = no age-based rotation)
+	MaxBackups  int  `json:"max_backups,omitempty"`   // number of rotated files to keep (default: 7)
+	Compress    bool `json:"compress,omitempty"`      // compress rotated files with gzip
+
+	DisableStderr bool `json:"disable_stderr,omitempty"` // log only to the file, not to stderr
+}
+
+// outputs of logs
+var logOutputs struct {
+	sync.Mutex
+
+	writers       []io.Writer
+	disableStderr bool
+
+	plain      io.Writer             // outputs except structured ones (nil if none)
+	structured []structuredLogWriter // structured outputs (for passing fields of requests)
+}
+
+// add given writer to the outputs of logs
+func addLogOutput(w io.Writer) {
+	logOutputs.Lock()
+	defer logOutputs.Unlock()
+
+	logOutputs.writers = append(logOutputs.writers, w)
+
+	writers := append([]io.Writer{}, logOutputs.writers...)
+	if !logOutputs.disableStderr {
+		writers = append([]io.Writer{os.Stderr}, writers...)
+	}
+	log.SetOutput(io.MultiWriter(writers...))
+
+	plain := []io.Writer{}
+	logOutputs.structured = nil
+	for _, writer := range writers {
+		if structured, ok := writer.(structuredLogWriter); ok {
+			logOutputs.structured = append(logOutputs.structured, structured)
+		} else {
+			plain = append(plain, writer)
+		}
+	}
+	logOutputs.plain = nil
+	if len(plain) > 0 {
+		logOutputs.plain = io.MultiWriter(plain...)
+	}
+}
+
+// start logging to the file in given config (if any)
+func openLogFile(conf config) error {
+	if conf.LogFile == nil {
+		return nil
+	}
+	if conf.LogFile.Path == "" {
+		return fmt.Errorf("`path` of `log_file` is empty")
+	}
+
+	f := &rotatingFile{conf: *conf.LogFile}
+	if err := f.open(); err != nil {
+		return fmt.Errorf("failed to open log file: %s", err)
+	}
+
+	logOutputs.Lock()
+	logOutputs.disableStderr = conf.LogFile.DisableStderr
+	logOutputs.Unlock()
+
+	addLogOutput(f)
+
+	log.Printf(">>> logging to file: %s", conf.LogFile.Path)
+
+	return nil
+}
+
+// file which is rotated by its size and age
+type rotatingFile struct {
+	sync.Mutex
+
+	conf logFileConfig
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	cleanup sync.Mutex // (for compressing and cleaning up rotated files one rotation at a time)
+}
+
+// write given bytes to the file, after rotating it if needed
+//
+// NOTE: errors are printed to stderr, not logged (as it is the writer of logs)
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	maxSize := int64(DefaultLogFileMaxSizeMB) * 1024 * 1024
+	if f.conf.MaxSizeMB > 0 {
+		maxSize = int64(f.conf.MaxSizeMB) * 1024 * 1024
+	}
+	tooOld := f.conf.MaxAgeHours > 0 && time.Since(f.openedAt) > time.Duration(f.conf.MaxAgeHours)*time.Hour
+
+	if f.size > 0 && (f.size+int64(len(p)) > maxSize || tooOld) {
+		if err := f.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to rotate log file: %s\n", err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// open (or create) the file for appending
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.conf.Path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.conf.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	if f.size > 0 {
+		f.openedAt = info.ModTime() // (for not keeping old logs for too long)
+	}
+
+	return nil
+}
+
+// rename the file with a timestamp, open a new one, and clean up old rotated files
+//
+// (when it fails, the original file is reopened for keeping logs)
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	rotated := f.conf.Path + "." + time.Now().Format(rotatedLogFileTimeLayout)
+	if err := os.Rename(f.conf.Path, rotated); err != nil {
+		return errors.Join(err, f.open())
+	}
+	if err := f.open(); err != nil {
+		if renameErr := os.Rename(rotated, f.conf.Path); renameErr != nil {
+			return errors.Join(err, renameErr)
+		}
+		return errors.Join(err, f.open())
+	}
+
+	// (compressed and cleaned up in background, for not blocking logs)
+	go func() {
+		f.cleanup.Lock()
+		defer f.cleanup.Unlock()
+
+		if f.conf.Compress {
+			if err := gzipFile(rotated); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to compress rotated log file: %s\n", err)
+			}
+		}
+		removeOldLogFiles(f.conf) // (after compression, so that a file and its compressed one are not counted twice)
+	}()
+
+	return nil
+}
+
+// compress given file with gzip (as `*.gz`), and remove the original one
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+
+	w := gzip.NewWriter(dst)
+	if _, err := io.Copy(w, src); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return err
+	}
+	if err := w.Close(); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// remove rotated log files except the latest `max_backups` ones
+func removeOldLogFiles(conf logFileConfig) {
+	maxBackups := DefaultLogFileMaxBackups
+	if conf.MaxBackups > 0 {
+		maxBackups = conf.MaxBackups
+	}
+
+	matches, err := filepath.Glob(conf.Path + ".*")
+	if err != nil {
+		return
+	}
+
+	rotated := []string{}
+	for _, match := range matches {
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(match, conf.Path+"."), ".gz")
+		if _, err := time.Parse(rotatedLogFileTimeLayout, timestamp); err == nil {
+			rotated = append(rotated, match)
+		}
+	}
+	sort.Strings(rotated) // (oldest first)
+
+	for len(rotated) > maxBackups {
+		if err := os.Remove(rotated[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to remove old log file: %s\n", err)
+		}
+		rotated = rotated[1:]
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// outputs of logs (`log_output`)
+const (
+	LogOutputStderr   = "stderr"
+	LogOutputSyslog   = "syslog"
+	LogOutputJournald = "journald"
+)
+
+// identifier of logs in syslog and journald
+const LogIdentifier = "telegram-llamafiles-bot"
+
+// path of the socket of journald
+const JournaldSocketPath = "/run/systemd/journal/socket"
+
+// max length of messages sent to journald (for fitting in a datagram)
+const JournaldMaxMessageLength = 48 * 1024
+
+// priorities of logs (as in syslog)
+const (
+	LogPriorityErr  = 3
+	LogPriorityInfo = 6
+)
+
+// regular expression for stripping timestamps from log lines
+var logTimestampRegex = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} `)
+
+// sink of structured logs
+type structuredLogSink interface {
+	send(priority int, message string, fields map[string]string) error
+}
+
+// writer of logs to a structured sink, with priorities and fields
+//
+// (lines logged with `log` have no fields; fields of requests are passed with their loggers)
+type structuredLogWriter struct {
+	sink structuredLogSink
+}
+
+// write given log line to the sink (without fields)
+func (w structuredLogWriter) Write(p []byte) (int, error) {
+	message := logTimestampRegex.ReplaceAllString(strings.TrimRight(string(p), "\n"), "") // (timestamps are added by the sink)
+
+	if err := w.send(message, nil); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// send given message with fields to the sink
+func (w structuredLogWriter) send(message string, fields map[string]string) error {
+	priority := LogPriorityInfo
+	if strings.HasPrefix(message, "Error:") {
+		priority = LogPriorityErr
+	}
+	return w.sink.send(priority, message, fields)
+}
+
+// logger of a request, which passes the fields of the request to structured logs
+type requestLogger struct {
+	fields map[string]string
+}
+
+// returns the logger of given request
+func (r request) logger() requestLogger {
+	return requestLogger{
+		fields: map[string]string{
+			"REQUEST_ID":  strconv.FormatUint(r.id, 10),
+			"REQUEST_REF": r.ref,
+			"MODEL":       r.model.String(),
+			"CHAT_ID":     strconv.FormatInt(r.targetChatID, 10),
+		},
+	}
+}
+
+// log given message like `log.Printf`, with the fields of the request in structured logs
+func (l requestLogger) Printf(format string, v ...any) {
+	message := fmt.Sprintf(format, v...)
+
+	logOutputs.Lock()
+	plain, structured := logOutputs.plain, logOutputs.structured
+	logOutputs.Unlock()
+
+	if len(structured) == 0 {
+		_ = log.Output(2, message)
+		return
+	}
+
+	if plain != nil {
+		_ = log.New(plain, log.Prefix(), log.Flags()).Output(2, message)
+	}
+	for _, w := range structured {
+		if err := w.send(message, l.fields); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to send log: %s\n", err)
+		}
+	}
+}
+
+// start logging to the output in given config (syslog or journald, instead of stderr)
+func openLogOutput(conf config) error {
+	var sink structuredLogSink
+	var err error
+	switch conf.LogOutput {
+	case "", LogOutputStderr:
+		return nil
+	case LogOutputSyslog:
+		sink, err = newSyslogSink()
+	case LogOutputJournald:
+		sink, err = newJournaldSink()
+	default:
+		return fmt.Errorf("unknown `log_output`: %s", conf.LogOutput)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %s", conf.LogOutput, err)
+	}
+
+	logOutputs.Lock()
+	logOutputs.disableStderr = true
+	logOutputs.Unlock()
+
+	addLogOutput(structuredLogWriter{sink: sink})
+
+	return nil
+}
+
+// sink of logs to journald, with its native protocol
+type journaldSink struct {
+	conn *net.UnixConn
+}
+
+// connect to journald
+func newJournaldSink() (structuredLogSink, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: JournaldSocketPath, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return journaldSink{conn: conn}, nil
+}
+
+// send given message with fields to journald
+func (s journaldSink) send(priority int, message string, fields map[string]string) error {
+	if len(message) > JournaldMaxMessageLength {
+		message = message[:JournaldMaxMessageLength] + "..."
+	}
+
+	var buf bytes.Buffer
+	writeField := func(name, value string) {
+		if strings.Contains(value, "\n") { // (binary-safe format for multi-line values)
+			buf.WriteString(name + "\n")
+			_ = binary.Write(&buf, binary.LittleEndian, uint64(len(value)))
+			buf.WriteString(value + "\n")
+		} else {
+			buf.WriteString(name + "=" + value + "\n")
+		}
+	}
+
+	writeField("MESSAGE", message)
+	writeField("PRIORITY", strconv.Itoa(priority))
+	writeField("SYSLOG_IDENTIFIER", LogIdentifier)
+	for name, value := range fields {
+		writeField(name, value)
+	}
+
+	_, err := s.conn.Write(buf.Bytes())
+	return err
+}
+
+// returns given fields as `key="value"` pairs (for sinks without structured fields, eg. syslog)
+func formatLogFields(fields map[string]string) string {
+	pairs := []string{}
+	for _, name := range []string{"REQUEST_ID", "MODEL", "CHAT_ID"} {
+		if value, exists := fields[name]; exists {
+			pairs = append(pairs, fmt.Sprintf("%s=%q", strings.ToLower(name), value))
+		}
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+
+package main
+
+import (
+	"log/syslog"
+)
+
+// sink of logs to syslog
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// connect to the local syslog
+func newSyslogSink() (structuredLogSink, error) {
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, LogIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	return syslogSink{writer: writer}, nil
+}
+
+// send given message to syslog, with fields appended as `key="value"` pairs
+func (s syslogSink) send(priority int, message string, fields map[string]string) error {
+	if formatted := formatLogFields(fields); formatted != "" {
+		message += " [" + formatted + "]"
+	}
+
+	if priority <= LogPriorityErr {
+		return s.writer.Err(message)
+	}
+	return s.writer.Info(message)
+}
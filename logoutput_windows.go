@@ -0,0 +1,12 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+)
+
+// syslog is not supported on Windows
+func newSyslogSink() (structuredLogSink, error) {
+	return nil, fmt.Errorf("syslog is not supported on Windows")
+}
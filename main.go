@@ -8,6 +8,15 @@ import (
 
 func main() {
 	if len(os.Args) > 1 {
+		if os.Args[1] == "--version" {
+			fmt.Println(versionString())
+			return
+		}
+
+		if exitCode, isSubcommand := runSubcommand(os.Args[1], os.Args[2:]); isSubcommand {
+			os.Exit(exitCode)
+		}
+
 		if conf, err := readConfig(os.Args[1]); err == nil {
 			runBot(conf)
 		} else {
@@ -22,9 +31,18 @@ func showHelp() {
 	fmt.Printf(`Usage:
 
   $ %[1]s [CONFIG_FILEPATH]
+  $ %[1]s generate [--config CONFIG_FILEPATH] [--model MODEL] [--verbose] "PROMPT"
+  $ %[1]s repl [--config CONFIG_FILEPATH] [--model MODEL] [--turns N] [--verbose]
+  $ %[1]s validate CONFIG_FILEPATH
+  $ %[1]s schema
+  $ %[1]s encrypt [--key-file KEY_FILEPATH] CONFIG_FILEPATH
+  $ %[1]s decrypt [--key-file KEY_FILEPATH] ENCRYPTED_CONFIG_FILEPATH
+  $ %[1]s --version
 
 Example:
 
   $ %[1]s ./config.json
+  $ %[1]s generate --config ./config.json --model 0 "Why is the sky blue?"
+  $ %[1]s validate ./config.json
 `, os.Args[0])
 }
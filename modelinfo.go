@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// names of `general.file_type` in GGUF metadata (`llama_ftype` of llama.cpp)
+var ggufFileTypes = map[uint64]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+	19: "IQ2_XXS",
+	20: "IQ2_XS",
+	21: "Q2_K_S",
+	22: "IQ3_XS",
+	23: "IQ3_XXS",
+	24: "IQ1_S",
+	25: "IQ4_NL",
+	26: "IQ3_S",
+	27: "IQ3_M",
+	28: "IQ2_S",
+	29: "IQ2_M",
+	30: "IQ4_XS",
+	31: "IQ1_M",
+	32: "BF16",
+}
+
+// returns the path of the GGUF weights of given model:
+// `-m` (or `--model`) in `llamafile_other_parameters`, or the llamafile itself
+func ggufPathOf(model model) (string, bool) {
+	params := model.LlamafileOtherParameters
+	for i := 0; i < len(params)-1; i++ {
+		if params[i] == "-m" || params[i] == "--model" {
+			return params[i+1], true
+		}
+	}
+
+	if model.LlamafilePath != nil {
+		return *model.LlamafilePath, true
+	}
+	return "", false
+}
+
+// handle `/modelinfo` command: read GGUF metadata of the model with given index or name (or alias),
+// and return a message about it (without running a generation)
+func modelInfoMessage(conf config, args string) string {
+	m, found := modelByIndexOrName(conf, args)
+	if !found {
+		return fmt.Sprintf("No such model: %s", escapeForHTML(args))
+	}
+
+	path, isLocal := ggufPathOf(m)
+	if !isLocal {
+		return fmt.Sprintf("<b>%s</b> is not a local model, so it has no GGUF metadata.", escapeForHTML(m.String()))
+	}
+
+	metadata, parameters, err := readGGUF(path, true)
+	if err != nil {
+		return fmt.Sprintf("Failed to read GGUF metadata of <b>%s</b>: %s", escapeForHTML(m.String()), escapeForHTML(err.Error()))
+	}
+
+	lines := []string{fmt.Sprintf("<b>%s</b>", escapeForHTML(m.String()))}
+
+	if name, exists := metadata.String("general.name"); exists {
+		lines = append(lines, fmt.Sprintf("<b>Name</b>: %s", escapeForHTML(name)))
+	}
+
+	arch, exists := metadata.String("general.architecture")
+	if exists {
+		lines = append(lines, fmt.Sprintf("<b>Architecture</b>: %s", escapeForHTML(arch)))
+	}
+
+	if count, exists := metadata.Uint("general.parameter_count"); exists && count > 0 {
+		parameters = count
+	}
+	if parameters > 0 {
+		lines = append(lines, fmt.Sprintf("<b>Parameters</b>: %s", formatParameterCount(parameters)))
+	} else if label, exists := metadata.String("general.size_label"); exists {
+		lines = append(lines, fmt.Sprintf("<b>Parameters</b>: %s", escapeForHTML(label)))
+	}
+
+	if fileType, exists := metadata.Uint("general.file_type"); exists {
+		quantization, known := ggufFileTypes[fileType]
+		if !known {
+			quantization = fmt.Sprintf("unknown (%d)", fileType)
+		}
+		lines = append(lines, fmt.Sprintf("<b>Quantization</b>: %s", quantization))
+	}
+
+	if length, exists := metadata.Uint(arch + ".context_length"); exists {
+		lines = append(lines, fmt.Sprintf("<b>Context length</b>: %d (trained), %d (in use)", length, contextLength(m)))
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		lines = append(lines, fmt.Sprintf("<b>File size</b>: %s", formatBytes(info.Size())))
+	}
+
+	threads, auto := threadsOf(m)
+	if auto {
+		lines = append(lines, fmt.Sprintf("<b>Threads</b>: %d (auto, of %d CPUs)", threads, runtime.NumCPU()))
+	} else {
+		lines = append(lines, fmt.Sprintf("<b>Threads</b>: %d", threads))
+	}
+	batchSize, isDefault := batchSizeOf(m)
+	if isDefault {
+		lines = append(lines, fmt.Sprintf("<b>Batch size</b>: %d (default)", batchSize))
+	} else {
+		lines = append(lines, fmt.Sprintf("<b>Batch size</b>: %d", batchSize))
+	}
+
+	if offload, exists := gpuOffloadOf(m); exists {
+		lines = append(lines, fmt.Sprintf("<b>GPU offload</b>: %s", escapeForHTML(offload)))
+	}
+
+	if chatTemplate, exists := metadata.String("tokenizer.chat_template"); exists {
+		name := "unknown"
+		for _, known := range knownChatTemplates {
+			if strings.Contains(chatTemplate, known.token) {
+				name = known.name
+				break
+			}
+		}
+		lines = append(lines, fmt.Sprintf("<b>Chat template</b>: %s", name))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// format given number of parameters (eg. 7.24B)
+func formatParameterCount(count uint64) string {
+	switch {
+	case count >= 1e9:
+		return fmt.Sprintf("%.2fB", float64(count)/1e9)
+	case count >= 1e6:
+		return fmt.Sprintf("%.2fM", float64(count)/1e6)
+	}
+	return fmt.Sprintf("%d", count)
+}
+
+// format given number of bytes (eg. 4.07 GiB)
+func formatBytes(size int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+
+	value, unit := float64(size), 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d %s", size, units[unit])
+	}
+	return fmt.Sprintf("%.2f %s", value, units[unit])
+}
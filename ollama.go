@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// default base url of ollama daemon
+const DefaultOllamaBaseURL = "http://localhost:11434"
+
+// options of ollama requests
+type ollamaOptions struct {
+	NumPredict  *int     `json:"num_predict,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	Seed        *int     `json:"seed,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+}
+
+// request body of ollama's `/api/chat`
+type ollamaChatRequest struct {
+	Model    string                  `json:"model"`
+	Messages []chatCompletionMessage `json:"messages"`
+	Stream   bool                    `json:"stream"`
+	Options  ollamaOptions           `json:"options"`
+}
+
+// response body of ollama's `/api/chat`
+type ollamaChatResponse struct {
+	Message            chatCompletionMessage `json:"message"`
+	PromptEvalCount    int                   `json:"prompt_eval_count"`
+	PromptEvalDuration int64                 `json:"prompt_eval_duration"` // in nanoseconds
+	EvalCount          int                   `json:"eval_count"`
+	EvalDuration       int64                 `json:"eval_duration"` // in nanoseconds
+	Error              *string               `json:"error,omitempty"`
+}
+
+// check if it is a model of ollama daemon
+func (m model) isOllama() bool {
+	return m.OllamaModel != nil
+}
+
+// returns the base url of the model's ollama daemon
+func (m model) ollamaBaseURL() string {
+	if m.OllamaBaseURL != nil {
+		return strings.TrimSuffix(*m.OllamaBaseURL, "/")
+	}
+	return DefaultOllamaBaseURL
+}
+
+// generator which requests to ollama daemon
+type ollamaGenerator struct {
+	model model
+}
+
+// Generate implements Generator interface
+func (g ollamaGenerator) Generate(ctx context.Context, prompt generationPrompt, opts generationOptions) (result generationResult, err error) {
+	model := g.model
+
+	body, err := json.Marshal(ollamaChatRequest{
+		Model:    *model.OllamaModel,
+		Messages: chatMessages(prompt),
+		Stream:   false,
+		Options: ollamaOptions{
+			NumPredict:  opts.maxTokens,
+			Temperature: opts.temperature,
+			Seed:        opts.seed,
+			Stop:        model.StopSequences,
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, model.ollamaBaseURL()+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := http.Client{Timeout: RemoteBackendTimeoutSeconds * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	var chat ollamaChatResponse
+	if err := json.Unmarshal(bytes, &chat); err != nil {
+		return result, fmt.Errorf("HTTP %d: failed to parse response: %s", resp.StatusCode, err)
+	}
+	if chat.Error != nil {
+		return result, fmt.Errorf("HTTP %d: %s", resp.StatusCode, *chat.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("HTTP %d: unexpected response", resp.StatusCode)
+	}
+
+	result.timings = &llamafileTimings{
+		promptTokens:       chat.PromptEvalCount,
+		promptEvalDuration: time.Duration(chat.PromptEvalDuration),
+		generatedTokens:    chat.EvalCount,
+		evalDuration:       time.Duration(chat.EvalDuration),
+	}
+
+	result.text = strings.TrimSpace(chat.Message.Content)
+
+	return result, nil
+}
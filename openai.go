@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// message of chat completions
+type chatCompletionMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// request body of OpenAI-compatible chat completions
+type chatCompletionRequest struct {
+	Model       string                  `json:"model"`
+	Messages    []chatCompletionMessage `json:"messages"`
+	MaxTokens   *int                    `json:"max_tokens,omitempty"`
+	Temperature *float64                `json:"temperature,omitempty"`
+	Seed        *int                    `json:"seed,omitempty"`
+	Stop        []string                `json:"stop,omitempty"`
+}
+
+// response body of OpenAI-compatible chat completions
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatCompletionMessage `json:"message"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage,omitempty"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// check if it is a model of an OpenAI-compatible server
+func (m model) isOpenAICompatible() bool {
+	return m.APIBaseURL != nil && m.ModelName != nil
+}
+
+// returns the host of the model's api base url
+func (m model) apiHost() string {
+	if u, err := url.Parse(*m.APIBaseURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return *m.APIBaseURL
+}
+
+// build chat messages for given prompt (with the conversation history)
+func chatMessages(prompt generationPrompt) (messages []chatCompletionMessage) {
+	for _, turn := range prompt.history {
+		messages = append(messages,
+			chatCompletionMessage{Role: "user", Content: turn.User},
+			chatCompletionMessage{Role: "assistant", Content: turn.Assistant},
+		)
+	}
+
+	return append(messages, chatCompletionMessage{Role: "user", Content: prompt.text})
+}
+
+// generator which requests to an OpenAI-compatible server
+type openAIGenerator struct {
+	model model
+}
+
+// Generate implements Generator interface
+func (g openAIGenerator) Generate(ctx context.Context, prompt generationPrompt, opts generationOptions) (result generationResult, err error) {
+	model := g.model
+
+	body, err := json.Marshal(chatCompletionRequest{
+		Model:       *model.ModelName,
+		Messages:    chatMessages(prompt),
+		MaxTokens:   opts.maxTokens,
+		Temperature: opts.temperature,
+		Seed:        opts.seed,
+		Stop:        model.StopSequences,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(*model.APIBaseURL, "/")+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if model.APIKey != nil {
+		req.Header.Set("Authorization", "Bearer "+*model.APIKey)
+	}
+
+	startedAt := time.Now()
+
+	client := http.Client{Timeout: RemoteBackendTimeoutSeconds * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	var completion chatCompletionResponse
+	if err := json.Unmarshal(bytes, &completion); err != nil {
+		return result, fmt.Errorf("HTTP %d: failed to parse response: %s", resp.StatusCode, err)
+	}
+	if completion.Error != nil {
+		return result, fmt.Errorf("HTTP %d: %s", resp.StatusCode, completion.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK || len(completion.Choices) == 0 {
+		return result, fmt.Errorf("HTTP %d: no choices in response", resp.StatusCode)
+	}
+
+	if completion.Usage != nil {
+		result.timings = &llamafileTimings{
+			promptTokens:    completion.Usage.PromptTokens,
+			generatedTokens: completion.Usage.CompletionTokens,
+			evalDuration:    time.Since(startedAt), // (including the network latency)
+		}
+	}
+
+	result.text = strings.TrimSpace(completion.Choices[0].Message.Content)
+
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// number of consecutive errors of the same kind, before reporting them
+const RepeatedErrorReportThreshold = 5
+
+// max length of stack traces in error reports
+const ErrorReportStackLength = 3000
+
+// numbers of consecutive errors, keyed by their kinds
+var consecutiveErrors = map[string]int{}
+var consecutiveErrorsLock sync.Mutex
+
+// recover from a panic, log it with its stack trace, and report it to `error_report_chat_id`
+//
+// NOTE: it should be deferred directly (eg. `defer recoverPanic(conf, bot, "somewhere")`)
+func recoverPanic(conf config, bot *tg.Bot, where string) {
+	if r := recover(); r != nil {
+		stack := string(debug.Stack())
+		log.Printf("Error: recovered from a panic in %s: %v\n%s", where, r, stack)
+
+		if len(stack) > ErrorReportStackLength {
+			stack = stack[:ErrorReportStackLength] + "..."
+		}
+		reportError(conf, bot, fmt.Sprintf("Recovered from a panic in %s: %v", where, r), stack)
+	}
+}
+
+// run given function, and recover from a panic in it (returns true if it panicked)
+func withRecovery(conf config, bot *tg.Bot, where string, fn func()) (panicked bool) {
+	panicked = true // (returned as it is, when recovered from a panic)
+	defer recoverPanic(conf, bot, where)
+
+	fn()
+
+	return false
+}
+
+// count an error of given kind, and report it when it occurred `RepeatedErrorReportThreshold` times in a row
+func countError(conf config, bot *tg.Bot, kind string, err error) {
+	consecutiveErrorsLock.Lock()
+	consecutiveErrors[kind]++
+	count := consecutiveErrors[kind]
+	consecutiveErrorsLock.Unlock()
+
+	if count == RepeatedErrorReportThreshold {
+		reportError(conf, bot, fmt.Sprintf("%s failed %d times in a row, last error: %s", kind, count, err), "")
+	}
+}
+
+// returns the number of consecutive errors of given kind
+func consecutiveErrorCount(kind string) int {
+	consecutiveErrorsLock.Lock()
+	defer consecutiveErrorsLock.Unlock()
+
+	return consecutiveErrors[kind]
+}
+
+// reset the number of consecutive errors of given kind
+func resetErrors(kind string) {
+	consecutiveErrorsLock.Lock()
+	defer consecutiveErrorsLock.Unlock()
+
+	delete(consecutiveErrors, kind)
+}
+
+// send an error report with given summary (and detail, if any) to `error_report_chat_id`
+func reportError(conf config, bot *tg.Bot, summary, detail string) {
+	if conf.ErrorReportChatID == nil || bot == nil {
+		return
+	}
+
+	text := "⚠️ " + escapeForHTML(summary)
+	if detail != "" {
+		text += fmt.Sprintf("\n<pre>%s</pre>", escapeForHTML(detail))
+	}
+
+	limiter.wait(conf)
+
+	if sent := bot.SendMessage(*conf.ErrorReportChatID, text, tg.OptionsSendMessage{}.SetParseMode(tg.ParseModeHTML)); !sent.Ok {
+		limiter.pauseIfNeeded(sent.Parameters)
+
+		log.Printf("Error: failed to send error report: %s", *sent.Description)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// currency of Telegram Stars
+const CurrencyStars = "XTR"
+
+// defaults of payments
+const (
+	DefaultTopupStars = 100
+	MaxTopupStars     = 10000
+)
+
+// prefix of invoice payloads for topping up credits
+const topupPayloadPrefix = "topup:"
+
+// config for purchasing credits of generation time with Telegram Stars
+//
+// (credits are used only after the free `quota` is used up)
+type paymentsConfig struct {
+	SecondsPerStar int `json:"seconds_per_star"`        // generation time purchased with each star
+	DefaultStars   int `json:"default_stars,omitempty"` // number of stars for `/topup` without an amount (default: 100)
+}
+
+// check if purchasing credits is enabled
+func paymentsEnabled(conf config) bool {
+	return conf.Payments != nil && conf.Payments.SecondsPerStar > 0 && conf.Quota != nil
+}
+
+// returns the remaining credits of given user
+func creditsOf(userID int64) (credits time.Duration) {
+	db.read(func(data dbData) {
+		credits = time.Duration(data.Credits[userID]) * time.Millisecond
+	})
+
+	return credits
+}
+
+// add given credits of a payment to given user's balance, and return the new balance
+//
+// (credits of a payment are added only once, even if its update is handled again: `duplicate` will be true then)
+func addCredits(userID int64, credits time.Duration, chargeID string) (balance time.Duration, duplicate bool, err error) {
+	err = db.update(func(data *dbData) {
+		if _, processed := data.Payments[chargeID]; processed {
+			duplicate = true
+			return
+		}
+
+		if data.Credits == nil {
+			data.Credits = map[int64]int64{}
+		}
+		if data.Payments == nil {
+			data.Payments = map[string]int64{}
+		}
+
+		data.Credits[userID] += credits.Milliseconds()
+		data.Payments[chargeID] = time.Now().Unix()
+		balance = time.Duration(data.Credits[userID]) * time.Millisecond
+	})
+
+	return balance, duplicate, err
+}
+
+// generate a message about the quota usage and credits of given user
+func balanceMessage(conf config, userID int64) string {
+	if conf.Quota == nil || conf.Quota.GenerationSeconds <= 0 {
+		return "Quotas are not configured."
+	}
+
+	used := (time.Duration(quotaUsageOf(conf, userID).UsedMillis) * time.Millisecond).Round(time.Second)
+	lines := []string{
+		fmt.Sprintf("<b>Quota</b>: %s of %s used (reset at %s)", min(used, conf.Quota.budget()), conf.Quota.budget(), conf.Quota.nextPeriodStart(time.Now()).Format(time.DateTime)),
+	}
+	if paymentsEnabled(conf) {
+		lines = append(lines, fmt.Sprintf("<b>Credits</b>: %s (purchase more with %s)", creditsOf(userID).Round(time.Second), CommandTopup))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// send an invoice for topping up credits with given number of stars
+func handleTopupCommand(conf config, bot *tg.Bot, args string, message tg.Message) {
+	if !paymentsEnabled(conf) {
+		sendReply(conf, bot, message, "Payments are not configured.")
+		return
+	}
+
+	stars := DefaultTopupStars
+	if conf.Payments.DefaultStars > 0 {
+		stars = conf.Payments.DefaultStars
+	}
+	if args != "" {
+		var err error
+		if stars, err = strconv.Atoi(args); err != nil || stars <= 0 || stars > MaxTopupStars {
+			sendReply(conf, bot, message, fmt.Sprintf("Usage: %s [NUMBER_OF_STARS (1-%d)]", CommandTopup, MaxTopupStars))
+			return
+		}
+	}
+
+	credits := time.Duration(stars*conf.Payments.SecondsPerStar) * time.Second
+	label := fmt.Sprintf("%s of generation time", credits)
+
+	limiter.wait(conf)
+
+	options := tg.OptionsSendInvoice{}.
+		SetReplyParameters(tg.ReplyParameters{MessageID: message.MessageID})
+	if sent := bot.SendInvoice(message.Chat.ID, "Credits", label, topupPayloadPrefix+strconv.Itoa(stars), "", CurrencyStars, []tg.LabeledPrice{{Label: label, Amount: stars}}, options); !sent.Ok {
+		limiter.pauseIfNeeded(sent.Parameters)
+
+		log.Printf("Error: failed to send invoice: %s", *sent.Description)
+	}
+}
+
+// parse the number of stars from given invoice payload
+func starsFromPayload(payload string) (stars int, ok bool) {
+	if amount, found := strings.CutPrefix(payload, topupPayloadPrefix); found {
+		if stars, err := strconv.Atoi(amount); err == nil && stars > 0 {
+			return stars, true
+		}
+	}
+	return 0, false
+}
+
+// answer a pre-checkout query of an invoice for topping up credits
+func handlePreCheckoutQuery(conf config, bot *tg.Bot, update tg.Update) {
+	query := *update.PreCheckoutQuery
+
+	var errorMessage *string
+	stars, ok := starsFromPayload(query.InvoicePayload)
+	if !allowed(conf, update) {
+		message := "You are not allowed to use this bot."
+		errorMessage = &message
+	} else if !paymentsEnabled(conf) || !ok || query.Currency != CurrencyStars || query.TotalAmount != stars {
+		message := "This invoice is not valid anymore."
+		errorMessage = &message
+	}
+
+	if answered := bot.AnswerPreCheckoutQuery(query.ID, errorMessage == nil, errorMessage); !answered.Ok {
+		log.Printf("Error: failed to answer pre-checkout query: %s", *answered.Description)
+	}
+}
+
+// add credits of a successful payment to the payer's balance
+//
+// (when credits cannot be added, the payer and the admin are notified for a refund)
+func handleSuccessfulPayment(conf config, bot *tg.Bot, update tg.Update) {
+	message := *update.Message
+	payment := message.SuccessfulPayment
+	stars, ok := starsFromPayload(payment.InvoicePayload)
+	if !ok || message.From == nil || conf.Payments == nil {
+		log.Printf("Error: unexpected payment (charge id: %s)", payment.TelegramPaymentChargeID)
+
+		failedToAddCredits(conf, bot, message, "unexpected payment")
+		return
+	}
+	if !allowed(conf, update) { // (not allowed after the pre-checkout, eg. removed from `allowed_telegram_usernames`)
+		log.Printf("Error: payment of a user who is not allowed (charge id: %s)", payment.TelegramPaymentChargeID)
+
+		failedToAddCredits(conf, bot, message, "payment of a user who is not allowed")
+		return
+	}
+
+	credits := time.Duration(stars*conf.Payments.SecondsPerStar) * time.Second
+	balance, duplicate, err := addCredits(message.From.ID, credits, payment.TelegramPaymentChargeID)
+	if err != nil {
+		log.Printf("Error: failed to add credits of payment (charge id: %s): %s", payment.TelegramPaymentChargeID, err)
+
+		failedToAddCredits(conf, bot, message, fmt.Sprintf("failed to add credits: %s", err))
+		return
+	}
+	if duplicate {
+		log.Printf(">>> ignoring already processed payment (charge id: %s)", payment.TelegramPaymentChargeID)
+		return
+	}
+
+	log.Printf(">>> user %d purchased %s of credits with %d star(s) (charge id: %s)", message.From.ID, credits, stars, payment.TelegramPaymentChargeID)
+
+	sendReply(conf, bot, message, fmt.Sprintf("Thank you! %s of generation time was added to your credits (balance: %s).", credits, balance.Round(time.Second)))
+}
+
+// notify the payer and the admin (`error_report_chat_id`) that credits of given payment were not added
+func failedToAddCredits(conf config, bot *tg.Bot, message tg.Message, reason string) {
+	payment := message.SuccessfulPayment
+
+	var userID int64
+	if message.From != nil {
+		userID = message.From.ID
+	}
+	reportError(conf, bot, fmt.Sprintf("Credits of a payment were not added, refund it: %s", reason), fmt.Sprintf("user id: %d\nstars: %d\ncharge id: %s", userID, payment.TotalAmount, payment.TelegramPaymentChargeID))
+
+	sendReply(conf, bot, message, fmt.Sprintf("Sorry, your credits could not be added. It was reported to the admin for a refund (charge id: <code>%s</code>).", escapeForHTML(payment.TelegramPaymentChargeID)))
+}
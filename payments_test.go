@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddCreditsOnlyOnce(t *testing.T) {
+	db = &database{}
+
+	balance, duplicate, err := addCredits(1, time.Minute, "charge-1")
+	if err != nil || duplicate || balance != time.Minute {
+		t.Fatalf("unexpected result of the first payment: %s, %t, %v", balance, duplicate, err)
+	}
+
+	// (same payment again)
+	if _, duplicate, err = addCredits(1, time.Minute, "charge-1"); err != nil || !duplicate {
+		t.Errorf("the same payment should be a duplicate: %t, %v", duplicate, err)
+	}
+	if credits := creditsOf(1); credits != time.Minute {
+		t.Errorf("credits should be added only once: %s", credits)
+	}
+
+	// (another payment)
+	if balance, duplicate, err = addCredits(1, time.Minute, "charge-2"); err != nil || duplicate || balance != 2*time.Minute {
+		t.Errorf("unexpected result of another payment: %s, %t, %v", balance, duplicate, err)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// pool of equivalent models (eg. copies of the same model on different GPUs)
+//
+// each request for a pool is dispatched to its least busy member, and members process requests concurrently
+type modelPool struct {
+	sync.Mutex
+
+	name    string
+	members []*poolMember
+	next    int // (for breaking ties in round-robin)
+}
+
+// member of a model pool, with its own queue and worker
+//
+// (its queue is a priority queue like the request queue, so priorities and `queue_overflow_policy` are kept)
+type poolMember struct {
+	model      model
+	queue      *priorityQueue
+	processing int // number of requests being processed (0 or 1)
+}
+
+// returns the number of dispatched requests which are not finished yet
+func (m *poolMember) load() int {
+	return m.queue.length() + m.processing
+}
+
+// model pools, keyed by their names (rebuilt on reloads)
+var pools = struct {
+	sync.RWMutex
+
+	byName map[string]*modelPool
+
+	conf config  // (for workers of members, which handle requests with its live version)
+	bot  *tg.Bot // (for workers of members)
+}{
+	byName: map[string]*modelPool{},
+}
+
+// build pools from enabled models with `pool`, and start workers of their members
+func startModelPools(conf config, bot *tg.Bot) {
+	pools.Lock()
+	pools.conf, pools.bot = conf, bot
+	pools.Unlock()
+
+	updateModelPools(conf)
+}
+
+// update pools with given (reloaded) config
+//
+// members are matched by their order in each pool: matched ones are updated in place, new ones are started,
+// and removed ones are stopped after processing their queued requests
+func updateModelPools(conf config) {
+	pools.Lock()
+	defer pools.Unlock()
+
+	if pools.bot == nil { // (not started, eg. in CLI subcommands)
+		return
+	}
+
+	models := map[string][]model{}
+	names := []string{}
+	for _, m := range conf.Models {
+		if m.Disabled || m.Pool == nil {
+			continue
+		}
+		if _, exists := models[*m.Pool]; !exists {
+			names = append(names, *m.Pool)
+		}
+		models[*m.Pool] = append(models[*m.Pool], m)
+	}
+
+	for name, pool := range pools.byName {
+		if _, exists := models[name]; !exists {
+			log.Printf(">>> removing pool '%s'", name)
+
+			pool.Lock()
+			for _, member := range pool.members {
+				member.queue.close()
+			}
+			pool.Unlock()
+
+			delete(pools.byName, name)
+		}
+	}
+
+	for _, name := range names {
+		pool, exists := pools.byName[name]
+		if !exists {
+			pool = &modelPool{name: name}
+			pools.byName[name] = pool
+		}
+
+		pool.Lock()
+		for i, m := range models[name] {
+			if i < len(pool.members) {
+				pool.members[i].model = m
+				continue
+			}
+
+			member := &poolMember{
+				model: m,
+				queue: newPriorityQueue(RequestQueueSize),
+			}
+			pool.members = append(pool.members, member)
+
+			go pool.work(pools.conf, pools.bot, member)
+		}
+		for _, member := range pool.members[len(models[name]):] {
+			member.queue.close()
+		}
+		pool.members = pool.members[:len(models[name])]
+		pool.next %= len(pool.members)
+		pool.Unlock()
+
+		log.Printf(">>> pool '%s' has %d member(s)", name, len(models[name]))
+	}
+}
+
+// process requests dispatched to given member, until its queue is closed (and empty)
+func (p *modelPool) work(conf config, bot *tg.Bot, member *poolMember) {
+	for {
+		request, ok := member.queue.pop()
+		if !ok {
+			return
+		}
+
+		p.Lock()
+		member.processing++
+		p.Unlock()
+
+		handleRequestSafely(liveConfig(conf), bot, request)
+
+		p.Lock()
+		member.processing--
+		p.Unlock()
+	}
+}
+
+// returns the pool of given model (nil if it is not in any pool)
+func poolOf(model model) *modelPool {
+	if model.Pool == nil {
+		return nil
+	}
+
+	pools.RLock()
+	defer pools.RUnlock()
+
+	return pools.byName[*model.Pool]
+}
+
+// dispatch given request to the least busy member of the pool, without blocking
+//
+// (members disabled at runtime are skipped, unless all of them are disabled)
+func (p *modelPool) dispatch(conf config, bot *tg.Bot, request request) {
+	p.Lock()
+	var member *poolMember
+	for _, skipDisabled := range []bool{true, false} {
+		for i := range p.members {
+			candidate := p.members[(p.next+i)%len(p.members)]
+			if skipDisabled && candidate.model.isDisabled() {
+				continue
+			}
+			if member == nil || candidate.load() < member.load() {
+				member = candidate
+			}
+		}
+		if member != nil {
+			break
+		}
+	}
+	for i, m := range p.members {
+		if m == member {
+			p.next = (i + 1) % len(p.members)
+		}
+	}
+	load := member.load() + 1
+	p.Unlock()
+
+	request.logger().Printf(">>> dispatching request %s to a member of pool '%s': %s (load: %d)", request, p.name, member.model, load)
+
+	request.model = member.model
+	pushRequest(conf, bot, member.queue, request)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+func TestUpdateModelPools(t *testing.T) {
+	name, a, b := "pool", "a", "b"
+	conf := config{Models: []model{{Name: &a, Pool: &name}, {Name: &b, Pool: &name}}}
+
+	pools.Lock()
+	pools.bot = &tg.Bot{}
+	pools.Unlock()
+	defer func() {
+		updateModelPools(config{})
+
+		pools.Lock()
+		pools.bot = nil
+		pools.Unlock()
+	}()
+
+	updateModelPools(conf)
+	pool := poolOf(conf.Models[0])
+	if pool == nil || len(pool.members) != 2 {
+		t.Fatalf("pool should have 2 members: %+v", pool)
+	}
+	removed := pool.members[1]
+
+	// (reloaded without the second member)
+	updateModelPools(config{Models: conf.Models[:1]})
+	if len(pool.members) != 1 || pool.members[0].model.String() != a {
+		t.Errorf("pool should have only the first member: %d", len(pool.members))
+	}
+	if removed.queue.push(request{}) {
+		t.Errorf("queue of the removed member should be closed")
+	}
+
+	// (reloaded without the pool)
+	updateModelPools(config{})
+	if poolOf(conf.Models[0]) != nil {
+		t.Errorf("pool should be removed")
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	q := newPriorityQueue(2)
+	if !q.push(request{id: 1}) || !q.push(request{id: 2, priority: true}) {
+		t.Fatalf("failed to push requests")
+	}
+	if q.push(request{id: 3}) {
+		t.Errorf("full queue should refuse requests")
+	}
+
+	if r, ok := q.pop(); !ok || r.id != 2 {
+		t.Errorf("priority request should be popped first: %d", r.id)
+	}
+
+	q.close()
+	if r, ok := q.pop(); !ok || r.id != 1 {
+		t.Errorf("remaining request should be popped from a closed queue: %d", r.id)
+	}
+	if _, ok := q.pop(); ok {
+		t.Errorf("closed and empty queue should not pop")
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+)
+
+// default listen address of the pprof endpoint
+const DefaultPprofListenAddress = "127.0.0.1:6060"
+
+// config of the pprof endpoint, for profiling goroutine leaks and memory growth in production
+type pprofConfig struct {
+	ListenAddress string `json:"listen_address,omitempty"` // (default: "127.0.0.1:6060", only loopback addresses are allowed)
+}
+
+// start the pprof endpoint in background, if configured
+func startPprof(conf config) {
+	if conf.Pprof == nil {
+		return
+	}
+
+	addr := pprofListenAddress(conf)
+	if err := validatePprofListenAddress(addr); err != nil {
+		log.Printf("Error: not starting pprof endpoint: %s", err)
+		return
+	}
+
+	// (not on `http.DefaultServeMux`, for not exposing it on other servers)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	log.Printf(">>> starting pprof endpoint on http://%s/debug/pprof/", addr)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Error: pprof endpoint stopped: %s", err)
+		}
+	}()
+}
+
+// returns the listen address of the pprof endpoint in given config
+func pprofListenAddress(conf config) string {
+	if conf.Pprof == nil || conf.Pprof.ListenAddress == "" {
+		return DefaultPprofListenAddress
+	}
+	return conf.Pprof.ListenAddress
+}
+
+// check if given listen address is a loopback one (eg. "127.0.0.1:6060", "[::1]:6060", or "localhost:6060")
+func validatePprofListenAddress(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address of pprof: %s", err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("listen address of pprof is not a loopback one: %s", addr)
+	}
+	return nil
+}
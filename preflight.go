@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memory needed for running a model, relative to the size of its weights (for KV cache and buffers, roughly)
+const PreflightMemoryRatio = 1.1
+
+// timeout of querying free VRAM
+const VRAMQueryTimeoutSeconds = 5
+
+// cached numbers of layers, keyed by GGUF path
+var blockCounts = map[string]uint64{}
+var blockCountsLock sync.Mutex
+
+// check if there is enough memory (and VRAM, with `-ngl`) for launching given model,
+// so that it is refused with a clear message, instead of letting the OOM killer take down the host
+//
+// NOTE: if the amount of available memory (or VRAM) cannot be determined, it is not checked
+func preflightCheck(model model) error {
+	if model.SkipPreflightCheck {
+		return nil
+	}
+
+	path, isLocal := ggufPathOf(model)
+	if !isLocal {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil // (will be reported on launch)
+	}
+	required := uint64(float64(info.Size()) * PreflightMemoryRatio)
+
+	// layers offloaded to GPUs
+	if layers := gpuLayersOf(model); layers > 0 {
+		if free, ok := freeVRAM(); ok {
+			fraction := 1.0
+			if blocks := blockCountOf(path); blocks > 0 {
+				fraction = min(float64(layers)/float64(blocks+1), 1) // (+1 for the output layer)
+			}
+
+			vram := uint64(float64(required) * fraction)
+			if vram > free {
+				return fmt.Errorf("not enough VRAM for %s: %s is needed for %d layer(s), but only %s is free (offload fewer layers with `-ngl`, or set `skip_preflight_check`)", model, formatBytes(int64(vram)), layers, formatBytes(int64(free)))
+			}
+			required -= vram
+		}
+	}
+
+	if available, ok := availableRAM(); ok && required > available {
+		return fmt.Errorf("not enough memory for %s: %s is needed, but only %s is available (free up memory, or set `skip_preflight_check`)", model, formatBytes(int64(required)), formatBytes(int64(available)))
+	}
+
+	return nil
+}
+
+// returns the number of layers offloaded to GPUs (`gpu.layers`, or `-ngl`, `--n-gpu-layers`, `--gpu-layers` in `llamafile_other_parameters`)
+func gpuLayersOf(model model) int {
+	if model.GPU != nil && model.GPU.Backend == "disable" {
+		return 0
+	}
+
+	params := model.otherParameters()
+	for i := 0; i < len(params)-1; i++ {
+		switch params[i] {
+		case "-ngl", "--n-gpu-layers", "--gpu-layers":
+			if layers, err := strconv.Atoi(params[i+1]); err == nil {
+				return layers
+			}
+		}
+	}
+	return 0
+}
+
+// returns the number of layers (`*.block_count` in GGUF metadata) of given GGUF file (0 if unknown)
+func blockCountOf(path string) uint64 {
+	blockCountsLock.Lock()
+	defer blockCountsLock.Unlock()
+
+	if count, exists := blockCounts[path]; exists {
+		return count
+	}
+
+	var count uint64
+	if metadata, err := readGGUFMetadata(path); err == nil {
+		if arch, exists := metadata.String("general.architecture"); exists {
+			count, _ = metadata.Uint(arch + ".block_count")
+		}
+	}
+	blockCounts[path] = count
+
+	return count
+}
+
+// returns the available memory in bytes (`MemAvailable` in /proc/meminfo, so only on Linux)
+func availableRAM() (uint64, bool) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, found := strings.CutPrefix(scanner.Text(), "MemAvailable:"); found {
+			if kb, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), " kB"), 10, 64); err == nil {
+				return kb * 1024, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// returns the free VRAM of all GPUs in bytes (with `nvidia-smi`, so only on NVIDIA GPUs)
+func freeVRAM() (uint64, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), VRAMQueryTimeoutSeconds*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "nvidia-smi", "--query-gpu=memory.free", "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return 0, false
+	}
+
+	var free uint64
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		mib, err := strconv.ParseUint(strings.TrimSpace(line), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		free += mib * 1024 * 1024
+	}
+
+	return free, true
+}
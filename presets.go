@@ -0,0 +1,76 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// preset struct in config
+type preset struct {
+	Command     string `json:"command"`               // eg. "/tldr"
+	Template    string `json:"template"`              // eg. "Summarize the following text: %t"
+	Placeholder string `json:"placeholder"`           // eg. "%t"
+	ModelIndex  *int   `json:"model_index,omitempty"` // index of the model in `models` (if omitted, all enabled models will be used)
+}
+
+// returns the preset matching the command in given text, and the rest of the text
+func presetForCommand(conf config, text string) (p preset, args string, found bool) {
+	if !strings.HasPrefix(text, "/") {
+		return preset{}, "", false
+	}
+
+	command, args, _ := strings.Cut(text, " ")
+	command, _, _ = strings.Cut(command, "@") // strip bot name (eg. "/tldr@my_bot")
+
+	for _, p := range conf.Presets {
+		if p.Command == command {
+			return p, strings.TrimSpace(args), true
+		}
+	}
+
+	return preset{}, "", false
+}
+
+// handle preset command: fill the preset's template with the target text, and enqueue requests with it
+//
+// NOTE: target text is the replied-to message's text if there is one, or the command's arguments
+func handlePresetCommand(conf config, bot *tg.Bot, reqQueue *priorityQueue, preset preset, args string, message tg.Message) {
+	target := args
+	if message.HasReplyTo() && message.ReplyToMessage.HasText() {
+		target = *message.ReplyToMessage.Text
+	}
+
+	if target == "" {
+		log.Printf(">>> dropping preset command %s with no target text", preset.Command)
+		return
+	}
+
+	enqueuePresetRequests(conf, bot, reqQueue, preset, target, message)
+}
+
+// fill the preset's template with given target text, and enqueue requests with it
+func enqueuePresetRequests(conf config, bot *tg.Bot, reqQueue *priorityQueue, preset preset, target string, message tg.Message) {
+	filled := escapeForShell(strings.ReplaceAll(preset.Template, preset.Placeholder, target))
+
+	models := []model{}
+	for i, model := range conf.Models {
+		// skip disabled models and image generators
+		if model.isDisabled() || model.isImageGenerator() {
+			continue
+		}
+
+		// skip models not chosen by the preset
+		if preset.ModelIndex != nil && *preset.ModelIndex != i {
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	group := newRequestGroup(conf, len(models))
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, &filled, nil, generationOptions{}, group, nil, message)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// number of hex characters of hashes which replace user contents in logs
+const RedactedHashCharacters = 12
+
+// returns given user content for logging:
+// as it is if `log_user_content` is true, otherwise only its length and hash
+// (so that logs of the same content can still be correlated)
+func loggable(conf config, text string) string {
+	if conf.LogUserContent {
+		return text
+	}
+
+	hash := sha256.Sum256([]byte(text))
+
+	return fmt.Sprintf("<redacted: %d chars, sha256:%s>", len([]rune(text)), hex.EncodeToString(hash[:])[:RedactedHashCharacters])
+}
@@ -0,0 +1,594 @@
+// service for programmatic access to the request pipeline of telegram-llamafiles-bot
+//
+// served with `grpc` in config; the same operations are also available through the REST API (`api` in config):
+//
+//   Generate   => POST /v1/generate
+//   Status     => GET  /v1/status
+//   ListModels => GET  /v1/models
+//
+// NOTE: regenerate llamafiles.pb.go and llamafiles_grpc.pb.go with `go generate` after editing this file
+// (needs protoc, protoc-gen-go, and protoc-gen-go-grpc)
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/llamafiles.proto
+
+package llamafilesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GenerateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"` // index or name of the model (default: the first enabled one)
+	Prompt        string                 `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	MaxTokens     *int32                 `protobuf:"varint,3,opt,name=max_tokens,json=maxTokens,proto3,oneof" json:"max_tokens,omitempty"`
+	Temperature   *float64               `protobuf:"fixed64,4,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	Seed          *int32                 `protobuf:"varint,5,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	Priority      bool                   `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"` // jump ahead of normal requests in the queue (only for `priority_tokens`)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_proto_llamafiles_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_llamafiles_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_llamafiles_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GenerateRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetMaxTokens() int32 {
+	if x != nil && x.MaxTokens != nil {
+		return *x.MaxTokens
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetSeed() int32 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetPriority() bool {
+	if x != nil {
+		return x.Priority
+	}
+	return false
+}
+
+type GenerateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Model string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GenerateResponse_QueuePosition
+	//	*GenerateResponse_Output
+	//	*GenerateResponse_Error
+	Result        isGenerateResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_proto_llamafiles_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_llamafiles_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_llamafiles_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GenerateResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GenerateResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetResult() isGenerateResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GenerateResponse) GetQueuePosition() uint32 {
+	if x != nil {
+		if x, ok := x.Result.(*GenerateResponse_QueuePosition); ok {
+			return x.QueuePosition
+		}
+	}
+	return 0
+}
+
+func (x *GenerateResponse) GetOutput() string {
+	if x != nil {
+		if x, ok := x.Result.(*GenerateResponse_Output); ok {
+			return x.Output
+		}
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetError() string {
+	if x != nil {
+		if x, ok := x.Result.(*GenerateResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+type isGenerateResponse_Result interface {
+	isGenerateResponse_Result()
+}
+
+type GenerateResponse_QueuePosition struct {
+	QueuePosition uint32 `protobuf:"varint,3,opt,name=queue_position,json=queuePosition,proto3,oneof"` // (streamed while waiting in the queue)
+}
+
+type GenerateResponse_Output struct {
+	Output string `protobuf:"bytes,4,opt,name=output,proto3,oneof"`
+}
+
+type GenerateResponse_Error struct {
+	Error string `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+func (*GenerateResponse_QueuePosition) isGenerateResponse_Result() {}
+
+func (*GenerateResponse_Output) isGenerateResponse_Result() {}
+
+func (*GenerateResponse_Error) isGenerateResponse_Result() {}
+
+type StatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+	mi := &file_proto_llamafiles_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_llamafiles_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_llamafiles_proto_rawDescGZIP(), []int{2}
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UptimeSeconds int64                  `protobuf:"varint,1,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	Queued        uint32                 `protobuf:"varint,2,opt,name=queued,proto3" json:"queued,omitempty"`
+	InFlight      uint32                 `protobuf:"varint,3,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	Overflows     uint32                 `protobuf:"varint,4,opt,name=overflows,proto3" json:"overflows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_proto_llamafiles_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_llamafiles_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_llamafiles_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StatusResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetQueued() uint32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetInFlight() uint32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetOverflows() uint32 {
+	if x != nil {
+		return x.Overflows
+	}
+	return 0
+}
+
+type ListModelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_proto_llamafiles_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsRequest) ProtoMessage() {}
+
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_llamafiles_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_llamafiles_proto_rawDescGZIP(), []int{4}
+}
+
+type ListModelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Models        []*Model               `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_proto_llamafiles_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_llamafiles_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_llamafiles_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListModelsResponse) GetModels() []*Model {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+type Model struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Generations   uint32                 `protobuf:"varint,3,opt,name=generations,proto3" json:"generations,omitempty"`
+	Failures      uint32                 `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Model) Reset() {
+	*x = Model{}
+	mi := &file_proto_llamafiles_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Model) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Model) ProtoMessage() {}
+
+func (x *Model) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_llamafiles_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Model.ProtoReflect.Descriptor instead.
+func (*Model) Descriptor() ([]byte, []int) {
+	return file_proto_llamafiles_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Model) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Model) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Model) GetGenerations() uint32 {
+	if x != nil {
+		return x.Generations
+	}
+	return 0
+}
+
+func (x *Model) GetFailures() uint32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+var File_proto_llamafiles_proto protoreflect.FileDescriptor
+
+const file_proto_llamafiles_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/llamafiles.proto\x12\rllamafiles.v1\"\xe7\x01\n" +
+	"\x0fGenerateRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x16\n" +
+	"\x06prompt\x18\x02 \x01(\tR\x06prompt\x12\"\n" +
+	"\n" +
+	"max_tokens\x18\x03 \x01(\x05H\x00R\tmaxTokens\x88\x01\x01\x12%\n" +
+	"\vtemperature\x18\x04 \x01(\x01H\x01R\vtemperature\x88\x01\x01\x12\x17\n" +
+	"\x04seed\x18\x05 \x01(\x05H\x02R\x04seed\x88\x01\x01\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\bR\bpriorityB\r\n" +
+	"\v_max_tokensB\x0e\n" +
+	"\f_temperatureB\a\n" +
+	"\x05_seed\"\x9d\x01\n" +
+	"\x10GenerateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12'\n" +
+	"\x0equeue_position\x18\x03 \x01(\rH\x00R\rqueuePosition\x12\x18\n" +
+	"\x06output\x18\x04 \x01(\tH\x00R\x06output\x12\x16\n" +
+	"\x05error\x18\x05 \x01(\tH\x00R\x05errorB\b\n" +
+	"\x06result\"\x0f\n" +
+	"\rStatusRequest\"\x8a\x01\n" +
+	"\x0eStatusResponse\x12%\n" +
+	"\x0euptime_seconds\x18\x01 \x01(\x03R\ruptimeSeconds\x12\x16\n" +
+	"\x06queued\x18\x02 \x01(\rR\x06queued\x12\x1b\n" +
+	"\tin_flight\x18\x03 \x01(\rR\binFlight\x12\x1c\n" +
+	"\toverflows\x18\x04 \x01(\rR\toverflows\"\x13\n" +
+	"\x11ListModelsRequest\"B\n" +
+	"\x12ListModelsResponse\x12,\n" +
+	"\x06models\x18\x01 \x03(\v2\x14.llamafiles.v1.ModelR\x06models\"o\n" +
+	"\x05Model\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vgenerations\x18\x03 \x01(\rR\vgenerations\x12\x1a\n" +
+	"\bfailures\x18\x04 \x01(\rR\bfailures2\xf5\x01\n" +
+	"\n" +
+	"Llamafiles\x12M\n" +
+	"\bGenerate\x12\x1e.llamafiles.v1.GenerateRequest\x1a\x1f.llamafiles.v1.GenerateResponse0\x01\x12E\n" +
+	"\x06Status\x12\x1c.llamafiles.v1.StatusRequest\x1a\x1d.llamafiles.v1.StatusResponse\x12Q\n" +
+	"\n" +
+	"ListModels\x12 .llamafiles.v1.ListModelsRequest\x1a!.llamafiles.v1.ListModelsResponseB@Z>github.com/meinside/telegram-llamafiles-bot/proto;llamafilesv1b\x06proto3"
+
+var (
+	file_proto_llamafiles_proto_rawDescOnce sync.Once
+	file_proto_llamafiles_proto_rawDescData []byte
+)
+
+func file_proto_llamafiles_proto_rawDescGZIP() []byte {
+	file_proto_llamafiles_proto_rawDescOnce.Do(func() {
+		file_proto_llamafiles_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_llamafiles_proto_rawDesc), len(file_proto_llamafiles_proto_rawDesc)))
+	})
+	return file_proto_llamafiles_proto_rawDescData
+}
+
+var file_proto_llamafiles_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_llamafiles_proto_goTypes = []any{
+	(*GenerateRequest)(nil),    // 0: llamafiles.v1.GenerateRequest
+	(*GenerateResponse)(nil),   // 1: llamafiles.v1.GenerateResponse
+	(*StatusRequest)(nil),      // 2: llamafiles.v1.StatusRequest
+	(*StatusResponse)(nil),     // 3: llamafiles.v1.StatusResponse
+	(*ListModelsRequest)(nil),  // 4: llamafiles.v1.ListModelsRequest
+	(*ListModelsResponse)(nil), // 5: llamafiles.v1.ListModelsResponse
+	(*Model)(nil),              // 6: llamafiles.v1.Model
+}
+var file_proto_llamafiles_proto_depIdxs = []int32{
+	6, // 0: llamafiles.v1.ListModelsResponse.models:type_name -> llamafiles.v1.Model
+	0, // 1: llamafiles.v1.Llamafiles.Generate:input_type -> llamafiles.v1.GenerateRequest
+	2, // 2: llamafiles.v1.Llamafiles.Status:input_type -> llamafiles.v1.StatusRequest
+	4, // 3: llamafiles.v1.Llamafiles.ListModels:input_type -> llamafiles.v1.ListModelsRequest
+	1, // 4: llamafiles.v1.Llamafiles.Generate:output_type -> llamafiles.v1.GenerateResponse
+	3, // 5: llamafiles.v1.Llamafiles.Status:output_type -> llamafiles.v1.StatusResponse
+	5, // 6: llamafiles.v1.Llamafiles.ListModels:output_type -> llamafiles.v1.ListModelsResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_llamafiles_proto_init() }
+func file_proto_llamafiles_proto_init() {
+	if File_proto_llamafiles_proto != nil {
+		return
+	}
+	file_proto_llamafiles_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_llamafiles_proto_msgTypes[1].OneofWrappers = []any{
+		(*GenerateResponse_QueuePosition)(nil),
+		(*GenerateResponse_Output)(nil),
+		(*GenerateResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_llamafiles_proto_rawDesc), len(file_proto_llamafiles_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_llamafiles_proto_goTypes,
+		DependencyIndexes: file_proto_llamafiles_proto_depIdxs,
+		MessageInfos:      file_proto_llamafiles_proto_msgTypes,
+	}.Build()
+	File_proto_llamafiles_proto = out.File
+	file_proto_llamafiles_proto_goTypes = nil
+	file_proto_llamafiles_proto_depIdxs = nil
+}
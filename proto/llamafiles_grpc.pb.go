@@ -0,0 +1,218 @@
+// service for programmatic access to the request pipeline of telegram-llamafiles-bot
+//
+// served with `grpc` in config; the same operations are also available through the REST API (`api` in config):
+//
+//   Generate   => POST /v1/generate
+//   Status     => GET  /v1/status
+//   ListModels => GET  /v1/models
+//
+// NOTE: regenerate llamafiles.pb.go and llamafiles_grpc.pb.go with `go generate` after editing this file
+// (needs protoc, protoc-gen-go, and protoc-gen-go-grpc)
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: proto/llamafiles.proto
+
+package llamafilesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Llamafiles_Generate_FullMethodName   = "/llamafiles.v1.Llamafiles/Generate"
+	Llamafiles_Status_FullMethodName     = "/llamafiles.v1.Llamafiles/Status"
+	Llamafiles_ListModels_FullMethodName = "/llamafiles.v1.Llamafiles/ListModels"
+)
+
+// LlamafilesClient is the client API for Llamafiles service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LlamafilesClient interface {
+	// enqueue a generation request into the shared queue, and stream its result
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateResponse], error)
+	// returns the state of the queue
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// returns the enabled models
+	ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error)
+}
+
+type llamafilesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLlamafilesClient(cc grpc.ClientConnInterface) LlamafilesClient {
+	return &llamafilesClient{cc}
+}
+
+func (c *llamafilesClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Llamafiles_ServiceDesc.Streams[0], Llamafiles_Generate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateRequest, GenerateResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Llamafiles_GenerateClient = grpc.ServerStreamingClient[GenerateResponse]
+
+func (c *llamafilesClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, Llamafiles_Status_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *llamafilesClient) ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModelsResponse)
+	err := c.cc.Invoke(ctx, Llamafiles_ListModels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LlamafilesServer is the server API for Llamafiles service.
+// All implementations must embed UnimplementedLlamafilesServer
+// for forward compatibility.
+type LlamafilesServer interface {
+	// enqueue a generation request into the shared queue, and stream its result
+	Generate(*GenerateRequest, grpc.ServerStreamingServer[GenerateResponse]) error
+	// returns the state of the queue
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// returns the enabled models
+	ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error)
+	mustEmbedUnimplementedLlamafilesServer()
+}
+
+// UnimplementedLlamafilesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLlamafilesServer struct{}
+
+func (UnimplementedLlamafilesServer) Generate(*GenerateRequest, grpc.ServerStreamingServer[GenerateResponse]) error {
+	return status.Error(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedLlamafilesServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedLlamafilesServer) ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListModels not implemented")
+}
+func (UnimplementedLlamafilesServer) mustEmbedUnimplementedLlamafilesServer() {}
+func (UnimplementedLlamafilesServer) testEmbeddedByValue()                    {}
+
+// UnsafeLlamafilesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LlamafilesServer will
+// result in compilation errors.
+type UnsafeLlamafilesServer interface {
+	mustEmbedUnimplementedLlamafilesServer()
+}
+
+func RegisterLlamafilesServer(s grpc.ServiceRegistrar, srv LlamafilesServer) {
+	// If the following call panics, it indicates UnimplementedLlamafilesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Llamafiles_ServiceDesc, srv)
+}
+
+func _Llamafiles_Generate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LlamafilesServer).Generate(m, &grpc.GenericServerStream[GenerateRequest, GenerateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Llamafiles_GenerateServer = grpc.ServerStreamingServer[GenerateResponse]
+
+func _Llamafiles_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LlamafilesServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Llamafiles_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LlamafilesServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Llamafiles_ListModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LlamafilesServer).ListModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Llamafiles_ListModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LlamafilesServer).ListModels(ctx, req.(*ListModelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Llamafiles_ServiceDesc is the grpc.ServiceDesc for Llamafiles service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Llamafiles_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "llamafiles.v1.Llamafiles",
+	HandlerType: (*LlamafilesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Status",
+			Handler:    _Llamafiles_Status_Handler,
+		},
+		{
+			MethodName: "ListModels",
+			Handler:    _Llamafiles_ListModels_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Generate",
+			Handler:       _Llamafiles_Generate_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/llamafiles.proto",
+}
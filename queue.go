@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// queue of requests, where priority requests jump ahead of normal ones
+//
+// (requests of the same priority are processed in the order of enqueueing)
+type priorityQueue struct {
+	sync.Mutex
+	cond *sync.Cond
+
+	capacity int
+	priority []request
+	normal   []request
+
+	closed bool // (closed queues refuse new requests, and stop popping when they are empty)
+}
+
+// create a new priority queue with given capacity
+func newPriorityQueue(capacity int) *priorityQueue {
+	q := &priorityQueue{capacity: capacity}
+	q.cond = sync.NewCond(&q.Mutex)
+
+	return q
+}
+
+// push given request to the queue, and return false if the queue is full
+func (q *priorityQueue) push(r request) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.closed || len(q.priority)+len(q.normal) >= q.capacity {
+		return false
+	}
+
+	if r.priority {
+		q.priority = append(q.priority, r)
+	} else {
+		q.normal = append(q.normal, r)
+	}
+	q.cond.Signal()
+
+	return true
+}
+
+// pop the next request from the queue, waiting until there is one (false if the queue is closed and empty)
+func (q *priorityQueue) pop() (r request, ok bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	for len(q.priority)+len(q.normal) == 0 {
+		if q.closed {
+			return r, false
+		}
+		q.cond.Wait()
+	}
+
+	if len(q.priority) > 0 {
+		r, q.priority = q.priority[0], q.priority[1:]
+	} else {
+		r, q.normal = q.normal[0], q.normal[1:]
+	}
+
+	return r, true
+}
+
+// close the queue (remaining requests can still be popped)
+func (q *priorityQueue) close() {
+	q.Lock()
+	defer q.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// returns the number of requests in the queue
+func (q *priorityQueue) length() int {
+	q.Lock()
+	defer q.Unlock()
+
+	return len(q.priority) + len(q.normal)
+}
+
+// remove and return the oldest request of the lowest priority (for making room), or false if the queue is empty
+func (q *priorityQueue) dropOldest() (r request, dropped bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.normal) > 0 {
+		r, q.normal = q.normal[0], q.normal[1:]
+		return r, true
+	} else if len(q.priority) > 0 {
+		r, q.priority = q.priority[0], q.priority[1:]
+		return r, true
+	}
+
+	return r, false
+}
+
+// tracker of queued and in-flight requests
+//
+// (channels cannot be inspected, so requests are tracked separately here)
+type requestTracker struct {
+	sync.Mutex
+
+	lastID uint64
+
+	queued   []request // in the order of enqueueing
+	inFlight []request
+}
+
+// global request tracker
+var tracker = requestTracker{}
+
+// length of the random part of request ids, in bytes (eg. 3 = "4f1a0c")
+const RequestRefBytes = 3
+
+// returns the id of given request for logs (eg. "#42 [4f1a0c]")
+//
+// (sequential numbers restart from 1 on each launch, so the random part is for searching logs with it)
+func (r request) String() string {
+	return fmt.Sprintf("#%d [%s]", r.id, r.ref)
+}
+
+// assign an id to given request, and track it as queued
+func (t *requestTracker) add(r *request) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.lastID++
+	r.id = t.lastID
+	r.ref = randomHex(RequestRefBytes)
+	r.enqueuedAt = time.Now()
+
+	// (priority requests are placed ahead of normal ones, as in the request queue)
+	index := len(t.queued)
+	if r.priority {
+		for i, q := range t.queued {
+			if !q.priority {
+				index = i
+				break
+			}
+		}
+	}
+	t.queued = append(t.queued[:index], append([]request{*r}, t.queued[index:]...)...)
+}
+
+// mark given request as being processed
+func (t *requestTracker) start(r request) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.queued = removeRequest(t.queued, r.id)
+	t.inFlight = append(t.inFlight, r)
+}
+
+// mark given request as processed
+func (t *requestTracker) finish(r request) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.inFlight = removeRequest(t.inFlight, r.id)
+}
+
+// stop tracking given (dropped) request
+func (t *requestTracker) drop(r request) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.queued = removeRequest(t.queued, r.id)
+}
+
+// returns the position (from 1) of the queued request with given id, or false if it is not queued
+func (t *requestTracker) position(id uint64) (int, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	for i, r := range t.queued {
+		if r.id == id {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// returns the number of queued and in-flight requests
+func (t *requestTracker) counts() (queued, inFlight int) {
+	t.Lock()
+	defer t.Unlock()
+
+	return len(t.queued), len(t.inFlight)
+}
+
+// returns copies of queued and in-flight requests
+func (t *requestTracker) snapshot() (queued, inFlight []request) {
+	t.Lock()
+	defer t.Unlock()
+
+	return append([]request{}, t.queued...), append([]request{}, t.inFlight...)
+}
+
+// remove a request with given id from requests
+func removeRequest(requests []request, id uint64) []request {
+	for i, r := range requests {
+		if r.id == id {
+			return append(requests[:i], requests[i+1:]...)
+		}
+	}
+	return requests
+}
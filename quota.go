@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// reset periods of quotas
+const (
+	QuotaPeriodDaily  = "daily"
+	QuotaPeriodWeekly = "weekly"
+)
+
+// percentages of quotas at which users are notified
+var quotaNotificationPercents = []int{80, 100}
+
+// config for per-user quotas of generation time
+//
+// (admins are exempt from quotas)
+type quotaConfig struct {
+	GenerationSeconds int    `json:"generation_seconds"`      // budget of generation time for each user in a period
+	Period            string `json:"period,omitempty"`        // "daily" (default) or "weekly"
+	ResetHour         int    `json:"reset_hour,omitempty"`    // hour of the day (0-23, local time) when quotas are reset
+	ResetWeekday      string `json:"reset_weekday,omitempty"` // day of the week when weekly quotas are reset (default: "monday")
+}
+
+// quota usage of a user in the current period
+type quotaUsage struct {
+	PeriodStart  time.Time `json:"period_start"`
+	UsedMillis   int64     `json:"used_ms"`
+	NotifiedPerc int       `json:"notified_percent,omitempty"` // last notified percentage
+}
+
+// returns the start of the quota period which contains given time
+func (q quotaConfig) periodStart(t time.Time) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), q.ResetHour, 0, 0, 0, t.Location())
+	if start.After(t) {
+		start = start.AddDate(0, 0, -1)
+	}
+
+	if q.Period == QuotaPeriodWeekly {
+		weekday := time.Monday
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(d.String(), q.ResetWeekday) {
+				weekday = d
+			}
+		}
+
+		for start.Weekday() != weekday {
+			start = start.AddDate(0, 0, -1)
+		}
+	}
+
+	return start
+}
+
+// returns the start of the next quota period after given time
+func (q quotaConfig) nextPeriodStart(t time.Time) time.Time {
+	if q.Period == QuotaPeriodWeekly {
+		return q.periodStart(t).AddDate(0, 0, 7)
+	}
+	return q.periodStart(t).AddDate(0, 0, 1)
+}
+
+// returns the budget of generation time
+func (q quotaConfig) budget() time.Duration {
+	return time.Duration(q.GenerationSeconds) * time.Second
+}
+
+// returns the quota usage of given user in the current period
+func quotaUsageOf(conf config, userID int64) quotaUsage {
+	var usage quotaUsage
+	db.read(func(data dbData) {
+		usage = data.Quotas[userID]
+	})
+
+	if start := conf.Quota.periodStart(time.Now()); !usage.PeriodStart.Equal(start) { // (reset)
+		usage = quotaUsage{PeriodStart: start}
+	}
+
+	return usage
+}
+
+// check if given request's user has used up the quota
+func quotaExceeded(conf config, request request) bool {
+	if conf.Quota == nil || conf.Quota.GenerationSeconds <= 0 || request.userID == 0 || request.admin {
+		return false
+	}
+
+	if time.Duration(quotaUsageOf(conf, request.userID).UsedMillis)*time.Millisecond < conf.Quota.budget() {
+		return false
+	}
+
+	// (purchased credits are used after the quota)
+	return !paymentsEnabled(conf) || creditsOf(request.userID) <= 0
+}
+
+// returns the message for requests which exceeded the quota
+func quotaExceededMessage(conf config) string {
+	message := fmt.Sprintf("You have used up your quota of generation time (%s). It will be reset at %s.",
+		conf.Quota.budget(), conf.Quota.nextPeriodStart(time.Now()).Format(time.DateTime))
+	if paymentsEnabled(conf) {
+		message += fmt.Sprintf(" You can purchase more with %s.", CommandTopup)
+	}
+	return message
+}
+
+// charge the generation time of given finished request to its user's quota
+// (and credits, after the quota is used up),
+// and notify the user when it reaches the notification percentages
+func chargeQuota(conf config, bot *tg.Bot, request request) {
+	if conf.Quota == nil || conf.Quota.GenerationSeconds <= 0 || request.userID == 0 || request.admin ||
+		request.startedProcessingAt.IsZero() {
+		return
+	}
+
+	elapsed := time.Since(request.startedProcessingAt)
+	start := conf.Quota.periodStart(time.Now())
+
+	var usage quotaUsage
+	notify := 0
+	if err := db.update(func(data *dbData) {
+		if data.Quotas == nil {
+			data.Quotas = map[int64]quotaUsage{}
+		}
+		if data.Credits == nil {
+			data.Credits = map[int64]int64{}
+		}
+
+		usage = data.Quotas[request.userID]
+		if !usage.PeriodStart.Equal(start) { // (reset)
+			usage = quotaUsage{PeriodStart: start}
+		}
+		budget := conf.Quota.budget().Milliseconds()
+		if overflow := usage.UsedMillis + elapsed.Milliseconds() - max(usage.UsedMillis, budget); overflow > 0 && paymentsEnabled(conf) {
+			data.Credits[request.userID] = max(0, data.Credits[request.userID]-overflow)
+		}
+		usage.UsedMillis += elapsed.Milliseconds()
+
+		percent := int(usage.UsedMillis * 100 / budget)
+		for _, p := range quotaNotificationPercents {
+			if percent >= p && usage.NotifiedPerc < p {
+				notify = p
+			}
+		}
+		if notify > 0 {
+			usage.NotifiedPerc = notify
+		}
+
+		data.Quotas[request.userID] = usage
+	}); err != nil {
+		log.Printf("Error: failed to save quota usage of user %d: %s", request.userID, err)
+		return
+	}
+
+	if notify == 0 {
+		return
+	}
+
+	log.Printf(">>> user %d reached %d%% of the quota", request.userID, notify)
+
+	used := (time.Duration(usage.UsedMillis) * time.Millisecond).Round(time.Second)
+	text := fmt.Sprintf("You have used <b>%d%%</b> of your quota of generation time (%s of %s). It will be reset at %s.",
+		notify, used, conf.Quota.budget(), conf.Quota.nextPeriodStart(time.Now()).Format(time.DateTime))
+	if notify >= 100 && paymentsEnabled(conf) {
+		text += fmt.Sprintf(" Your credits (%s) will be used until then, and you can purchase more with %s.", creditsOf(request.userID).Round(time.Second), CommandTopup)
+	}
+	if _, err := sendMessageWithRetry(conf, bot, request.targetChatID, request.targetMessageID, text, nil); err != nil {
+		log.Printf("Error: failed to send quota notification: %s", err)
+	}
+}
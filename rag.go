@@ -0,0 +1,437 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+	_ "modernc.org/sqlite"
+)
+
+// default values of RAG configs
+const (
+	DefaultRAGChunkSize = 1000 // in characters
+	DefaultRAGTopK      = 3
+)
+
+// configs for retrieval-augmented generation with local documents
+type ragConfig struct {
+	DocumentsDir        string `json:"documents_dir"`         // directory of documents (.txt and .md files) to index
+	IndexPath           string `json:"index_path,omitempty"`  // SQLite database file for persisting the index (if omitted, documents will be indexed on every startup)
+	EmbeddingModelIndex int    `json:"embedding_model_index"` // index of the model in `models` for generating embeddings
+	ChunkSize           int    `json:"chunk_size,omitempty"`  // size of each chunk in characters
+	TopK                int    `json:"top_k,omitempty"`       // number of chunks to retrieve for each question
+}
+
+// a chunk of a document with its embedding
+type ragChunk struct {
+	Source    string // relative path of the document
+	Index     int    // index of the chunk in the document
+	Text      string
+	Embedding []float64
+}
+
+// index of documents (persisted in a SQLite database)
+type ragIndex struct {
+	Files  map[string]int64 // modification times (unix seconds) of indexed documents, keyed by relative path
+	Chunks []ragChunk
+}
+
+// global index of documents
+var rag = struct {
+	sync.Mutex
+
+	index ragIndex
+	ready bool
+}{}
+
+// returns the embedding model of given RAG config
+func (c ragConfig) embeddingModel(conf config) (model, error) {
+	if c.EmbeddingModelIndex < 0 || c.EmbeddingModelIndex >= len(conf.Models) {
+		return model{}, fmt.Errorf("invalid `embedding_model_index`: %d", c.EmbeddingModelIndex)
+	}
+	return conf.Models[c.EmbeddingModelIndex], nil
+}
+
+// split given text into chunks of given size (at paragraph boundaries, if possible)
+func chunkText(text string, size int) (chunks []string) {
+	var chunk strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		if chunk.Len() > 0 && chunk.Len()+len(paragraph) > size {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+		}
+
+		// split a too-long paragraph
+		for len(paragraph) > size {
+			cut := size
+			if i := strings.LastIndexAny(paragraph[:size], " \n"); i > 0 {
+				cut = i
+			}
+			chunks = append(chunks, strings.ToValidUTF8(strings.TrimSpace(paragraph[:cut]), ""))
+			paragraph = strings.ToValidUTF8(strings.TrimSpace(paragraph[cut:]), "")
+		}
+
+		if chunk.Len() > 0 {
+			chunk.WriteString("\n\n")
+		}
+		chunk.WriteString(paragraph)
+	}
+	if chunk.Len() > 0 {
+		chunks = append(chunks, chunk.String())
+	}
+
+	return chunks
+}
+
+// enqueue a step for indexing documents, so that embeddings are not generated concurrently with other requests
+func enqueueIndexingDocuments(conf config, reqQueue *priorityQueue) {
+	embeddingModel, err := conf.RAG.embeddingModel(conf)
+	if err != nil {
+		log.Printf("Error: failed to index documents: %s", err)
+		return
+	}
+
+	request := request{
+		model: embeddingModel,
+		date:  time.Now(),
+		extra: requestExtra{indexDocuments: true},
+	}
+	tracker.add(&request)
+	if !reqQueue.push(request) {
+		tracker.drop(request)
+
+		log.Printf("Error: failed to index documents: request queue is full")
+	}
+}
+
+// index documents in the configured directory (only new or modified ones)
+func indexDocuments(ctx context.Context, conf config) {
+	c := conf.RAG
+	embeddingModel, err := c.embeddingModel(conf)
+	if err != nil {
+		log.Printf("Error: failed to index documents: %s", err)
+		return
+	}
+	chunkSize := DefaultRAGChunkSize
+	if c.ChunkSize > 0 {
+		chunkSize = c.ChunkSize
+	}
+
+	// load the persisted index
+	index := ragIndex{Files: map[string]int64{}}
+	if c.IndexPath != "" {
+		if loaded, err := loadRAGIndex(c.IndexPath); err == nil {
+			index = loaded
+		} else {
+			log.Printf("Error: failed to read index of documents: %s", err)
+		}
+	}
+
+	existing := map[string]bool{}
+	if err := filepath.WalkDir(c.DocumentsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := strings.ToLower(filepath.Ext(path)); ext != ".txt" && ext != ".md" {
+			return nil
+		}
+
+		source, _ := filepath.Rel(c.DocumentsDir, path)
+		existing[source] = true
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if modTime, indexed := index.Files[source]; indexed && modTime == info.ModTime().Unix() {
+			return nil // not modified
+		}
+
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		log.Printf(">>> indexing document: %s", source)
+
+		chunks := []ragChunk{}
+		for i, text := range chunkText(string(bytes), chunkSize) {
+			embedding, err := embed(ctx, embeddingModel, text)
+			if err != nil {
+				return fmt.Errorf("failed to embed '%s': %s", source, err)
+			}
+			chunks = append(chunks, ragChunk{Source: source, Index: i, Text: text, Embedding: embedding})
+		}
+
+		index.Chunks = append(removeChunks(index.Chunks, source), chunks...)
+		index.Files[source] = info.ModTime().Unix()
+
+		return nil
+	}); err != nil {
+		log.Printf("Error: failed to index documents: %s", err)
+	}
+
+	// remove deleted documents
+	for source := range index.Files {
+		if !existing[source] {
+			index.Chunks = removeChunks(index.Chunks, source)
+			delete(index.Files, source)
+		}
+	}
+
+	if c.IndexPath != "" {
+		if err := saveRAGIndex(c.IndexPath, index); err != nil {
+			log.Printf("Error: failed to save index of documents: %s", err)
+		}
+	}
+
+	log.Printf(">>> indexed %d document(s) in %d chunk(s)", len(index.Files), len(index.Chunks))
+
+	rag.Lock()
+	rag.index = index
+	rag.ready = true
+	rag.Unlock()
+}
+
+// remove chunks of given source
+func removeChunks(chunks []ragChunk, source string) (kept []ragChunk) {
+	for _, chunk := range chunks {
+		if chunk.Source != source {
+			kept = append(kept, chunk)
+		}
+	}
+	return kept
+}
+
+// retrieve the most relevant chunks for given question
+func retrieveChunks(ctx context.Context, conf config, question string) (chunks []ragChunk, err error) {
+	rag.Lock()
+	ready := rag.ready
+	indexed := rag.index.Chunks
+	rag.Unlock()
+
+	if !ready {
+		return nil, fmt.Errorf("documents are not indexed yet")
+	}
+
+	embeddingModel, err := conf.RAG.embeddingModel(conf)
+	if err != nil {
+		return nil, err
+	}
+	embedding, err := embed(ctx, embeddingModel, question)
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		chunk ragChunk
+		score float64
+	}
+	scores := []scored{}
+	for _, chunk := range indexed {
+		scores = append(scores, scored{chunk, cosineSimilarity(embedding, chunk.Embedding)})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].score > scores[j].score
+	})
+
+	topK := DefaultRAGTopK
+	if conf.RAG.TopK > 0 {
+		topK = conf.RAG.TopK
+	}
+	for i := 0; i < len(scores) && i < topK; i++ {
+		chunks = append(chunks, scores[i].chunk)
+	}
+
+	return chunks, nil
+}
+
+// retrieve chunks of documents for the question of given request, and return the request with its prompt and sources
+//
+// (it is a step of the request pipeline, run by the worker, as the question is embedded with the embedding model)
+func retrieveForRequest(ctx context.Context, conf config, request request) (request, error) {
+	chunks, err := retrieveChunks(ctx, conf, request.extra.question)
+	if err != nil {
+		return request, err
+	}
+
+	var context strings.Builder
+	sources := []string{}
+	for i, chunk := range chunks {
+		fmt.Fprintf(&context, "[%d] %s\n\n", i+1, chunk.Text)
+		sources = append(sources, fmt.Sprintf("[%d] %s (#%d)", i+1, chunk.Source, chunk.Index+1))
+	}
+
+	text := escapeForShell(fmt.Sprintf("Answer the question with the following context, citing the numbers of the used parts (eg. [1]).\n\nContext:\n%s\nQuestion: %s", context.String(), request.extra.question))
+	request.originalText = &text
+	request.extra.sources = sources
+
+	return request, nil
+}
+
+// handle `/ask` command: enqueue requests for answering the question with retrieved chunks of documents
+func handleAskCommand(conf config, bot *tg.Bot, reqQueue *priorityQueue, args string, message tg.Message) {
+	if conf.RAG == nil {
+		sendReply(conf, bot, message, "Documents are not configured.")
+		return
+	}
+	if args == "" {
+		sendReply(conf, bot, message, fmt.Sprintf("Usage: %s [QUESTION]", CommandAsk))
+		return
+	}
+
+	question, options := parseDirectives(conf, args)
+
+	// (chunks are retrieved when each request is handled)
+	text := escapeForShell(question)
+	models := modelsForChat(conf, message.Chat.ID)
+	group := newRequestGroup(conf, len(models))
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, &text, nil, options, group, &requestExtra{question: question}, message)
+	}
+}
+
+// load the index of documents from the SQLite database at given path
+func loadRAGIndex(path string) (index ragIndex, err error) {
+	db, err := openRAGIndex(path)
+	if err != nil {
+		return ragIndex{}, err
+	}
+	defer db.Close()
+
+	index.Files = map[string]int64{}
+	files, err := db.Query(`SELECT source, mod_time FROM files`)
+	if err != nil {
+		return ragIndex{}, err
+	}
+	defer files.Close()
+	for files.Next() {
+		var source string
+		var modTime int64
+		if err := files.Scan(&source, &modTime); err != nil {
+			return ragIndex{}, err
+		}
+		index.Files[source] = modTime
+	}
+	if err := files.Err(); err != nil {
+		return ragIndex{}, err
+	}
+
+	chunks, err := db.Query(`SELECT source, idx, text, embedding FROM chunks ORDER BY source, idx`)
+	if err != nil {
+		return ragIndex{}, err
+	}
+	defer chunks.Close()
+	for chunks.Next() {
+		var chunk ragChunk
+		var embedding []byte
+		if err := chunks.Scan(&chunk.Source, &chunk.Index, &chunk.Text, &embedding); err != nil {
+			return ragIndex{}, err
+		}
+		if chunk.Embedding, err = decodeEmbedding(embedding); err != nil {
+			return ragIndex{}, fmt.Errorf("broken embedding of '%s' (#%d): %s", chunk.Source, chunk.Index+1, err)
+		}
+		index.Chunks = append(index.Chunks, chunk)
+	}
+
+	return index, chunks.Err()
+}
+
+// save given index of documents to the SQLite database at given path (replacing the previous one)
+func saveRAGIndex(path string, index ragIndex) (err error) {
+	db, err := openRAGIndex(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(`DELETE FROM files; DELETE FROM chunks`); err != nil {
+		return err
+	}
+	for source, modTime := range index.Files {
+		if _, err = tx.Exec(`INSERT INTO files (source, mod_time) VALUES (?, ?)`, source, modTime); err != nil {
+			return err
+		}
+	}
+	for _, chunk := range index.Chunks {
+		if _, err = tx.Exec(`INSERT INTO chunks (source, idx, text, embedding) VALUES (?, ?, ?, ?)`, chunk.Source, chunk.Index, chunk.Text, encodeEmbedding(chunk.Embedding)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// open (or create) the SQLite database of the index at given path
+func openRAGIndex(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS files (
+	source TEXT PRIMARY KEY,
+	mod_time INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS chunks (
+	source TEXT NOT NULL,
+	idx INTEGER NOT NULL,
+	text TEXT NOT NULL,
+	embedding BLOB NOT NULL,
+	PRIMARY KEY (source, idx)
+)`); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// encode given embedding into bytes (little-endian float64s)
+func encodeEmbedding(embedding []float64) []byte {
+	bytes := make([]byte, 8*len(embedding))
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint64(bytes[8*i:], math.Float64bits(v))
+	}
+	return bytes
+}
+
+// decode given bytes into an embedding
+func decodeEmbedding(bytes []byte) ([]float64, error) {
+	if len(bytes)%8 != 0 {
+		return nil, fmt.Errorf("invalid length: %d", len(bytes))
+	}
+	embedding := make([]float64, len(bytes)/8)
+	for i := range embedding {
+		embedding[i] = math.Float64frombits(binary.LittleEndian.Uint64(bytes[8*i:]))
+	}
+	return embedding, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRAGIndexRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.db")
+
+	index := ragIndex{
+		Files: map[string]int64{"a.md": 1700000000, "b/c.txt": 1700000001},
+		Chunks: []ragChunk{
+			{Source: "a.md", Index: 0, Text: "first", Embedding: []float64{0.1, -0.2, 0.3}},
+			{Source: "a.md", Index: 1, Text: "second", Embedding: []float64{1, 0, -1}},
+			{Source: "b/c.txt", Index: 0, Text: "third", Embedding: []float64{0.5}},
+		},
+	}
+	if err := saveRAGIndex(path, index); err != nil {
+		t.Fatalf("failed to save index: %s", err)
+	}
+
+	// (saved again, for replacing the previous one)
+	if err := saveRAGIndex(path, index); err != nil {
+		t.Fatalf("failed to save index again: %s", err)
+	}
+
+	loaded, err := loadRAGIndex(path)
+	if err != nil {
+		t.Fatalf("failed to load index: %s", err)
+	}
+	if !reflect.DeepEqual(loaded, index) {
+		t.Errorf("loaded index differs:\n%+v\n%+v", loaded, index)
+	}
+}
+
+func TestDecodeEmbedding(t *testing.T) {
+	if _, err := decodeEmbedding([]byte{1, 2, 3}); err == nil {
+		t.Errorf("broken embedding should fail to decode")
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"strings"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// max number of sent replies to remember for regenerations
+const MaxSentReplies = 1000
+
+// modes of regenerations with reactions
+const (
+	RegenerationModeEdit = "edit" // edit the reply in place (default)
+	RegenerationModeNew  = "new"  // post a new variant
+)
+
+// a sent reply, remembered for regenerating it later
+type sentReply struct {
+	ChatID           int64 `json:"chat_id"`
+	MessageID        int64 `json:"message_id"`         // id of the reply
+	RequestMessageID int64 `json:"request_message_id"` // id of the message which requested the generation
+
+	Model string `json:"model,omitempty"` // name of the model (empty if outputs of the chat's models were combined)
+
+	OriginalText *string `json:"original_text,omitempty"`
+	CommentText  *string `json:"comment_text,omitempty"`
+}
+
+// remember the reply of given request (only for plain text generations)
+func recordSentReply(request request, replyMessageID int64) {
+	if request.originalText == nil || request.group.isComparison() ||
+		len(request.extra.documentChunks) > 0 || len(request.extra.sources) > 0 {
+		return
+	}
+
+	reply := sentReply{
+		ChatID:           request.targetChatID,
+		MessageID:        replyMessageID,
+		RequestMessageID: request.targetMessageID,
+		OriginalText:     request.originalText,
+		CommentText:      request.commentText,
+	}
+	if request.group == nil {
+		reply.Model = request.model.String()
+	}
+
+	if err := db.update(func(data *dbData) {
+		// (replace the existing one, if any)
+		for i, r := range data.SentReplies {
+			if r.ChatID == reply.ChatID && r.MessageID == reply.MessageID {
+				data.SentReplies = append(data.SentReplies[:i], data.SentReplies[i+1:]...)
+				break
+			}
+		}
+
+		data.SentReplies = append(data.SentReplies, reply)
+		if len(data.SentReplies) > MaxSentReplies {
+			data.SentReplies = data.SentReplies[len(data.SentReplies)-MaxSentReplies:]
+		}
+	}); err != nil {
+		log.Printf("Error: failed to save sent reply: %s", err)
+	}
+}
+
+// returns the sent replies to given message
+func sentRepliesTo(chatID, requestMessageID int64) (replies []sentReply) {
+	db.read(func(data dbData) {
+		for _, r := range data.SentReplies {
+			if r.ChatID == chatID && r.RequestMessageID == requestMessageID {
+				replies = append(replies, r)
+			}
+		}
+	})
+
+	return replies
+}
+
+// returns the sent reply with given id
+func sentReplyWithID(chatID, messageID int64) (reply sentReply, exists bool) {
+	db.read(func(data dbData) {
+		for _, r := range data.SentReplies {
+			if r.ChatID == chatID && r.MessageID == messageID {
+				reply, exists = r, true
+				return
+			}
+		}
+	})
+
+	return reply, exists
+}
+
+// regenerate given reply with given texts and options
+//
+// the result replaces the reply if `replace` is true, otherwise it is sent as a new reply
+func regenerateReply(conf config, bot *tg.Bot, reqQueue *priorityQueue, reply sentReply, originalText, commentText *string, options generationOptions, message tg.Message, replace bool) {
+	var models []model
+	if reply.Model == "" {
+		models = modelsForChat(conf, reply.ChatID)
+	} else {
+		for _, m := range conf.Models {
+			if !m.isDisabled() && m.String() == reply.Model {
+				models = append(models, m)
+				break
+			}
+		}
+	}
+	if len(models) == 0 {
+		log.Printf(">>> no model is available for regenerating reply %d", reply.MessageID)
+		return
+	}
+
+	extra := &requestExtra{}
+	if replace {
+		extra.replaceMessageID = reply.MessageID
+	}
+
+	var group *requestGroup
+	if reply.Model == "" {
+		group = newRequestGroup(conf, len(models))
+	}
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, originalText, commentText, options, group, extra, message)
+	}
+}
+
+// handle an edited message: regenerate the replies to it with the new text, replacing them in place
+func handleEditedMessage(conf config, bot *tg.Bot, reqQueue *priorityQueue, message tg.Message) {
+	if !message.HasText() || strings.HasPrefix(*message.Text, "/") {
+		return
+	}
+
+	replies := sentRepliesTo(message.Chat.ID, message.MessageID)
+	if len(replies) == 0 {
+		return
+	}
+
+	log.Printf(">>> regenerating %d repl(ies) to edited message %d", len(replies), message.MessageID)
+
+	text, options := parseDirectives(conf, *message.Text)
+	text = escapeForShell(text)
+
+	for _, reply := range replies {
+		originalText, commentText := &text, (*string)(nil)
+		if reply.CommentText != nil { // (the edited message was a comment on the original one)
+			originalText, commentText = reply.OriginalText, &text
+		}
+
+		regenerateReply(conf, bot, reqQueue, reply, originalText, commentText, options, message, true)
+	}
+}
+
+// handle a reaction: regenerate the reacted reply with a new seed, if it is `regeneration_reaction`
+func handleMessageReaction(conf config, bot *tg.Bot, reqQueue *priorityQueue, reaction tg.MessageReactionUpdated) {
+	if conf.RegenerationReaction == nil {
+		return
+	}
+
+	reacted := false
+	for _, r := range reaction.NewReaction {
+		if r.Emoji != nil && *r.Emoji == *conf.RegenerationReaction {
+			reacted = true
+			break
+		}
+	}
+	if !reacted {
+		return
+	}
+
+	reply, exists := sentReplyWithID(reaction.Chat.ID, reaction.MessageID)
+	if !exists {
+		return
+	}
+
+	log.Printf(">>> regenerating reply %d with a new seed", reply.MessageID)
+
+	seed := rand.Intn(math.MaxInt32)
+	message := tg.Message{
+		MessageID: reply.RequestMessageID,
+		From:      reaction.User,
+		Chat:      reaction.Chat,
+		Date:      reaction.Date,
+	}
+
+	regenerateReply(conf, bot, reqQueue, reply, reply.OriginalText, reply.CommentText, generationOptions{seed: &seed}, message, conf.RegenerationMode != RegenerationModeNew)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// the main config which is currently in effect (replaced on reloads)
+var current struct {
+	sync.RWMutex
+
+	conf *config
+}
+
+// set the main config which is in effect
+func setCurrentConfig(conf config) {
+	current.Lock()
+	defer current.Unlock()
+
+	current.conf = &conf
+}
+
+// returns the live version of given config: reloaded one (for the same bot), with models registered at runtime
+//
+// (given config is returned as it is, if no config was set, eg. in CLI subcommands)
+func liveConfig(conf config) config {
+	current.RLock()
+	if current.conf != nil {
+		base := *current.conf
+		if conf.botName == "" {
+			conf = base
+		} else if i := slices.IndexFunc(base.Bots, func(b botConfig) bool { return b.Name == conf.botName }); i >= 0 {
+			conf = configForBot(base, base.Bots[i])
+		}
+	}
+	current.RUnlock()
+
+	return withAddedModels(conf)
+}
+
+// reload the main config from its file, and return a summary of changes
+//
+// NOTE: changes of bot tokens, `bots`, `db_path`, `dashboard`, `api`, `pprof`, and `health` need a restart
+func reloadConfig() (summary string, err error) {
+	current.RLock()
+	old := *current.conf
+	current.RUnlock()
+
+	log.Printf(">>> reloading config from: %s", old.path)
+
+	conf, err := readConfig(old.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file: %s", err)
+	}
+	applyChatTemplates(&conf)
+	fixExecutePermissions(conf)
+
+	if err := validateModels(&conf); err != nil {
+		return "", err
+	}
+	if err := validateTools(conf.Tools); err != nil {
+		return "", err
+	}
+	if err := compileContentFilter(conf); err != nil {
+		_ = compileContentFilter(old) // (restore the previous rules)
+		return "", err
+	}
+
+	setCurrentConfig(conf)
+	dropReloadedModels(conf)
+	updateModelPools(conf)
+
+	summary = configDiff(old, conf)
+	log.Printf(">>> reloaded config: %s", strings.ReplaceAll(summary, "\n", ", "))
+
+	return summary, nil
+}
+
+// returns a summary of changes between given configs
+func configDiff(old, new config) string {
+	lines := []string{}
+
+	names := func(c config) (names []string) {
+		for _, m := range c.Models {
+			names = append(names, m.String())
+		}
+		return names
+	}
+	oldNames, newNames := names(old), names(new)
+	for _, name := range newNames {
+		if !slices.Contains(oldNames, name) {
+			lines = append(lines, "+ model: "+name)
+		}
+	}
+	for _, name := range oldNames {
+		if !slices.Contains(newNames, name) {
+			lines = append(lines, "- model: "+name)
+		}
+	}
+	for _, m := range new.Models {
+		if i := slices.Index(oldNames, m.String()); i >= 0 && old.Models[i].Disabled != m.Disabled {
+			if m.Disabled {
+				lines = append(lines, "* disabled model: "+m.String())
+			} else {
+				lines = append(lines, "* enabled model: "+m.String())
+			}
+		}
+	}
+
+	for _, list := range []struct {
+		key      string
+		old, new []string
+	}{
+		{"allowed user", old.AllowedTelegramUsernames, new.AllowedTelegramUsernames},
+		{"admin", old.AdminTelegramUsernames, new.AdminTelegramUsernames},
+		{"priority user", old.PriorityTelegramUsernames, new.PriorityTelegramUsernames},
+	} {
+		for _, username := range list.new {
+			if !slices.Contains(list.old, username) {
+				lines = append(lines, fmt.Sprintf("+ %s: %s", list.key, username))
+			}
+		}
+		for _, username := range list.old {
+			if !slices.Contains(list.new, username) {
+				lines = append(lines, fmt.Sprintf("- %s: %s", list.key, username))
+			}
+		}
+	}
+
+	restart := []string{}
+	if old.TelegramBotToken != new.TelegramBotToken || old.TelegramBotTokenFile != new.TelegramBotTokenFile || !slices.Equal(old.TelegramBotTokenCommand, new.TelegramBotTokenCommand) {
+		restart = append(restart, "telegram_bot_token")
+	}
+	if old.TelegramAPIBaseURL != new.TelegramAPIBaseURL {
+		restart = append(restart, "telegram_api_base_url")
+	}
+	if old.TelegramProxy != new.TelegramProxy || old.TelegramTimeoutSeconds != new.TelegramTimeoutSeconds {
+		restart = append(restart, "telegram_proxy")
+	}
+	if len(old.Bots) != len(new.Bots) {
+		restart = append(restart, "bots")
+	}
+	if old.DBPath != new.DBPath {
+		restart = append(restart, "db_path")
+	}
+	if (old.Dashboard == nil) != (new.Dashboard == nil) || (old.Dashboard != nil && *old.Dashboard != *new.Dashboard) {
+		restart = append(restart, "dashboard")
+	}
+	if (old.API == nil) != (new.API == nil) || (old.API != nil && *old.API != *new.API) {
+		restart = append(restart, "api")
+	}
+	if !reflect.DeepEqual(old.GRPC, new.GRPC) {
+		restart = append(restart, "grpc")
+	}
+	if (old.Pprof == nil) != (new.Pprof == nil) || (old.Pprof != nil && *old.Pprof != *new.Pprof) {
+		restart = append(restart, "pprof")
+	}
+	if (old.Health == nil) != (new.Health == nil) || (old.Health != nil && *old.Health != *new.Health) {
+		restart = append(restart, "health")
+	}
+	if len(restart) > 0 {
+		lines = append(lines, fmt.Sprintf("! needs a restart: %s", strings.Join(restart, ", ")))
+	}
+
+	if len(lines) == 0 {
+		return "no changes in models or users"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// handle `/reload` command: reload the config file, and return a message about it
+func reloadMessage() string {
+	summary, err := reloadConfig()
+	if err != nil {
+		log.Printf("Error: failed to reload config: %s", err)
+		return fmt.Sprintf("Failed to reload config: %s", escapeForHTML(err.Error()))
+	}
+
+	return fmt.Sprintf("Reloaded config:\n<pre>%s</pre>", escapeForHTML(summary))
+}
+
+// reload the config file on SIGHUP, in background
+func reloadOnSIGHUP() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+
+	go func() {
+		for range ch {
+			if _, err := reloadConfig(); err != nil {
+				log.Printf("Error: failed to reload config: %s", err)
+			}
+		}
+	}()
+}
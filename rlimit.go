@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// resource limits of llamafile processes (0 = unlimited)
+//
+// NOTE: they are applied with `prlimit` (of util-linux), so only on Linux
+type resourceLimits struct {
+	MemoryMB   int `json:"memory_mb,omitempty"`    // max virtual memory (`RLIMIT_AS`)
+	CPUSeconds int `json:"cpu_seconds,omitempty"`  // max CPU time (`RLIMIT_CPU`)
+	FileSizeMB int `json:"file_size_mb,omitempty"` // max size of written files (`RLIMIT_FSIZE`)
+}
+
+// seconds between the soft and hard limits of CPU time (SIGXCPU is sent at the soft one, and SIGKILL at the hard one)
+const CPULimitGraceSeconds = 5
+
+var warnUnsupportedLimits sync.Once
+
+// returns the command and arguments for running given command with given resource limits
+//
+// (if limits are not supported on this platform, given command is returned as it is)
+func withResourceLimits(limits *resourceLimits, command string, args []string) (string, []string) {
+	if limits == nil || (limits.MemoryMB <= 0 && limits.CPUSeconds <= 0 && limits.FileSizeMB <= 0) {
+		return command, args
+	}
+
+	prlimit, err := exec.LookPath("prlimit")
+	if runtime.GOOS != "linux" || err != nil {
+		warnUnsupportedLimits.Do(func() {
+			log.Printf("Error: `resource_limits` are not applied, as they need `prlimit` on Linux")
+		})
+		return command, args
+	}
+
+	ps := []string{}
+	if limits.MemoryMB > 0 {
+		ps = append(ps, "--as="+strconv.FormatInt(int64(limits.MemoryMB)*1024*1024, 10))
+	}
+	if limits.CPUSeconds > 0 {
+		ps = append(ps, "--cpu="+strconv.Itoa(limits.CPUSeconds)+":"+strconv.Itoa(limits.CPUSeconds+CPULimitGraceSeconds))
+	}
+	if limits.FileSizeMB > 0 {
+		ps = append(ps, "--fsize="+strconv.FormatInt(int64(limits.FileSizeMB)*1024*1024, 10))
+	}
+	ps = append(ps, "--", command)
+
+	return prlimit, append(ps, args...)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// name of the route taken when no other route matches
+const DefaultRouteName = "default"
+
+// max number of tokens for the classifier's answer
+const RouterClassifierMaxTokens = 8
+
+// config for routing messages to models
+type routerConfig struct {
+	ClassifierModelIndex *int    `json:"classifier_model_index,omitempty"` // (fast) model for classifying prompts which no keyword matched
+	DefaultModelIndex    *int    `json:"default_model_index,omitempty"`    // model for the default route (if omitted, the models of the chat)
+	Routes               []route `json:"routes"`
+}
+
+// route to a model
+type route struct {
+	Name        string   `json:"name"`                  // eg. "code"
+	Description string   `json:"description,omitempty"` // description of prompts for this route (for the classifier)
+	Keywords    []string `json:"keywords,omitempty"`    // prompts containing any of them (case-insensitively) take this route
+	ModelIndex  int      `json:"model_index"`
+}
+
+// returns the route which matches any of its keywords in given text
+func routeByKeywords(routes []route, text string) *route {
+	lowered := strings.ToLower(text)
+
+	for _, r := range routes {
+		for _, keyword := range r.Keywords {
+			if keyword != "" && strings.Contains(lowered, strings.ToLower(keyword)) {
+				return &r
+			}
+		}
+	}
+
+	return nil
+}
+
+// returns the route which the classifier model chose for given text (nil if none)
+func routeByClassifier(ctx context.Context, conf config, text string) *route {
+	index := *conf.Router.ClassifierModelIndex
+	if index < 0 || index >= len(conf.Models) {
+		log.Printf("Error: invalid `classifier_model_index` of router: %d", index)
+		return nil
+	}
+
+	names := []string{}
+	var sb strings.Builder
+	sb.WriteString("Classify the following message into one of these categories:\n")
+	for _, r := range conf.Router.Routes {
+		fmt.Fprintf(&sb, "- %s: %s\n", r.Name, r.Description)
+		names = append(names, fmt.Sprintf(`"%s"`, r.Name))
+	}
+	fmt.Fprintf(&sb, "- %s: anything else\n", DefaultRouteName)
+	names = append(names, fmt.Sprintf(`"%s"`, DefaultRouteName))
+	sb.WriteString("\nAnswer with the name of the category only.\n\nMessage: " + text)
+
+	prompt := escapeForShell(sb.String())
+	maxTokens, temperature := RouterClassifierMaxTokens, 0.0
+	grammar := "root ::= " + strings.Join(names, " | ") // (only for llamafiles)
+	classification := request{
+		model:        conf.Models[index],
+		originalText: &prompt,
+		date:         time.Now(),
+		options: generationOptions{
+			maxTokens:   &maxTokens,
+			temperature: &temperature,
+			grammar:     &grammar,
+		},
+	}
+
+	result, err := generate(ctx, classification)
+	if err != nil {
+		log.Printf("Error: failed to classify prompt for routing: %s", err)
+		return nil
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(result.text))
+	for _, r := range conf.Router.Routes {
+		if strings.HasPrefix(answer, strings.ToLower(r.Name)) {
+			return &r
+		}
+	}
+
+	return nil
+}
+
+// enqueue requests of a message for the models of the chat,
+// or for the routed model, if the router is configured and no model is selected for the chat
+func enqueueRequests(conf config, bot *tg.Bot, reqQueue *priorityQueue, originalText, commentText *string, options generationOptions, message tg.Message) {
+	if conf.Router == nil || getChatSettings(message.Chat.ID).Model != nil {
+		models := modelsForChat(conf, message.Chat.ID)
+
+		// (skip models which are not allowed to the user, unless none is left)
+		if allowed := slices.DeleteFunc(slices.Clone(models), func(m model) bool {
+			return !m.allowsUser(conf, message.From)
+		}); len(allowed) > 0 {
+			models = allowed
+		}
+
+		group := newRequestGroup(conf, len(models))
+		for _, model := range models {
+			enqueueRequest(conf, bot, reqQueue, model, originalText, commentText, options, group, nil, message)
+		}
+		return
+	}
+
+	var text string
+	if commentText != nil {
+		text = *commentText + "\n\n"
+	}
+	text += *originalText
+
+	routeTo := func(r *route) {
+		enqueueRoutedRequests(conf, bot, reqQueue, r, originalText, commentText, options, message)
+	}
+
+	// route with keywords
+	if r := routeByKeywords(conf.Router.Routes, text); r != nil || conf.Router.ClassifierModelIndex == nil {
+		routeTo(r)
+		return
+	}
+
+	// or, classify it with the classifier model in the queue (as it may take a while), and enqueue it again for the routed model
+	index := *conf.Router.ClassifierModelIndex
+	if index < 0 || index >= len(conf.Models) {
+		log.Printf("Error: invalid `classifier_model_index` of router: %d", index)
+
+		routeTo(nil)
+		return
+	}
+	enqueueRequest(conf, bot, reqQueue, conf.Models[index], &text, nil, generationOptions{}, nil, &requestExtra{routeTo: routeTo}, message)
+}
+
+// enqueue requests of a message for the model of given route,
+// or for the default model (or the models of the chat) if it is nil
+func enqueueRoutedRequests(conf config, bot *tg.Bot, reqQueue *priorityQueue, r *route, originalText, commentText *string, options generationOptions, message tg.Message) {
+	models := []model{}
+	name := DefaultRouteName
+	if r != nil {
+		if r.ModelIndex >= 0 && r.ModelIndex < len(conf.Models) && !conf.Models[r.ModelIndex].isDisabled() {
+			models = append(models, conf.Models[r.ModelIndex])
+			name = r.Name
+		} else {
+			log.Printf("Error: invalid or disabled model of route '%s': %d", r.Name, r.ModelIndex)
+		}
+	}
+	if len(models) == 0 {
+		if index := conf.Router.DefaultModelIndex; index != nil && *index >= 0 && *index < len(conf.Models) && !conf.Models[*index].isDisabled() {
+			models = append(models, conf.Models[*index])
+		} else {
+			models = modelsForChat(conf, message.Chat.ID)
+		}
+	}
+
+	log.Printf(">>> routing message to '%s' route", name)
+
+	group := newRequestGroup(conf, len(models))
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, originalText, commentText, options, group, &requestExtra{route: name}, message)
+	}
+}
+
+// classify given routing request with the classifier model, and enqueue the routed requests
+//
+// (it is a step of the request pipeline, run by the worker, so the classifier does not run concurrently with other generations)
+func classifyForRouting(ctx context.Context, conf config, request request) {
+	request.logger().Printf(">>> classifying request %s for routing", request)
+
+	r := routeByClassifier(ctx, conf, *request.originalText)
+	request.extra.routeTo(r)
+
+	request.trace.finish()
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// sandboxing of llamafile processes, as they run with attacker-influenced prompts
+//
+// NOTE: llamafile sandboxes itself (with pledge() and unveil() of cosmopolitan) in CLI mode,
+// so `--unsecure` which disables it is never passed
+type sandboxConfig struct {
+	WorkingDir         string `json:"working_dir,omitempty"`          // working directory of llamafile processes (default: an empty, read-only directory in the temp directory)
+	WritableWorkingDir bool   `json:"writable_working_dir,omitempty"` // allow the working directory to be writable (not recommended)
+}
+
+// flags of llamafile which disable its sandbox
+var unsecureFlags = []string{"--unsecure"}
+
+// working directory of llamafile processes (empty if not sandboxed)
+var sandboxDir string
+
+// prepare the working directory of llamafile processes with given config
+func prepareSandbox(conf config) error {
+	if conf.Sandbox == nil {
+		return nil
+	}
+
+	dir := conf.Sandbox.WorkingDir
+	if dir == "" {
+		var err error
+		if dir, err = os.MkdirTemp("", "llamafiles-bot-sandbox-"); err != nil {
+			return fmt.Errorf("failed to create a working directory for sandbox: %s", err)
+		}
+		if !conf.Sandbox.WritableWorkingDir {
+			if err := os.Chmod(dir, 0555); err != nil {
+				return fmt.Errorf("failed to make the working directory of sandbox read-only: %s", err)
+			}
+		}
+	} else {
+		if info, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("working directory of sandbox is not accessible: %s", err)
+		} else if !info.IsDir() {
+			return fmt.Errorf("working directory of sandbox is not a directory: %s", dir)
+		}
+	}
+
+	if !conf.Sandbox.WritableWorkingDir && isWritableDir(dir) {
+		if conf.Sandbox.WorkingDir == "" {
+			os.Remove(dir)
+		}
+
+		if os.Geteuid() == 0 {
+			return fmt.Errorf("working directory of sandbox is writable, as the bot is running as root: %s", dir)
+		}
+		return fmt.Errorf("working directory of sandbox is writable: %s (make it read-only, or set `writable_working_dir`)", dir)
+	}
+
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	sandboxDir = dir
+
+	log.Printf(">>> running llamafiles in working directory: %s", sandboxDir)
+
+	return nil
+}
+
+// check if files can be created in given directory
+func isWritableDir(dir string) bool {
+	f, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return false
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	return true
+}
+
+// set the environment and the working directory of given llamafile command for given model
+//
+// (relative paths of existing files in the command are made absolute, as they are relative to the bot's working directory)
+func sandboxCommand(cmd *exec.Cmd, model model) {
+	cmd.Env = environFor(model)
+	if sandboxDir == "" {
+		return
+	}
+
+	cmd.Dir = sandboxDir
+	if abs, err := filepath.Abs(cmd.Path); err == nil && !filepath.IsAbs(cmd.Path) {
+		cmd.Path = abs
+	}
+	for i := 1; i < len(cmd.Args); i++ {
+		if cmd.Args[i-1] == "-p" || cmd.Args[i-1] == "--prompt" { // (not prompts)
+			continue
+		}
+		if arg := cmd.Args[i]; !filepath.IsAbs(arg) && !strings.HasPrefix(arg, "-") {
+			if info, err := os.Stat(arg); err == nil && info.Mode().IsRegular() {
+				if abs, err := filepath.Abs(arg); err == nil {
+					cmd.Args[i] = abs
+				}
+			}
+		}
+	}
+}
+
+// validate sandboxing of given model, and return found problems
+func validateSandbox(model model) (problems []string) {
+	for _, flag := range unsecureFlags {
+		if slices.Contains(model.LlamafileOtherParameters, flag) {
+			problems = append(problems, fmt.Sprintf("`%s` in `llamafile_other_parameters` disables the sandbox of llamafile, so it is not allowed", flag))
+		}
+	}
+
+	return problems
+}
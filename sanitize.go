@@ -0,0 +1,75 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// regular expressions for sanitizing outputs
+var (
+	ansiEscapeRegex = regexp.MustCompile(`\x1b(?:\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)|[@-Z\\-_])`)
+	backspaceRegex  = regexp.MustCompile(`.\x08`)
+)
+
+// prefixes of llamafile's log lines which leak into stdout with some builds/flags
+var llamafileLogPrefixes = []string{
+	"llama_model_loader:",
+	"llama_new_context_with_model:",
+	"llama_kv_cache_init:",
+	"llama_print_timings:",
+	"llm_load_print_meta:",
+	"llm_load_tensors:",
+	"llm_load_vocab:",
+	"ggml_",
+	"system_info:",
+	"sampling:",
+	"sampler seed:",
+	"sampling order:",
+	"generate:",
+	"main: ",
+	"log_init",
+	"import_cuda_impl:",
+	"link_cuda_dso:",
+	"extracting /",
+}
+
+// remove ANSI escape codes, progress spinners, and llamafile's log lines from given output
+func sanitizeOutput(output string) string {
+	output = ansiEscapeRegex.ReplaceAllString(output, "")
+
+	// apply backspaces
+	for strings.Contains(output, "\x08") {
+		if replaced := backspaceRegex.ReplaceAllString(output, ""); replaced != output {
+			output = replaced
+		} else {
+			output = strings.ReplaceAll(output, "\x08", "")
+		}
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		// (spinners and progress bars overwrite the line with carriage returns)
+		if i := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		line = strings.TrimRight(line, "\r")
+
+		if isLlamafileLogLine(line) {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// check if given line is a log line of llamafile
+func isLlamafileLogLine(line string) bool {
+	for _, prefix := range llamafileLogPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
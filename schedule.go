@@ -0,0 +1,313 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// config of a scheduled prompt, which is generated and posted to a chat periodically
+// (eg. a daily briefing, or a reminder)
+type schedule struct {
+	Name string `json:"name,omitempty"` // (for logs, default: the cron expression)
+
+	Cron     string `json:"cron"`                // eg. "0 8 * * 1-5" (minute, hour, day of month, month, and day of week), or "@daily"
+	TimeZone string `json:"time_zone,omitempty"` // eg. "Asia/Seoul" (default: local time zone)
+
+	ChatID int64  `json:"chat_id"`
+	Prompt string `json:"prompt,omitempty"` // (for `preset`, it fills the placeholder)
+	Preset string `json:"preset,omitempty"` // command of a preset (eg. "/tldr")
+	Model  string `json:"model,omitempty"`  // index or name (or alias) of the model (default: the preset's, or the first enabled one)
+}
+
+// returns the name of the schedule for logs
+func (s schedule) String() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Cron
+}
+
+// macros of cron expressions
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// names of months and days of week in cron expressions
+var (
+	cronMonthNames   = []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	cronWeekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// parsed cron expression, with bitsets of matching values for each field
+type cronExpression struct {
+	minutes, hours, days, months, weekdays uint64
+
+	anyDay, anyWeekday bool // (for matching either of day of month and day of week, when both are restricted)
+}
+
+// parse given cron expression (5 fields, or a macro like "@daily")
+func parseCron(expr string) (c cronExpression, err error) {
+	if macro, exists := cronMacros[strings.ToLower(strings.TrimSpace(expr))]; exists {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronExpression{}, fmt.Errorf("cron expression should have 5 fields: '%s'", expr)
+	}
+
+	if c.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return cronExpression{}, fmt.Errorf("invalid minute of cron expression '%s': %s", expr, err)
+	}
+	if c.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return cronExpression{}, fmt.Errorf("invalid hour of cron expression '%s': %s", expr, err)
+	}
+	if c.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return cronExpression{}, fmt.Errorf("invalid day of month of cron expression '%s': %s", expr, err)
+	}
+	if c.months, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return cronExpression{}, fmt.Errorf("invalid month of cron expression '%s': %s", expr, err)
+	}
+	if c.weekdays, err = parseCronField(fields[4], 0, 7, cronWeekdayNames); err != nil {
+		return cronExpression{}, fmt.Errorf("invalid day of week of cron expression '%s': %s", expr, err)
+	}
+	if c.weekdays&(1<<7) != 0 { // (7 is also sunday)
+		c.weekdays |= 1
+	}
+	c.anyDay, c.anyWeekday = strings.HasPrefix(fields[2], "*"), strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// parse a field of cron expression (eg. "*", "*/15", "1-5", "mon-fri", or "0,30"), and return a bitset of its values
+func parseCronField(field string, lowest, highest int, names []string) (bits uint64, err error) {
+	value := func(str string) (int, error) {
+		for i, name := range names {
+			if name != "" && strings.EqualFold(str, name) {
+				return i, nil
+			}
+		}
+		n, err := strconv.Atoi(str)
+		if err != nil {
+			return 0, fmt.Errorf("not a number: '%s'", str)
+		}
+		if n < lowest || n > highest {
+			return 0, fmt.Errorf("out of range %d-%d: %d", lowest, highest, n)
+		}
+		return n, nil
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step: '%s'", stepStr)
+			}
+		}
+
+		var from, to int
+		if rng == "*" {
+			from, to = lowest, highest
+		} else if fromStr, toStr, isRange := strings.Cut(rng, "-"); isRange {
+			if from, err = value(fromStr); err != nil {
+				return 0, err
+			}
+			if to, err = value(toStr); err != nil {
+				return 0, err
+			}
+			if from > to {
+				return 0, fmt.Errorf("invalid range: '%s'", rng)
+			}
+		} else {
+			if from, err = value(rng); err != nil {
+				return 0, err
+			}
+			to = from
+			if hasStep { // (eg. "5/15" = "5-59/15")
+				to = highest
+			}
+		}
+
+		for i := from; i <= to; i += step {
+			bits |= 1 << i
+		}
+	}
+
+	return bits, nil
+}
+
+// check if given time matches the cron expression (in minutes)
+func (c cronExpression) matches(t time.Time) bool {
+	if c.minutes&(1<<t.Minute()) == 0 || c.hours&(1<<t.Hour()) == 0 || c.months&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	day, weekday := c.days&(1<<t.Day()) != 0, c.weekdays&(1<<int(t.Weekday())) != 0
+	if !c.anyDay && !c.anyWeekday {
+		return day || weekday // (as in cron, either of them matches when both are restricted)
+	}
+	return day && weekday
+}
+
+// returns the next time (in minutes) after given time which matches the cron expression (false if none in a year)
+func (c cronExpression) next(after time.Time) (time.Time, bool) {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	for end := t.AddDate(1, 0, 1); t.Before(end); t = t.Add(time.Minute) {
+		if c.matches(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// returns the parsed cron expression and the time zone of given schedule
+func (s schedule) parse() (cron cronExpression, location *time.Location, err error) {
+	return parseCronInTimeZone(s.Cron, s.TimeZone)
+}
+
+// parse given cron expression, and load given time zone (local one, if empty)
+func parseCronInTimeZone(expr, timeZone string) (cron cronExpression, location *time.Location, err error) {
+	if cron, err = parseCron(expr); err != nil {
+		return cronExpression{}, nil, err
+	}
+
+	location = time.Local
+	if timeZone != "" {
+		if location, err = time.LoadLocation(timeZone); err != nil {
+			return cronExpression{}, nil, fmt.Errorf("invalid time zone '%s': %s", timeZone, err)
+		}
+	}
+
+	return cron, location, nil
+}
+
+// run schedules in background, checking them every minute
+//
+// (schedules are read from the live config, so they can be added or changed with reloads)
+func startSchedules(conf config, bot *tg.Bot, reqQueue *priorityQueue) {
+	for _, s := range conf.Schedules {
+		if cron, location, err := s.parse(); err == nil {
+			if next, exists := cron.next(time.Now().In(location)); exists {
+				log.Printf(">>> schedule '%s' will run at %s", s, next.Format(time.DateTime+" MST"))
+			}
+		}
+	}
+
+	runEveryMinute(conf, bot, "running schedules", func(c config, now time.Time) {
+		for _, s := range c.Schedules {
+			cron, location, err := s.parse()
+			if err != nil {
+				log.Printf("Error: failed to parse schedule '%s': %s", s, err)
+				continue
+			}
+			if cron.matches(now.In(location)) {
+				runSchedule(c, bot, reqQueue, s)
+			}
+		}
+	})
+}
+
+// run given function at the start of every minute in background, with the live config
+func runEveryMinute(conf config, bot *tg.Bot, where string, fn func(c config, now time.Time)) {
+	go func() {
+		for {
+			now := time.Now().Truncate(time.Minute).Add(time.Minute)
+			time.Sleep(time.Until(now))
+
+			withRecovery(conf, bot, where, func() {
+				fn(liveConfig(conf), now)
+			})
+		}
+	}()
+}
+
+// enqueue a request with the prompt of given schedule, for posting its result to the chat
+func runSchedule(conf config, bot *tg.Bot, reqQueue *priorityQueue, s schedule) {
+	prompt, m, err := scheduledPrompt(conf, s)
+	if err != nil {
+		log.Printf("Error: failed to run schedule '%s': %s", s, err)
+		return
+	}
+
+	log.Printf(">>> running schedule '%s' with model: %s (chat: %d)", s, m, s.ChatID)
+
+	prompt = escapeForShell(prompt)
+	message := tg.Message{ // (not replying to any message)
+		Chat: tg.Chat{ID: s.ChatID},
+		Date: int(time.Now().Unix()),
+	}
+
+	enqueueRequest(conf, bot, reqQueue, m, &prompt, nil, generationOptions{}, nil, &requestExtra{schedule: s.String()}, message)
+}
+
+// returns the prompt and the model of given schedule
+func scheduledPrompt(conf config, s schedule) (prompt string, m model, err error) {
+	prompt, name := s.Prompt, s.Model
+	if s.Preset != "" {
+		p, _, found := presetForCommand(conf, s.Preset)
+		if !found {
+			return "", model{}, fmt.Errorf("no such preset: %s", s.Preset)
+		}
+		prompt = strings.ReplaceAll(p.Template, p.Placeholder, s.Prompt)
+		if name == "" && p.ModelIndex != nil {
+			name = strconv.Itoa(*p.ModelIndex)
+		}
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return "", model{}, fmt.Errorf("prompt is empty")
+	}
+
+	m, found := modelByIndexOrName(conf, name)
+	if !found || m.isDisabled() {
+		return "", model{}, fmt.Errorf("no such model (or it is disabled): '%s'", name)
+	}
+	if m.isImageGenerator() {
+		return "", model{}, fmt.Errorf("image generators cannot be scheduled: %s", m)
+	}
+
+	return prompt, m, nil
+}
+
+// validate schedules in given config, and return found problems
+func validateSchedules(conf config) (problems []string) {
+	for i, s := range conf.Schedules {
+		if cron, _, err := s.parse(); err != nil {
+			problems = append(problems, fmt.Sprintf("schedule #%d (%s) is invalid: %s", i, s, err))
+		} else if _, exists := cron.next(time.Now()); !exists {
+			problems = append(problems, fmt.Sprintf("schedule #%d (%s) never runs: %s", i, s, s.Cron))
+		}
+		if s.ChatID == 0 {
+			problems = append(problems, fmt.Sprintf("schedule #%d (%s) has no `chat_id`", i, s))
+		}
+		if s.Prompt == "" && s.Preset == "" {
+			problems = append(problems, fmt.Sprintf("schedule #%d (%s) has no `prompt` (or `preset`)", i, s))
+		}
+		if s.Preset != "" {
+			if _, _, found := presetForCommand(conf, s.Preset); !found {
+				problems = append(problems, fmt.Sprintf("schedule #%d (%s) has an unknown preset: %s", i, s, s.Preset))
+			}
+		}
+		if s.Model != "" {
+			if m, found := modelByIndexOrName(conf, s.Model); !found {
+				problems = append(problems, fmt.Sprintf("schedule #%d (%s) has an unknown model: '%s'", i, s, s.Model))
+			} else if m.isImageGenerator() {
+				problems = append(problems, fmt.Sprintf("schedule #%d (%s) has an image generator as its model: '%s'", i, s, s.Model))
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	// (2026-03-13 is a friday)
+	at := func(value string) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", value, time.UTC)
+		if err != nil {
+			t.Fatalf("invalid time: %s", value)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		expr    string
+		time    string
+		matches bool
+	}{
+		// wildcards and macros
+		{"* * * * *", "2026-03-13 10:17", true},
+		{"@daily", "2026-03-13 00:00", true},
+		{"@daily", "2026-03-13 00:01", false},
+		{"@HOURLY", "2026-03-13 10:00", true},
+		{"@weekly", "2026-03-15 00:00", true},
+		{"@weekly", "2026-03-13 00:00", false},
+		{"@yearly", "2026-01-01 00:00", true},
+
+		// lists, ranges, and steps
+		{"0,30 * * * *", "2026-03-13 10:30", true},
+		{"0,30 * * * *", "2026-03-13 10:15", false},
+		{"*/15 * * * *", "2026-03-13 10:45", true},
+		{"*/15 * * * *", "2026-03-13 10:50", false},
+		{"5/15 * * * *", "2026-03-13 10:20", true},
+		{"5/15 * * * *", "2026-03-13 10:15", false},
+		{"0 9-17/2 * * *", "2026-03-13 11:00", true},
+		{"0 9-17/2 * * *", "2026-03-13 12:00", false},
+		{"0 9-17/2 * * *", "2026-03-13 19:00", false},
+		{"0 0 1-10,20-25 * *", "2026-03-22 00:00", true},
+		{"0 0 1-10,20-25 * *", "2026-03-13 00:00", false},
+
+		// names
+		{"0 8 * * mon-fri", "2026-03-13 08:00", true},
+		{"0 8 * * mon-fri", "2026-03-14 08:00", false},
+		{"0 8 * mar *", "2026-03-14 08:00", true},
+		{"0 8 * JAN-feb *", "2026-03-14 08:00", false},
+
+		// sunday as 0 or 7
+		{"0 0 * * 0", "2026-03-15 00:00", true},
+		{"0 0 * * 7", "2026-03-15 00:00", true},
+		{"0 0 * * 5-7", "2026-03-16 00:00", false},
+
+		// day of month and day of week: either of them when both are restricted, both of them otherwise
+		{"0 0 13 * 1", "2026-03-13 00:00", true},
+		{"0 0 13 * 1", "2026-03-16 00:00", true},
+		{"0 0 13 * 1", "2026-03-14 00:00", false},
+		{"0 0 13 * *", "2026-03-16 00:00", false},
+		{"0 0 * * 1", "2026-03-13 00:00", false},
+		{"0 0 */2 * 5", "2026-03-13 00:00", true},
+		{"0 0 */2 * 5", "2026-03-20 00:00", false},
+	}
+
+	for _, test := range tests {
+		cron, err := parseCron(test.expr)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %s", test.expr, err)
+			continue
+		}
+		if matches := cron.matches(at(test.time)); matches != test.matches {
+			t.Errorf("'%s' at %s: expected matches = %v, got %v", test.expr, test.time, test.matches, matches)
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"@every_minute",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"-1 * * * *",
+		"*/0 * * * *",
+		"*/-5 * * * *",
+		"*/x * * * *",
+		"10-5 * * * *",
+		"1-x * * * *",
+		"a * * * *",
+		"* * * * fri-mon",
+		"* * * foo *",
+		"1,,2 * * * *",
+	} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parsing '%s' should fail", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	after := time.Date(2026, 3, 13, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+		found    bool
+	}{
+		{"* * * * *", time.Date(2026, 3, 13, 10, 18, 0, 0, time.UTC), true},
+		{"17 10 * * *", time.Date(2026, 3, 14, 10, 17, 0, 0, time.UTC), true},
+		{"0 8 * * mon", time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC), true},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), false}, // (none in a year)
+		{"0 0 31 4 *", time.Time{}, false},                                  // (never)
+	}
+
+	for _, test := range tests {
+		cron, err := parseCron(test.expr)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %s", test.expr, err)
+			continue
+		}
+		next, found := cron.next(after)
+		if found != test.found || (found && !next.Equal(test.expected)) {
+			t.Errorf("'%s': expected next %s (%v), got %s (%v)", test.expr, test.expected, test.found, next, found)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// scheduling priorities of llamafile processes, so that heavy inference doesn't starve other services
+//
+// NOTE: they are applied with `nice`, `ionice`, and `taskset` (`ionice` and `taskset` are only on Linux)
+type scheduling struct {
+	Nice        *int   `json:"nice,omitempty"`         // niceness (-20 ~ 19, higher is nicer)
+	IONiceClass string `json:"ionice_class,omitempty"` // I/O scheduling class: "realtime", "best-effort", or "idle"
+	IONiceLevel *int   `json:"ionice_level,omitempty"` // I/O priority in the class (0 ~ 7, lower is higher), not for "idle"
+	CPUAffinity string `json:"cpu_affinity,omitempty"` // list of CPUs to run on (eg. "0-3,6")
+}
+
+// I/O scheduling classes of `ionice`
+var ioniceClasses = []string{"realtime", "best-effort", "idle"}
+
+// list of CPUs for `taskset -c`
+var cpuListRegex = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+
+var warnMissingSchedulers sync.Map
+
+// returns the command and arguments for running given command of given model,
+// with its `resource_limits` and `scheduling`
+func wrapLlamafileCommand(model model, command string, args []string) (string, []string) {
+	command, args = withResourceLimits(model.ResourceLimits, command, args)
+	return withScheduling(model.Scheduling, command, args)
+}
+
+// returns the command and arguments for running given command with given scheduling priorities
+//
+// (tools which are not installed are skipped with a warning)
+func withScheduling(s *scheduling, command string, args []string) (string, []string) {
+	if s == nil {
+		return command, args
+	}
+
+	wrap := func(tool string, toolArgs ...string) {
+		path, err := exec.LookPath(tool)
+		if err != nil {
+			if _, warned := warnMissingSchedulers.LoadOrStore(tool, true); !warned {
+				log.Printf("Error: `scheduling` with `%s` is not applied, as it is not found: %s", tool, err)
+			}
+			return
+		}
+		args = append(append(toolArgs, command), args...)
+		command = path
+	}
+
+	// (applied from the innermost one)
+	if s.CPUAffinity != "" {
+		wrap("taskset", "-c", s.CPUAffinity)
+	}
+	if s.IONiceClass != "" {
+		ps := []string{"-c", strconv.Itoa(slices.Index(ioniceClasses, s.IONiceClass) + 1)}
+		if s.IONiceLevel != nil && s.IONiceClass != "idle" {
+			ps = append(ps, "-n", strconv.Itoa(*s.IONiceLevel))
+		}
+		wrap("ionice", ps...)
+	}
+	if s.Nice != nil {
+		wrap("nice", "-n", strconv.Itoa(*s.Nice))
+	}
+
+	return command, args
+}
+
+// validate given scheduling priorities, and return found problems
+func validateScheduling(s *scheduling) (problems []string) {
+	if s == nil {
+		return nil
+	}
+
+	if s.Nice != nil && (*s.Nice < -20 || *s.Nice > 19) {
+		problems = append(problems, fmt.Sprintf("`scheduling.nice` is out of range (-20 ~ 19): %d", *s.Nice))
+	}
+	if s.IONiceClass != "" && !slices.Contains(ioniceClasses, s.IONiceClass) {
+		problems = append(problems, fmt.Sprintf("`scheduling.ionice_class` is not one of %v: %s", ioniceClasses, s.IONiceClass))
+	}
+	if s.IONiceLevel != nil {
+		if s.IONiceClass == "" {
+			problems = append(problems, "`scheduling.ionice_level` is given without `scheduling.ionice_class`")
+		} else if *s.IONiceLevel < 0 || *s.IONiceLevel > 7 {
+			problems = append(problems, fmt.Sprintf("`scheduling.ionice_level` is out of range (0 ~ 7): %d", *s.IONiceLevel))
+		}
+	}
+	if s.CPUAffinity != "" && !cpuListRegex.MatchString(s.CPUAffinity) {
+		problems = append(problems, fmt.Sprintf("`scheduling.cpu_affinity` is not a valid list of CPUs (eg. \"0-3,6\"): %s", s.CPUAffinity))
+	}
+
+	return problems
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// max length of a message of the Bot API (in UTF-16 code units)
+const MaxMessageLength = 4096
+
+// returns the length of given text in UTF-16 code units, as the Bot API counts it
+//
+// (tags and entities in HTML parse mode are counted too, so it is longer than the one telegram counts)
+func messageLength(text string) (length int) {
+	for _, r := range text {
+		if l := utf16.RuneLen(r); l > 0 {
+			length += l
+		} else {
+			length++
+		}
+	}
+	return length
+}
+
+// split given text (in HTML parse mode) into tags, entities, and runes
+func htmlTokens(text string) (tokens []string) {
+	for len(text) > 0 {
+		end := 0
+		switch text[0] {
+		case '<':
+			end = strings.IndexByte(text, '>') + 1
+		case '&':
+			if end = strings.IndexByte(text, ';') + 1; end > 10 { // (not an entity, eg. "&amp;" or "&#128512;")
+				end = 0
+			}
+		}
+		if end <= 0 {
+			_, end = utf8.DecodeRuneInString(text)
+		}
+
+		tokens = append(tokens, text[:end])
+		text = text[end:]
+	}
+	return tokens
+}
+
+// returns the name of given tag, and whether it is a closing one (eg. "</code>")
+func htmlTagName(tag string) (name string, closing bool) {
+	name = strings.TrimSuffix(strings.TrimPrefix(tag, "<"), ">")
+	if closing = strings.HasPrefix(name, "/"); closing {
+		name = name[1:]
+	}
+	if i := strings.IndexAny(name, " \t\n"); i >= 0 {
+		name = name[:i]
+	}
+	return name, closing
+}
+
+// returns the closing tags of given open tags, in reverse order
+func htmlClosingTags(open []string) string {
+	var closing strings.Builder
+	for i := len(open) - 1; i >= 0; i-- {
+		name, _ := htmlTagName(open[i])
+		closing.WriteString("</" + name + ">")
+	}
+	return closing.String()
+}
+
+// returns the open tags after given token
+//
+// (given open tags are not modified)
+func htmlOpenTags(open []string, token string) []string {
+	if !strings.HasPrefix(token, "<") || !strings.HasSuffix(token, ">") {
+		return open
+	}
+
+	name, closing := htmlTagName(token)
+	if !closing {
+		return append(slices.Clone(open), token)
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		if n, _ := htmlTagName(open[i]); n == name {
+			return slices.Delete(slices.Clone(open), i, i+1)
+		}
+	}
+	return open
+}
+
+// split given text (in HTML parse mode) into messages which are not longer than given length,
+// preferably at line breaks
+//
+// tags which are open at the end of a message are closed there, and reopened at the start of the next one
+func splitMessage(text string, limit int) (messages []string) {
+	if messageLength(text) <= limit {
+		return []string{text}
+	}
+
+	var part []string // tokens of the current message
+	var length int    // length of the current message
+	var open []string // tags which are open at the end of the current message
+
+	lastBreak, openAtLastBreak := -1, []string(nil) // (last line break in the current message)
+
+	for _, token := range htmlTokens(text) {
+		tokenLength := messageLength(token)
+		nextOpen := htmlOpenTags(open, token)
+
+		if length+tokenLength+messageLength(htmlClosingTags(nextOpen)) > limit && len(part) > len(open) {
+			cut, openAtCut := len(part), open
+			if lastBreak > 0 {
+				cut, openAtCut = lastBreak, openAtLastBreak
+			}
+			messages = append(messages, strings.Join(part[:cut], "")+htmlClosingTags(openAtCut))
+
+			part = append(slices.Clone(openAtCut), part[cut:]...)
+			length = messageLength(strings.Join(part, ""))
+			lastBreak, openAtLastBreak = -1, nil
+		}
+
+		part = append(part, token)
+		length += tokenLength
+		open = nextOpen
+
+		if token == "\n" {
+			lastBreak, openAtLastBreak = len(part), open
+		}
+	}
+	if len(part) > 0 {
+		messages = append(messages, strings.Join(part, ""))
+	}
+
+	return messages
+}
+
+// pack given sections (in HTML parse mode) into messages which are not longer than given length,
+// splitting sections which are too long
+func packMessages(sections []string, limit int) (messages []string) {
+	current := ""
+	for _, section := range sections {
+		if current != "" && messageLength(current)+len("\n\n")+messageLength(section) <= limit {
+			current += "\n\n" + section
+			continue
+		}
+
+		if current != "" {
+			messages = append(messages, splitMessage(current, limit)...)
+		}
+		current = section
+	}
+	if current != "" {
+		messages = append(messages, splitMessage(current, limit)...)
+	}
+
+	return messages
+}
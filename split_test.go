@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		text     string
+		limit    int
+		expected []string
+	}{
+		{text: "short", limit: 10, expected: []string{"short"}},
+		{text: "line 1\nline 2\nline 3", limit: 14, expected: []string{"line 1\nline 2\n", "line 3"}},
+		{text: "abcdefghij", limit: 4, expected: []string{"abcd", "efgh", "ij"}},
+		{text: "<b>bold\ntext</b>", limit: 12, expected: []string{"<b>bold\n</b>", "<b>text</b>"}},
+		{text: "&amp;&amp;&amp;", limit: 10, expected: []string{"&amp;&amp;", "&amp;"}},
+		{text: "😀😀😀", limit: 4, expected: []string{"😀😀", "😀"}}, // (2 code units each)
+	}
+
+	for _, test := range tests {
+		messages := splitMessage(test.text, test.limit)
+		if strings.Join(messages, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("expected %q for %q, got %q", test.expected, test.text, messages)
+		}
+	}
+}
+
+func TestSplitLongMessage(t *testing.T) {
+	text := "<pre><code>\n" + strings.Repeat(strings.Repeat("a", 99)+"\n", 100) + "</code></pre>\n\n<i>(cached)</i>"
+
+	messages := splitMessage(text, MaxMessageLength)
+	if len(messages) != 3 {
+		t.Errorf("expected 3 messages, got %d", len(messages))
+	}
+	for _, message := range messages {
+		if messageLength(message) > MaxMessageLength {
+			t.Errorf("message should not be longer than %d: %d", MaxMessageLength, messageLength(message))
+		}
+		if strings.Count(message, "<pre>") != strings.Count(message, "</pre>") ||
+			strings.Count(message, "<code>") != strings.Count(message, "</code>") {
+			t.Errorf("tags should be closed in each message: %q...", message[:20])
+		}
+	}
+}
+
+func TestPackMessages(t *testing.T) {
+	tests := []struct {
+		sections []string
+		limit    int
+		expected []string
+	}{
+		{sections: []string{"aaa", "bbb", "ccc"}, limit: 8, expected: []string{"aaa\n\nbbb", "ccc"}},
+		{sections: []string{"aaa", "bbbbbbbbbb", "ccc"}, limit: 8, expected: []string{"aaa", "bbbbbbbb", "bb", "ccc"}},
+		{sections: []string{"aaa", "bbb", "ccc"}, limit: 100, expected: []string{"aaa\n\nbbb\n\nccc"}},
+	}
+
+	for _, test := range tests {
+		messages := packMessages(test.sections, test.limit)
+		if strings.Join(messages, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("expected %q for %q, got %q", test.expected, test.sections, messages)
+		}
+	}
+}
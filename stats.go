@@ -0,0 +1,130 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// statistics of a model
+type modelStats struct {
+	generations         int
+	failures            int
+	consecutiveFailures int // (reset on success)
+	totalDuration       time.Duration
+
+	// (from timings printed by llamafile)
+	promptTokens    int
+	generatedTokens int
+	evalDuration    time.Duration
+
+	lastError   *string
+	lastErrorAt time.Time
+}
+
+// statistics of the bot
+type botStats struct {
+	sync.Mutex
+
+	startedAt time.Time
+
+	overflows int // number of requests which met a full queue
+
+	models map[string]*modelStats // keyed by model's string representation
+}
+
+// global statistics
+var stats = botStats{
+	startedAt: time.Now(),
+	models:    map[string]*modelStats{},
+}
+
+// returns (or creates) statistics of given model
+//
+// NOTE: should be called while locked
+func (s *botStats) model(model model) *modelStats {
+	key := model.String()
+	if _, exists := s.models[key]; !exists {
+		s.models[key] = &modelStats{}
+	}
+	return s.models[key]
+}
+
+// record an overflow of the request queue
+func (s *botStats) recordOverflow() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.overflows++
+}
+
+// record a successful generation of given request
+func (s *botStats) recordSuccess(request request, duration time.Duration, timings *llamafileTimings) {
+	recordUsage(request, duration, false)
+
+	s.Lock()
+	defer s.Unlock()
+
+	ms := s.model(request.model)
+	ms.generations++
+	ms.consecutiveFailures = 0
+	ms.totalDuration += duration
+
+	if timings != nil {
+		ms.promptTokens += timings.promptTokens
+		ms.generatedTokens += timings.generatedTokens
+		ms.evalDuration += timings.evalDuration
+	}
+}
+
+// record a failed generation of given request
+func (s *botStats) recordFailure(request request, err error) {
+	collectError("generation with %s failed: %s", request.model, err)
+
+	recordUsage(request, time.Since(request.startedProcessingAt), true)
+
+	s.Lock()
+	defer s.Unlock()
+
+	ms := s.model(request.model)
+	ms.failures++
+	ms.consecutiveFailures++
+
+	errStr := err.Error()
+	ms.lastError = &errStr
+	ms.lastErrorAt = time.Now()
+}
+
+// returns the number of consecutive failures of given model
+func (s *botStats) consecutiveFailures(model model) int {
+	s.Lock()
+	defer s.Unlock()
+
+	if ms, exists := s.models[model.String()]; exists {
+		return ms.consecutiveFailures
+	}
+	return 0
+}
+
+// returns the average generation time of given model
+func (s *botStats) averageDuration(model model) (time.Duration, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	ms := s.model(model)
+	if ms.generations == 0 {
+		return 0, false
+	}
+	return ms.totalDuration / time.Duration(ms.generations), true
+}
+
+// returns the average number of generated tokens per second of given model
+func (s *botStats) tokensPerSecond(model model) (float64, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	ms := s.model(model)
+	if ms.evalDuration <= 0 {
+		return 0, false
+	}
+	return float64(ms.generatedTokens) / ms.evalDuration.Seconds(), true
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// prompt for summarizing older conversation turns
+const summarizationPrompt = "Summarize the following conversation between a user and an assistant briefly, keeping important facts:"
+
+// number of recent turns which will not be summarized
+const unsummarizedTurns = 2
+
+// returns the model for summarizing conversations: `summarization_model_index` or given model
+func summarizationModel(conf config, fallback model) model {
+	if index := conf.SummarizationModelIndex; index != nil && *index >= 0 && *index < len(conf.Models) {
+		return conf.Models[*index]
+	}
+
+	return fallback
+}
+
+// returns recent conversation turns of given chat with given model,
+// compressing older turns into a summary when they exceed the half of the model's context length
+func compressedConversationHistory(ctx context.Context, conf config, chatID int64, model model) []conversationTurn {
+	turns := conversationHistory(conf, chatID, model)
+	if len(turns) <= unsummarizedTurns {
+		return turns
+	}
+
+	tokens := 0
+	for _, turn := range turns {
+		tokens += countTokens(model, turn.User) + countTokens(model, turn.Assistant)
+	}
+	if tokens <= contextLength(model)/2 {
+		return turns
+	}
+
+	if err := summarizeConversationTurns(ctx, conf, chatID, model, turns[:len(turns)-unsummarizedTurns]); err != nil {
+		log.Printf("Error: failed to summarize conversation: %s", err)
+		return turns
+	}
+
+	return conversationHistory(conf, chatID, model)
+}
+
+// replace given older turns (the oldest ones of given chat with given model, including its summary) with a summary of them
+func summarizeConversationTurns(ctx context.Context, conf config, chatID int64, model model, older []conversationTurn) error {
+	var transcript strings.Builder
+	for _, turn := range older {
+		if turn.Summary {
+			fmt.Fprintf(&transcript, "(summary of earlier conversation) %s\n", turn.Assistant)
+		} else {
+			fmt.Fprintf(&transcript, "User: %s\nAssistant: %s\n", turn.User, turn.Assistant)
+		}
+	}
+
+	summarizer := summarizationModel(conf, model)
+	text := escapeForShell(summarizationPrompt + "\n\n" + transcript.String())
+	summarization := request{
+		model:        summarizer,
+		originalText: &text,
+		date:         time.Now(),
+	}
+
+	log.Printf(">>> summarizing %d conversation turn(s) of chat %d with model: %s", len(older), chatID, summarizer)
+
+	summary, err := generate(ctx, summarization)
+	if err != nil {
+		return err
+	}
+
+	replaceConversationTurns(chatID, model, len(older), conversationTurn{
+		Model:     model.String(),
+		User:      "Summarize our conversation so far.",
+		Assistant: escapeForShell(strings.TrimSpace(summary.text)),
+		Summary:   true,
+	})
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// send given state (eg. "READY=1") to systemd, when run as a service with `Type=notify`
+//
+// (see sd_notify(3), it does nothing without `NOTIFY_SOCKET`)
+func sdNotify(state string) bool {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false
+	}
+
+	// (abstract socket names which start with '@' are handled by `net`)
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		log.Printf("Error: failed to connect to systemd notify socket: %s", err)
+		return false
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		log.Printf("Error: failed to notify systemd of '%s': %s", state, err)
+		return false
+	}
+	return true
+}
+
+// returns the interval of watchdog heartbeats for systemd (`WatchdogSec=`), which is the half of its timeout
+func sdWatchdogInterval() (time.Duration, bool) {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, false // (for another process)
+	}
+
+	return time.Duration(usec) * time.Microsecond / 2, true
+}
+
+// notify systemd that the bot is ready, and send watchdog heartbeats in background (if `WatchdogSec=` is set)
+//
+// NOTE: heartbeats are sent after taking the locks of the config and the request tracker,
+// so they stop (and systemd restarts the bot) when the bot is deadlocked
+func notifySystemdReady(conf config) {
+	if !sdNotify("READY=1") {
+		return
+	}
+	log.Printf(">>> notified systemd of readiness")
+
+	if interval, ok := sdWatchdogInterval(); ok {
+		log.Printf(">>> sending watchdog heartbeats to systemd every %s", interval)
+
+		go func() {
+			for range time.Tick(interval) {
+				_ = liveConfig(conf)
+				_, _ = tracker.counts()
+
+				sdNotify("WATCHDOG=1")
+			}
+		}()
+	}
+}
+
+// stop polling updates of given bot gracefully on SIGINT or SIGTERM, in background
+//
+// (systemd is notified of stopping, and running llamafile servers are stopped)
+func stopOnSignals(bot *tg.Bot) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		sig := <-ch
+
+		log.Printf(">>> stopping on signal: %s", sig)
+
+		sdNotify("STOPPING=1")
+		stopLlamafileServers()
+		bot.StopPollingUpdates()
+	}()
+}
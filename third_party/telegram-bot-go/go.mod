@@ -0,0 +1,3 @@
+module github.com/meinside/telegram-bot-go
+
+go 1.21.3
@@ -0,0 +1,12 @@
+package telegrambot
+
+import (
+	"net/http"
+)
+
+// SetHTTPClient replaces the HTTP client for requests to the Bot API.
+//
+// (for proxies, timeouts, or a self-hosted Bot API server)
+func (b *Bot) SetHTTPClient(client *http.Client) {
+	b.httpClient = client
+}
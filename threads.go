@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// default batch size of llamafile (`-b`)
+const DefaultBatchSize = 512
+
+// flags in `llamafile_other_parameters` which are replaced by `threads` and `batch_size`
+var (
+	threadsFlags   = []string{"-t", "--threads"}
+	batchSizeFlags = []string{"-b", "--batch-size"}
+)
+
+// returns the effective number of threads for given model, and whether it was detected automatically
+//
+// (auto: half of the available CPUs, as most of them are hyper-threads which don't speed up inference)
+func threadsOf(model model) (int, bool) {
+	if model.Threads != nil {
+		return *model.Threads, false
+	}
+	if value, exists := flagValueOf(model.LlamafileOtherParameters, threadsFlags...); exists {
+		if threads, err := strconv.Atoi(value); err == nil {
+			return threads, false
+		}
+	}
+
+	cpus := runtime.NumCPU()
+	if model.Scheduling != nil && model.Scheduling.CPUAffinity != "" {
+		cpus = min(cpus, countCPUs(model.Scheduling.CPUAffinity))
+	}
+	return max((cpus+1)/2, 1), true
+}
+
+// returns the effective batch size for given model, and whether it is the default one
+func batchSizeOf(model model) (int, bool) {
+	if model.BatchSize != nil {
+		return *model.BatchSize, false
+	}
+	if value, exists := flagValueOf(model.LlamafileOtherParameters, batchSizeFlags...); exists {
+		if size, err := strconv.Atoi(value); err == nil {
+			return size, false
+		}
+	}
+
+	return DefaultBatchSize, true
+}
+
+// returns parameters of threads and batch size for given model
+//
+// (threads are always given, but batch size is given only when it is set)
+func tuningParams(model model) (params []string) {
+	if _, exists := flagValueOf(model.LlamafileOtherParameters, threadsFlags...); !exists {
+		threads, _ := threadsOf(model)
+		params = append(params, "-t", strconv.Itoa(threads))
+	}
+	if model.BatchSize != nil {
+		params = append(params, "-b", strconv.Itoa(*model.BatchSize))
+	}
+
+	return params
+}
+
+// validate `threads` and `batch_size` of given model, and return found problems
+func validateTuning(model model) (problems []string) {
+	if model.Threads != nil {
+		if *model.Threads <= 0 {
+			problems = append(problems, fmt.Sprintf("`threads` is not positive: %d", *model.Threads))
+		}
+		if flag, exists := flagOf(model.LlamafileOtherParameters, threadsFlags...); exists {
+			problems = append(problems, fmt.Sprintf("`%s` in `llamafile_other_parameters` conflicts with `threads`", flag))
+		}
+	}
+	if model.BatchSize != nil {
+		if *model.BatchSize <= 0 {
+			problems = append(problems, fmt.Sprintf("`batch_size` is not positive: %d", *model.BatchSize))
+		}
+		if flag, exists := flagOf(model.LlamafileOtherParameters, batchSizeFlags...); exists {
+			problems = append(problems, fmt.Sprintf("`%s` in `llamafile_other_parameters` conflicts with `batch_size`", flag))
+		}
+	}
+
+	return problems
+}
+
+// returns the first one of given flags in given parameters
+func flagOf(params []string, flags ...string) (string, bool) {
+	for _, param := range params {
+		for _, flag := range flags {
+			if param == flag {
+				return flag, true
+			}
+		}
+	}
+	return "", false
+}
+
+// returns the value of the first one of given flags in given parameters
+func flagValueOf(params []string, flags ...string) (string, bool) {
+	for i := 0; i < len(params)-1; i++ {
+		for _, flag := range flags {
+			if params[i] == flag {
+				return params[i+1], true
+			}
+		}
+	}
+	return "", false
+}
+
+// count CPUs in given list of CPUs (eg. "0-3,6" => 5)
+func countCPUs(list string) (count int) {
+	for _, part := range strings.Split(list, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		if !isRange {
+			count++
+			continue
+		}
+
+		start, err1 := strconv.Atoi(from)
+		end, err2 := strconv.Atoi(to)
+		if err1 == nil && err2 == nil && end >= start {
+			count += end - start + 1
+		}
+	}
+	return count
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// timings of a generation, printed by llamafile (on stderr)
+type llamafileTimings struct {
+	promptTokens       int
+	promptEvalDuration time.Duration
+
+	generatedTokens int
+	evalDuration    time.Duration
+}
+
+// regular expressions for parsing timings
+//
+// (eg. "llama_print_timings:        eval time =    1234.56 ms /    49 runs   (   25.19 ms per token,    39.69 tokens per second)")
+var (
+	promptEvalTimeRegex = regexp.MustCompile(`(?m)^[^:\n]*:\s*prompt eval time\s*=\s*([\d.]+) ms\s*/\s*(\d+) (?:tokens|runs)`)
+	evalTimeRegex       = regexp.MustCompile(`(?m)^[^:\n]*:\s*eval time\s*=\s*([\d.]+) ms\s*/\s*(\d+) (?:tokens|runs)`)
+)
+
+// parse timings from given stderr of llamafile, returns nil if there is none
+func parseLlamafileTimings(stderr string) *llamafileTimings {
+	var timings llamafileTimings
+
+	matches := evalTimeRegex.FindStringSubmatch(stderr)
+	if matches == nil {
+		return nil
+	}
+	timings.evalDuration, timings.generatedTokens = parseTimingMatches(matches)
+
+	if matches := promptEvalTimeRegex.FindStringSubmatch(stderr); matches != nil {
+		timings.promptEvalDuration, timings.promptTokens = parseTimingMatches(matches)
+	}
+
+	return &timings
+}
+
+// parse a duration (in milliseconds) and a number of tokens from given matches
+func parseTimingMatches(matches []string) (time.Duration, int) {
+	msecs, _ := strconv.ParseFloat(matches[1], 64)
+	tokens, _ := strconv.Atoi(matches[2])
+
+	return time.Duration(msecs * float64(time.Millisecond)), tokens
+}
+
+// returns the number of generated tokens per second
+func (t llamafileTimings) tokensPerSecond() float64 {
+	if t.evalDuration <= 0 {
+		return 0
+	}
+	return float64(t.generatedTokens) / t.evalDuration.Seconds()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// check if given model is disabled, in config or at runtime
+func (m model) isDisabled() bool {
+	if m.Disabled {
+		return true
+	}
+
+	disabled := false
+	db.read(func(data dbData) {
+		disabled = slices.Contains(data.DisabledModels, m.String())
+	})
+
+	return disabled
+}
+
+// disable (or re-enable) given model at runtime
+//
+// (models disabled in config cannot be enabled)
+func setModelDisabled(m model, disabled bool) error {
+	if disabled {
+		log.Printf(">>> disabling model at runtime: %s", m)
+	} else {
+		log.Printf(">>> enabling model at runtime: %s", m)
+	}
+
+	return db.update(func(data *dbData) {
+		name := m.String()
+		data.DisabledModels = slices.DeleteFunc(data.DisabledModels, func(n string) bool { return n == name })
+		if disabled {
+			data.DisabledModels = append(data.DisabledModels, name)
+		}
+	})
+}
+
+// argument of `/enable` and `/disable` for writing the change to the config file
+const toggleArgPersist = "persist"
+
+// handle `/enable` and `/disable` commands: toggle the model with given index (in `models`) or name at runtime,
+// and return a message about it
+//
+// (with a trailing `persist` argument, the change is also written to the config file)
+func toggleModelMessage(conf config, args string, disabled bool) string {
+	command := CommandEnable
+	if disabled {
+		command = CommandDisable
+	}
+
+	name, persist := strings.CutSuffix(args, " "+toggleArgPersist)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Sprintf("Usage: %s INDEX_OR_NAME [%s]", command, toggleArgPersist)
+	}
+
+	index, found := modelIndexByIndexOrName(conf, name)
+	if !found {
+		return fmt.Sprintf("No such model: %s", escapeForHTML(name))
+	}
+	m := conf.Models[index]
+	if m.Disabled {
+		return fmt.Sprintf("<b>%s</b> is disabled in config, so it cannot be toggled at runtime.", escapeForHTML(m.String()))
+	}
+
+	if err := setModelDisabled(m, disabled); err != nil {
+		log.Printf("Error: failed to toggle model %s: %s", m, err)
+		return fmt.Sprintf("Failed to toggle <b>%s</b>: %s", escapeForHTML(m.String()), escapeForHTML(err.Error()))
+	}
+
+	state := "enabled"
+	if disabled {
+		state = "disabled"
+	}
+	if persist {
+		if err := persistModelDisabled(conf, index, disabled); err != nil {
+			log.Printf("Error: failed to write the change of model %s to the config file: %s", m, err)
+			return fmt.Sprintf("<b>%s</b> was %s, but failed to write it to the config file: %s", escapeForHTML(m.String()), state, escapeForHTML(err.Error()))
+		}
+		return fmt.Sprintf("<b>%s</b> was %s (and written to the config file).", escapeForHTML(m.String()), state)
+	}
+
+	return fmt.Sprintf("<b>%s</b> was %s.", escapeForHTML(m.String()), state)
+}
+
+// returns the index of the model in config with given index or name (or alias)
+func modelIndexByIndexOrName(conf config, name string) (int, bool) {
+	if index, err := strconv.Atoi(name); err == nil {
+		return index, index >= 0 && index < len(conf.Models)
+	}
+
+	for i, m := range conf.Models {
+		if m.isNamed(name) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// write `disabled` of the model with given index to the config file
+func persistModelDisabled(conf config, index int, disabled bool) error {
+	return updateConfigFile(conf, func(raw map[string]any) error {
+		models, _ := raw["models"].([]any)
+		if index >= len(models) {
+			return fmt.Errorf("model #%d is not in the config file (but in `models_dir`, or added at runtime)", index)
+		}
+		m, ok := models[index].(map[string]any)
+		if !ok {
+			return fmt.Errorf("model #%d is malformed", index)
+		}
+		m["disabled"] = disabled
+
+		return nil
+	})
+}
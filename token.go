@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// timeout of `telegram_bot_token_command`
+const TokenCommandTimeoutSeconds = 30
+
+// returns the bot token from `telegram_bot_token`, `telegram_bot_token_file`, or `telegram_bot_token_command`
+func telegramBotToken(conf config) (token string, err error) {
+	switch {
+	case conf.TelegramBotToken != "":
+		return conf.TelegramBotToken, nil
+	case conf.TelegramBotTokenFile != "":
+		var bytes []byte
+		if bytes, err = os.ReadFile(conf.telegramBotTokenFilePath()); err != nil {
+			return "", fmt.Errorf("failed to read `telegram_bot_token_file`: %s", err)
+		}
+		token = strings.TrimSpace(string(bytes))
+	case len(conf.TelegramBotTokenCommand) > 0:
+		if token, err = runTokenCommand(conf.TelegramBotTokenCommand); err != nil {
+			return "", err
+		}
+	}
+
+	if token == "" {
+		return "", fmt.Errorf("bot token is empty")
+	}
+
+	return token, nil
+}
+
+// returns the path of `telegram_bot_token_file` (relative to the config file, if it is not absolute)
+func (c config) telegramBotTokenFilePath() string {
+	if c.TelegramBotTokenFile == "" || filepath.IsAbs(c.TelegramBotTokenFile) {
+		return c.TelegramBotTokenFile
+	}
+	return filepath.Join(filepath.Dir(c.path), c.TelegramBotTokenFile)
+}
+
+// run given command (eg. `["pass", "show", "telegram/bot-token"]`), and return its output as a token
+func runTokenCommand(command []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TokenCommandTimeoutSeconds*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = os.Stdin // (for commands which ask passphrases)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("`telegram_bot_token_command` failed: %s (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// (only the first line, as `pass` does)
+	token, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTelegramBotTokenFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token.txt"), []byte("  01234567:abcdefg\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %s", err)
+	}
+
+	for _, file := range []string{"token.txt", filepath.Join(dir, "token.txt")} {
+		conf := config{path: filepath.Join(dir, "config.json"), TelegramBotTokenFile: file}
+
+		if token, err := telegramBotToken(conf); err != nil {
+			t.Errorf("failed to read token file '%s': %s", file, err)
+		} else if token != "01234567:abcdefg" {
+			t.Errorf("unexpected token from '%s': '%s'", file, token)
+		}
+	}
+}
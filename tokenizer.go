@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// max length (in runes) of a token to look up in the vocabulary
+const maxTokenLength = 32
+
+// tokenizer built from the vocabulary in GGUF metadata
+//
+// NOTE: it tokenizes with greedy longest matches, so the number of tokens is approximate
+// (but much closer than counting characters)
+type tokenizer struct {
+	vocab     map[string]struct{}
+	maxLength int
+	kind      string // `tokenizer.ggml.model`: "llama" (SentencePiece), "gpt2" (BPE), ...
+}
+
+// cached tokenizers, keyed by llamafile path (nil if not available)
+var tokenizers = map[string]*tokenizer{}
+var tokenizersLock sync.Mutex
+
+// returns the tokenizer of given model, or nil if it is not available
+func tokenizerFor(model model) *tokenizer {
+	if model.LlamafilePath == nil {
+		return nil
+	}
+
+	tokenizersLock.Lock()
+	defer tokenizersLock.Unlock()
+
+	if t, exists := tokenizers[*model.LlamafilePath]; exists {
+		return t
+	}
+
+	var t *tokenizer
+	if metadata, err := readGGUFMetadata(*model.LlamafilePath); err == nil {
+		if tokens, exists := metadata.Strings(ggufKeyTokens); exists {
+			t = &tokenizer{
+				vocab: map[string]struct{}{},
+			}
+			t.kind, _ = metadata.String("tokenizer.ggml.model")
+			for _, token := range tokens {
+				t.vocab[token] = struct{}{}
+				t.maxLength = max(t.maxLength, len([]rune(token)))
+			}
+			t.maxLength = min(t.maxLength, maxTokenLength)
+		}
+	} else {
+		log.Printf("Error: failed to read vocabulary of model %s: %s", model, err)
+	}
+	tokenizers[*model.LlamafilePath] = t
+
+	return t
+}
+
+// convert given text into the representation of the vocabulary,
+// and return its runes with their (end) byte offsets in the original text
+func (t *tokenizer) normalize(text string) (runes []rune, offsets []int) {
+	if t.kind == "llama" {
+		runes, offsets = append(runes, '▁'), append(offsets, 0)
+	}
+
+	for i, r := range text {
+		end := i + len(string(r))
+
+		switch t.kind {
+		case "llama":
+			if r == ' ' {
+				r = '▁'
+			}
+		case "gpt2":
+			if r == ' ' {
+				r = 'Ġ'
+			} else if r == '\n' {
+				r = 'Ċ'
+			}
+		}
+
+		runes, offsets = append(runes, r), append(offsets, end)
+	}
+
+	return runes, offsets
+}
+
+// tokenize given text, and return (end) byte offsets of each token in the text
+func (t *tokenizer) tokenize(text string) (ends []int) {
+	runes, offsets := t.normalize(text)
+
+	for i := 0; i < len(runes); {
+		length := 1 // (unknown runes are counted as a token)
+		for l := min(t.maxLength, len(runes)-i); l > 1; l-- {
+			if _, exists := t.vocab[string(runes[i:i+l])]; exists {
+				length = l
+				break
+			}
+		}
+
+		i += length
+		ends = append(ends, offsets[i-1])
+	}
+
+	return ends
+}
+
+// returns the number of tokens of given text for given model
+//
+// NOTE: if the model's vocabulary is not available, it is estimated as 4 characters per token
+func countTokens(model model, text string) int {
+	if t := tokenizerFor(model); t != nil {
+		return len(t.tokenize(text))
+	}
+
+	return len(text)/4 + 1
+}
+
+// truncate given text to given number of tokens for given model
+func truncateToTokens(model model, text string, tokens int) (truncated string, isTruncated bool) {
+	if tokens <= 0 {
+		return "", text != ""
+	}
+
+	if t := tokenizerFor(model); t != nil {
+		ends := t.tokenize(text)
+		if len(ends) <= tokens {
+			return text, false
+		}
+		return strings.TrimSpace(text[:ends[tokens-1]]), true
+	}
+
+	if limit := tokens * 4; len(text) > limit {
+		return strings.ToValidUTF8(text[:limit], ""), true
+	}
+	return text, false
+}
+
+// fit the prompt of given request in the model's context length (with the tokens to generate), and return the request and prompt which fit,
+// with the number of tokens of the truncated input (0 if not truncated)
+//
+// when the prompt exceeds it, the oldest turns of the conversation history are dropped first, then the original text is truncated
+// (an error is returned if no token is left for the prompt)
+func fitToContextLength(request request, prompt string) (fitted request, fittedPrompt string, truncatedTokens int, err error) {
+	model := request.model
+
+	length := contextLength(model)
+	available := length
+	if maxTokens := maxTokensOf(request); maxTokens != nil {
+		if available -= *maxTokens; available <= 0 {
+			return request, "", 0, fmt.Errorf("max tokens (%d) leave no room for the prompt in the context length (%d tokens), lower them", *maxTokens, length)
+		}
+	}
+
+	promptTokens := countTokens(model, prompt)
+	if promptTokens <= available {
+		return request, prompt, 0, nil
+	}
+
+	log.Printf(">>> prompt (%d tokens) exceeds the context length of model %s (%d tokens available)", promptTokens, model, available)
+
+	for promptTokens > available && len(request.history) > 0 {
+		request.history = request.history[1:]
+		if prompt, err = llamafilePrompt(request); err != nil {
+			return request, "", 0, err
+		}
+		promptTokens = countTokens(model, prompt)
+	}
+	if promptTokens <= available || request.originalText == nil {
+		return request, prompt, 0, nil
+	}
+
+	// (repeated, as the number of tokens of the whole prompt can differ from the sum of its parts)
+	original := *request.originalText
+	truncatedTokens = countTokens(model, original)
+	for promptTokens > available && truncatedTokens > 0 {
+		truncatedTokens = max(truncatedTokens-(promptTokens-available), 0)
+		truncated, _ := truncateToTokens(model, original, truncatedTokens)
+		request.originalText = &truncated
+
+		if prompt, err = llamafilePrompt(request); err != nil {
+			return request, "", 0, err
+		}
+		promptTokens = countTokens(model, prompt)
+	}
+
+	return request, prompt, truncatedTokens, nil
+}
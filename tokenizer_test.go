@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// returns a tokenizer with given vocabulary
+func testTokenizer(kind string, tokens ...string) *tokenizer {
+	t := &tokenizer{vocab: map[string]struct{}{}, kind: kind}
+	for _, token := range tokens {
+		t.vocab[token] = struct{}{}
+		t.maxLength = max(t.maxLength, len([]rune(token)))
+	}
+	return t
+}
+
+func TestTokenize(t *testing.T) {
+	llama := testTokenizer("llama", "▁hello", "▁world", "▁", "!", "▁wor")
+	gpt2 := testTokenizer("gpt2", "hello", "Ġworld", "Ċ", "Ġ")
+
+	tests := []struct {
+		name      string
+		tokenizer *tokenizer
+		text      string
+		ends      []int
+	}{
+		{"llama: longest matches", llama, "hello world!", []int{5, 11, 12}},
+		{"llama: unknown runes", llama, "hi", []int{0, 1, 2}},
+		{"llama: multi-byte runes", llama, "hello 세계", []int{5, 6, 9, 12}},
+		{"gpt2: spaces and newlines", gpt2, "hello world\n", []int{5, 11, 12}},
+		{"gpt2: empty", gpt2, "", nil},
+	}
+
+	for _, test := range tests {
+		ends := test.tokenizer.tokenize(test.text)
+		if len(ends) != len(test.ends) {
+			t.Errorf("[%s] expected ends %v, got %v", test.name, test.ends, ends)
+			continue
+		}
+		for i := range ends {
+			if ends[i] != test.ends[i] {
+				t.Errorf("[%s] expected ends %v, got %v", test.name, test.ends, ends)
+				break
+			}
+		}
+	}
+}
+
+func TestCountAndTruncateTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gguf")
+	if err := os.WriteFile(path, testGGUF(), 0644); err != nil { // (vocabulary: "▁hello", "▁world", "▁", and "!")
+		t.Fatalf("failed to write GGUF file: %s", err)
+	}
+	withVocab := model{LlamafilePath: &path}
+	withoutVocab := model{} // (4 characters per token)
+
+	tests := []struct {
+		name      string
+		model     model
+		text      string
+		count     int
+		truncate  int
+		truncated string
+	}{
+		{"vocabulary", withVocab, "hello world!", 3, 2, "hello world"},
+		{"vocabulary, not truncated", withVocab, "hello world!", 3, 3, "hello world!"},
+		{"vocabulary, truncated to nothing", withVocab, "hello", 1, 0, ""},
+		{"estimation", withoutVocab, "12345678", 3, 1, "1234"},
+		{"estimation, multi-byte runes", withoutVocab, "세계", 2, 1, "세"},
+	}
+
+	for _, test := range tests {
+		if count := countTokens(test.model, test.text); count != test.count {
+			t.Errorf("[%s] expected %d tokens, got %d", test.name, test.count, count)
+		}
+		if truncated, _ := truncateToTokens(test.model, test.text, test.truncate); truncated != test.truncated {
+			t.Errorf("[%s] expected '%s' after truncation, got '%s'", test.name, test.truncated, truncated)
+		}
+	}
+}
+
+func TestFitToContextLength(t *testing.T) {
+	pattern, placeholder := "[INST]%p[/INST]", "%p"
+	m := model{
+		LlamafilePromptPattern:     &pattern,
+		LlamafilePromptPlaceholder: &placeholder,
+		LlamafileOtherParameters:   []string{"-c", "100"}, // (estimated as 4 characters per token, without a vocabulary)
+	}
+
+	turn := conversationTurn{User: strings.Repeat("q", 100), Assistant: strings.Repeat("a", 100)}
+
+	tests := []struct {
+		name         string
+		original     string
+		history      []conversationTurn
+		maxTokens    int
+		err          bool
+		keptTurns    int // expected number of kept turns
+		truncated    bool
+		keepOriginal bool
+	}{
+		{name: "fits", original: "hello", history: []conversationTurn{turn}, maxTokens: 20, keptTurns: 1, keepOriginal: true},
+		{name: "no room for the prompt", original: "hello", maxTokens: 100, err: true},
+		{name: "max tokens over the context", original: "hello", maxTokens: 150, err: true},
+		{name: "history dropped first", original: "hello", history: []conversationTurn{turn, turn, turn}, maxTokens: 20, keptTurns: 1, keepOriginal: true},
+		{name: "original truncated", original: strings.Repeat("word ", 200), history: []conversationTurn{turn}, maxTokens: 20, keptTurns: 0, truncated: true},
+	}
+
+	for _, test := range tests {
+		request := request{model: m, originalText: &test.original, history: test.history}
+		request.options.maxTokens = &test.maxTokens
+
+		prompt, err := llamafilePrompt(request)
+		if err != nil {
+			t.Fatalf("[%s] failed to build prompt: %s", test.name, err)
+		}
+
+		fitted, fittedPrompt, truncatedTokens, err := fitToContextLength(request, prompt)
+		if test.err {
+			if err == nil {
+				t.Errorf("[%s] should fail", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%s] failed to fit: %s", test.name, err)
+			continue
+		}
+
+		if tokens := countTokens(m, fittedPrompt); tokens > 100-test.maxTokens {
+			t.Errorf("[%s] prompt (%d tokens) should fit in %d tokens", test.name, tokens, 100-test.maxTokens)
+		}
+		if len(fitted.history) != test.keptTurns {
+			t.Errorf("[%s] expected %d turn(s) of history, got %d", test.name, test.keptTurns, len(fitted.history))
+		}
+		if (truncatedTokens > 0) != test.truncated {
+			t.Errorf("[%s] expected truncated = %v, got %d truncated tokens", test.name, test.truncated, truncatedTokens)
+		}
+		if test.keepOriginal && *fitted.originalText != test.original {
+			t.Errorf("[%s] original text should be kept: '%s'", test.name, *fitted.originalText)
+		}
+	}
+}
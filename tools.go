@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// constants for tools
+const (
+	DefaultToolMaxSteps = 3
+
+	ToolTimeoutSeconds = 30
+	MaxToolOutputBytes = 4000
+
+	MaxWebSearchResults = 5
+)
+
+// built-in tools
+const (
+	ToolBuiltInWebSearch  = "web_search"
+	ToolBuiltInCalculator = "calculator"
+)
+
+// configs for tool-calling
+type toolsConfig struct {
+	MaxSteps int    `json:"max_steps,omitempty"` // max number of tool calls for each request
+	Tools    []tool `json:"tools"`
+}
+
+// a tool which can be called by models
+type tool struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Command     []string `json:"command,omitempty"`  // command which receives the input on stdin, and returns the result on stdout
+	BuiltIn     string   `json:"built_in,omitempty"` // "web_search" or "calculator"
+}
+
+// a tool call (or the final answer) emitted by a model
+type toolCall struct {
+	Tool   string `json:"tool,omitempty"`
+	Input  string `json:"input,omitempty"`
+	Answer string `json:"answer,omitempty"`
+}
+
+// valid names of tools (for using them as literals in GBNF grammars, and in JSON, without escaping)
+var toolNameRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// validate tools in given config
+func validateTools(conf toolsConfig) error {
+	names := map[string]bool{}
+	for i, t := range conf.Tools {
+		if !toolNameRegex.MatchString(t.Name) {
+			return fmt.Errorf("name of tool #%d should only have alphanumeric characters and underscores: '%s'", i, t.Name)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("name of tool #%d is duplicated: '%s'", i, t.Name)
+		}
+		names[t.Name] = true
+
+		switch t.BuiltIn {
+		case ToolBuiltInWebSearch, ToolBuiltInCalculator:
+		case "":
+			if len(t.Command) == 0 || t.Command[0] == "" {
+				return fmt.Errorf("tool '%s' should have `command` or `built_in`", t.Name)
+			}
+		default:
+			return fmt.Errorf("unknown built-in tool '%s': '%s'", t.Name, t.BuiltIn)
+		}
+	}
+	return nil
+}
+
+// run the tool with given input
+func (t tool) run(ctx context.Context, input string) (string, error) {
+	switch t.BuiltIn {
+	case ToolBuiltInCalculator:
+		value, err := calculate(input)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case ToolBuiltInWebSearch:
+		return webSearch(ctx, input)
+	case "":
+		if len(t.Command) == 0 {
+			return "", fmt.Errorf("no command for tool '%s'", t.Name)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, ToolTimeoutSeconds*time.Second)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, t.Command[0], t.Command[1:]...)
+		cmd.Stdin = strings.NewReader(input)
+		out, err := cmd.Output()
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+
+	return "", fmt.Errorf("unknown built-in tool: '%s'", t.BuiltIn)
+}
+
+// regular expressions for parsing search results
+var (
+	webSearchResultRegex  = regexp.MustCompile(`(?s)<a[^>]*class="result__a"[^>]*href="([^"]*)"[^>]*>(.*?)</a>.*?<a[^>]*class="result__snippet"[^>]*>(.*?)</a>`)
+	webSearchTagRegex     = regexp.MustCompile(`<[^>]*>`)
+	webSearchRedirectHost = "duckduckgo.com"
+)
+
+// search the web with DuckDuckGo's HTML endpoint, and return the results as text
+func webSearch(ctx context.Context, query string) (string, error) {
+	client := http.Client{Timeout: ToolTimeoutSeconds * time.Second}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://html.duckduckgo.com/html/?q="+url.QueryEscape(query), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxURLFetchBytes))
+	if err != nil {
+		return "", err
+	}
+
+	results := []string{}
+	for _, matches := range webSearchResultRegex.FindAllStringSubmatch(string(body), MaxWebSearchResults) {
+		link := html.UnescapeString(matches[1])
+
+		// (unwrap redirection links)
+		if u, err := url.Parse(link); err == nil && strings.HasSuffix(u.Hostname(), webSearchRedirectHost) {
+			if target := u.Query().Get("uddg"); target != "" {
+				link = target
+			}
+		}
+
+		title := html.UnescapeString(webSearchTagRegex.ReplaceAllString(matches[2], ""))
+		snippet := html.UnescapeString(webSearchTagRegex.ReplaceAllString(matches[3], ""))
+		results = append(results, fmt.Sprintf("- %s (%s)\n  %s", strings.TrimSpace(title), link, strings.TrimSpace(snippet)))
+	}
+	if len(results) == 0 {
+		return "No results.", nil
+	}
+
+	return strings.Join(results, "\n"), nil
+}
+
+// GBNF grammar for a JSON string
+const gbnfJSONString = `string ::= "\"" ( [^"\\\x00-\x1f] | "\\" ["\\/bfnrt] )* "\""`
+
+// build a GBNF grammar which constrains outputs to tool calls of given tools (or the final answer)
+func toolCallGrammar(tools []tool, allowCalls bool) string {
+	if !allowCalls || len(tools) == 0 {
+		return `root ::= "{\"answer\": " string "}"` + "\n" + gbnfJSONString
+	}
+
+	names := []string{}
+	for _, t := range tools {
+		names = append(names, fmt.Sprintf(`"\"%s\""`, t.Name))
+	}
+
+	return strings.Join([]string{
+		`root ::= call | answer`,
+		`call ::= "{\"tool\": " name ", \"input\": " string "}"`,
+		`answer ::= "{\"answer\": " string "}"`,
+		`name ::= ` + strings.Join(names, " | "),
+		gbnfJSONString,
+	}, "\n")
+}
+
+// build an instruction for tool-calling with given tools
+func toolInstruction(tools []tool) string {
+	var sb strings.Builder
+	sb.WriteString("You can use the following tools:\n")
+	for _, t := range tools {
+		fmt.Fprintf(&sb, "- %s: %s\n", t.Name, t.Description)
+	}
+	sb.WriteString("\nTo use a tool, respond with {\"tool\": NAME, \"input\": INPUT}. When you know the answer, respond with {\"answer\": ANSWER}.")
+
+	return sb.String()
+}
+
+// answer given request with tools: let the model call tools and feed their results back, until it answers
+//
+// returns the answer, and the list of tool calls
+func generateWithTools(ctx context.Context, conf config, request request, gen Generator) (answer string, calls []string, err error) {
+	tools := conf.Tools.Tools
+	maxSteps := DefaultToolMaxSteps
+	if conf.Tools.MaxSteps > 0 {
+		maxSteps = conf.Tools.MaxSteps
+	}
+
+	var question string
+	if request.originalText != nil {
+		question = *request.originalText
+	}
+	if request.commentText != nil {
+		question = fmt.Sprintf("%s\n\n%s", *request.commentText, question)
+	}
+
+	var scratchpad strings.Builder
+	for step := 0; step <= maxSteps; step++ {
+		text := escapeForShell(toolInstruction(tools) + "\n\nQuestion: " + question + "\n" + scratchpad.String())
+
+		stepRequest := request
+		stepRequest.originalText = &text
+		stepRequest.commentText = nil
+
+		var prompt generationPrompt
+		if stepRequest, prompt, _, err = promptFor(stepRequest); err != nil {
+			return "", calls, err
+		}
+
+		// (grammars of the model are replaced with the one for tool calls, and the last step should answer without tools)
+		opts := resolvedOptions(stepRequest)
+		grammar := toolCallGrammar(tools, step < maxSteps)
+		opts.grammar = &grammar
+
+		var generated generationResult
+		if generated, err = gen.Generate(ctx, prompt, opts); err != nil {
+			return "", calls, err
+		}
+		output := generated.text
+
+		var call toolCall
+		if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &call); err != nil {
+			// (not a tool call, so treat it as the answer)
+			return output, calls, nil
+		}
+		if call.Tool == "" {
+			return call.Answer, calls, nil
+		}
+
+		// run the tool
+		var result string
+		found := false
+		for _, t := range tools {
+			if t.Name == call.Tool {
+				found = true
+
+				log.Printf(">>> calling tool %s with input: %s", t.Name, loggable(conf, call.Input))
+
+				if out, err := t.run(ctx, call.Input); err == nil {
+					result = out
+				} else {
+					result = fmt.Sprintf("Error: %s", err)
+				}
+				break
+			}
+		}
+		if !found {
+			result = fmt.Sprintf("Error: no such tool: %s", call.Tool)
+		}
+		if len(result) > MaxToolOutputBytes {
+			result = string(bytes.ToValidUTF8([]byte(result[:MaxToolOutputBytes]), nil))
+		}
+
+		calls = append(calls, fmt.Sprintf("%s(%s)", call.Tool, call.Input))
+		fmt.Fprintf(&scratchpad, "\nTool call: %s(%s)\nTool result: %s\n", call.Tool, call.Input, strings.TrimSpace(result))
+	}
+
+	return "", calls, fmt.Errorf("no answer after %d steps", maxSteps)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   float64
+	}{
+		{"1+2", 3},
+		{" 1 + 2 * 3 ", 7},
+		{"(1+2)*3", 9},
+		{"10-4-3", 3},
+		{"7/2", 3.5},
+		{"100/10/5", 2},
+		{"10%3", 1},
+		{"5.5%2", 1.5},
+		{"2^10", 1024},
+		{"2^3^2", 512}, // (right-associative)
+		{"-3+5", 2},
+		{"2*-3", -6},
+		{"--2", 2},
+		{"+4", 4},
+		{"((2))", 2},
+		{"0.25+0.5", 0.75},
+	}
+
+	for _, test := range tests {
+		value, err := calculate(test.expression)
+		if err != nil {
+			t.Errorf("failed to calculate '%s': %s", test.expression, err)
+		} else if value != test.expected {
+			t.Errorf("expected %v for '%s', got %v", test.expected, test.expression, value)
+		}
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"", "unexpected end"},
+		{"1+", "unexpected end"},
+		{"1/0", "division by zero"},
+		{"5%0", "division by zero"},
+		{"(1+2", "missing ')'"},
+		{"1+2)", "unexpected ')'"},
+		{"abc", "unexpected 'a'"},
+		{"2*x", "unexpected 'x'"},
+		{"1..2", "invalid syntax"},
+		{"1.5e3", "unexpected 'e'"}, // (exponents are not supported)
+	}
+
+	for _, test := range tests {
+		if _, err := calculate(test.expression); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("expected error '%s' for '%s', got: %v", test.err, test.expression, err)
+		}
+	}
+}
+
+func TestValidateTools(t *testing.T) {
+	tests := []struct {
+		names []string
+		valid bool
+	}{
+		{names: []string{"web_search", "calculator", "get_weather2"}, valid: true},
+		{names: []string{}, valid: true},
+		{names: []string{""}},
+		{names: []string{`quote"`}},
+		{names: []string{`back\slash`}},
+		{names: []string{"with space"}},
+		{names: []string{"hyphen-ated"}},
+		{names: []string{"same", "same"}},
+	}
+
+	for _, test := range tests {
+		conf := toolsConfig{}
+		for _, name := range test.names {
+			conf.Tools = append(conf.Tools, tool{Name: name, Command: []string{"echo"}})
+		}
+
+		if err := validateTools(conf); (err == nil) != test.valid {
+			t.Errorf("expected valid = %v for %q, got error: %v", test.valid, test.names, err)
+		}
+	}
+}
+
+func TestValidateToolCommands(t *testing.T) {
+	tests := []struct {
+		tool  tool
+		valid bool
+	}{
+		{tool: tool{Name: "search", BuiltIn: ToolBuiltInWebSearch}, valid: true},
+		{tool: tool{Name: "calc", BuiltIn: ToolBuiltInCalculator}, valid: true},
+		{tool: tool{Name: "weather", Command: []string{"/usr/local/bin/weather", "--json"}}, valid: true},
+		{tool: tool{Name: "nothing"}},
+		{tool: tool{Name: "empty", Command: []string{}}},
+		{tool: tool{Name: "blank", Command: []string{""}}},
+		{tool: tool{Name: "unknown", BuiltIn: "web_browser"}},
+		{tool: tool{Name: "unknown_with_command", BuiltIn: "web_browser", Command: []string{"echo"}}},
+	}
+
+	for _, test := range tests {
+		if err := validateTools(toolsConfig{Tools: []tool{test.tool}}); (err == nil) != test.valid {
+			t.Errorf("expected valid = %v for %+v, got error: %v", test.valid, test.tool, err)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// default service name of traces
+const DefaultTracingServiceName = "telegram-llamafiles-bot"
+
+// spans are exported in batches of this size, or at this interval
+const (
+	TracingBatchSize             = 100
+	TracingExportIntervalSeconds = 5
+	TracingExportTimeoutSeconds  = 10
+	TracingSpanQueueSize         = 1000
+)
+
+// config of tracing, exported to an OpenTelemetry collector with OTLP/HTTP (JSON encoding)
+type tracingConfig struct {
+	OTLPEndpoint string            `json:"otlp_endpoint"`          // eg. "http://127.0.0.1:4318" (`/v1/traces` is appended)
+	ServiceName  string            `json:"service_name,omitempty"` // (default: "telegram-llamafiles-bot")
+	Headers      map[string]string `json:"headers,omitempty"`      // eg. for authentication
+}
+
+// a finished span
+type span struct {
+	traceID, spanID, parentSpanID string
+
+	name       string
+	start, end time.Time
+	attributes map[string]any // (string or int64 values)
+	err        error
+}
+
+// trace of a request: the root span (from enqueueing to delivery), and its child spans
+//
+// (methods of nil trace do nothing, so it can be used without checking if tracing is enabled)
+type requestTrace struct {
+	traceID    string
+	rootSpanID string
+	start      time.Time
+	attributes map[string]any
+}
+
+// queue of finished spans to be exported (nil if tracing is disabled)
+var spans chan span
+
+// start exporting spans to the OTLP endpoint in given config, in background
+func startTracing(conf config) {
+	if conf.Tracing == nil || conf.Tracing.OTLPEndpoint == "" {
+		return
+	}
+
+	spans = make(chan span, TracingSpanQueueSize)
+
+	endpoint := strings.TrimSuffix(conf.Tracing.OTLPEndpoint, "/") + "/v1/traces"
+	serviceName := conf.Tracing.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultTracingServiceName
+	}
+
+	log.Printf(">>> exporting traces to: %s", endpoint)
+
+	go func() {
+		ticker := time.NewTicker(TracingExportIntervalSeconds * time.Second)
+		defer ticker.Stop()
+
+		batch := []span{}
+		for {
+			select {
+			case s := <-spans:
+				if batch = append(batch, s); len(batch) < TracingBatchSize {
+					continue
+				}
+			case <-ticker.C:
+				if len(batch) == 0 {
+					continue
+				}
+			}
+
+			if err := exportSpans(endpoint, serviceName, conf.Tracing.Headers, batch); err != nil {
+				log.Printf("Error: failed to export %d span(s): %s", len(batch), err)
+			}
+			batch = []span{}
+		}
+	}()
+}
+
+// start a trace of given request (nil if tracing is disabled)
+func startTrace(request request) *requestTrace {
+	if spans == nil {
+		return nil
+	}
+
+	attributes := map[string]any{
+		"request.id":  int64(request.id),
+		"request.ref": request.ref,
+		"model":       request.model.String(),
+		"chat.id":     request.targetChatID,
+	}
+	if request.botName != "" {
+		attributes["bot"] = request.botName
+	}
+
+	return &requestTrace{
+		traceID:    randomHex(16),
+		rootSpanID: randomHex(8),
+		start:      request.enqueuedAt,
+		attributes: attributes,
+	}
+}
+
+// record a child span with given name and duration
+func (t *requestTrace) span(name string, start, end time.Time, attributes map[string]any, err error) {
+	if t == nil {
+		return
+	}
+
+	queueSpan(span{
+		traceID:      t.traceID,
+		spanID:       randomHex(8),
+		parentSpanID: t.rootSpanID,
+		name:         name,
+		start:        start,
+		end:          end,
+		attributes:   attributes,
+		err:          err,
+	})
+}
+
+// record the root span, which ends now
+func (t *requestTrace) finish() {
+	if t == nil {
+		return
+	}
+
+	queueSpan(span{
+		traceID:    t.traceID,
+		spanID:     t.rootSpanID,
+		name:       "request",
+		start:      t.start,
+		end:        time.Now(),
+		attributes: t.attributes,
+	})
+}
+
+// queue given span for exporting (dropped if the queue is full)
+func queueSpan(s span) {
+	select {
+	case spans <- s:
+	default:
+		// (dropped, for not blocking requests)
+	}
+}
+
+// export given spans to the endpoint (as `ExportTraceServiceRequest` in JSON)
+func exportSpans(endpoint, serviceName string, headers map[string]string, batch []span) error {
+	encoded := []map[string]any{}
+	for _, s := range batch {
+		attributes := []map[string]any{}
+		for key, value := range s.attributes {
+			switch v := value.(type) {
+			case int64:
+				attributes = append(attributes, map[string]any{"key": key, "value": map[string]any{"intValue": strconv.FormatInt(v, 10)}})
+			default:
+				attributes = append(attributes, map[string]any{"key": key, "value": map[string]any{"stringValue": fmt.Sprint(v)}})
+			}
+		}
+
+		e := map[string]any{
+			"traceId":           s.traceID,
+			"spanId":            s.spanID,
+			"name":              s.name,
+			"kind":              1, // SPAN_KIND_INTERNAL
+			"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+			"attributes":        attributes,
+		}
+		if s.parentSpanID != "" {
+			e["parentSpanId"] = s.parentSpanID
+		}
+		if s.err != nil {
+			e["status"] = map[string]any{"code": 2, "message": s.err.Error()} // STATUS_CODE_ERROR
+		}
+		encoded = append(encoded, e)
+	}
+
+	ver, _, _ := buildMetadata()
+
+	body, err := json.Marshal(map[string]any{
+		"resourceSpans": []map[string]any{
+			{
+				"resource": map[string]any{
+					"attributes": []map[string]any{
+						{"key": "service.name", "value": map[string]any{"stringValue": serviceName}},
+						{"key": "service.version", "value": map[string]any{"stringValue": ver}},
+					},
+				},
+				"scopeSpans": []map[string]any{
+					{
+						"scope": map[string]any{"name": DefaultTracingServiceName},
+						"spans": encoded,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := http.Client{Timeout: TracingExportTimeoutSeconds * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// returns a random hex string of given number of bytes
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// timeout of each text-to-speech command
+const TTSTimeoutSeconds = 120
+
+// default command for converting synthesized audio into OGG/Opus
+var defaultTTSConverterCommand = []string{"ffmpeg", "-y", "-loglevel", "error", "-i", "%i", "-c:a", "libopus", "%o"}
+
+// configs for text-to-speech voice replies
+type ttsConfig struct {
+	Command          []string `json:"command"`                     // command which receives the text on stdin, and writes audio to `%o` (eg. ["piper", "--model", "/path/to/voice.onnx", "--output_file", "%o"])
+	ConverterCommand []string `json:"converter_command,omitempty"` // command which converts audio `%i` into OGG/Opus `%o` (default: ffmpeg)
+	VoiceOnly        bool     `json:"voice_only,omitempty"`        // send only voice replies (without text replies)
+}
+
+// check if voice replies are enabled in given chat
+func voiceEnabled(conf config, chatID int64) bool {
+	if conf.TTS == nil || len(conf.TTS.Command) == 0 {
+		return false
+	}
+
+	voice := getChatSettings(chatID).Voice
+	return voice != nil && *voice
+}
+
+// run given command with `%i`/`%o` placeholders replaced, and given stdin
+func runTTSCommand(ctx context.Context, command []string, input, output string, stdin string) error {
+	ctx, cancel := context.WithTimeout(ctx, TTSTimeoutSeconds*time.Second)
+	defer cancel()
+
+	args := []string{}
+	for _, arg := range command[1:] {
+		arg = strings.ReplaceAll(arg, "%i", input)
+		arg = strings.ReplaceAll(arg, "%o", output)
+		args = append(args, arg)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("'%s' failed: %s (%s)", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// synthesize given text into OGG/Opus audio
+func synthesizeSpeech(ctx context.Context, conf config, text string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "tts-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	synthesized := filepath.Join(dir, "synthesized.wav")
+	converted := filepath.Join(dir, "voice.ogg")
+
+	if err := runTTSCommand(ctx, conf.TTS.Command, "", synthesized, text); err != nil {
+		return nil, err
+	}
+
+	converter := defaultTTSConverterCommand
+	if len(conf.TTS.ConverterCommand) > 0 {
+		converter = conf.TTS.ConverterCommand
+	}
+	if err := runTTSCommand(ctx, converter, synthesized, converted, ""); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(converted)
+}
+
+// synthesize given generated text, and send it as a voice message, returns true if it was sent
+func sendVoiceReply(ctx context.Context, conf config, bot *tg.Bot, request request, generated string) bool {
+	voice, err := synthesizeSpeech(ctx, conf, generated)
+	if err != nil {
+		log.Printf("Error: failed to synthesize speech: %s", err)
+		return false
+	}
+
+	limiter.wait(conf)
+
+	options := tg.OptionsSendVoice{}
+	if request.targetMessageID != 0 {
+		options = options.SetReplyParameters(tg.ReplyParameters{MessageID: request.targetMessageID})
+	}
+	if sent := bot.SendVoice(request.targetChatID, tg.InputFileFromBytes(voice), options); !sent.Ok {
+		limiter.pauseIfNeeded(sent.Parameters)
+
+		log.Printf("Error: failed to send voice: %s", *sent.Description)
+		return false
+	}
+
+	return true
+}
+
+// set (or show) whether to reply with voice in given chat, and generate a message about it
+func voiceMessage(conf config, chatID int64, args string) string {
+	if conf.TTS == nil || len(conf.TTS.Command) == 0 {
+		return "Text-to-speech is not configured."
+	}
+
+	var voice bool
+	switch args {
+	case "":
+		return fmt.Sprintf("Voice replies of this chat: <b>%t</b>", voiceEnabled(conf, chatID))
+	case "on":
+		voice = true
+	case "off":
+		voice = false
+	default:
+		return fmt.Sprintf("Usage: %s [on | off]", CommandVoice)
+	}
+
+	updateChatSettings(chatID, func(settings *chatSettings) {
+		settings.Voice = &voice
+	})
+
+	return fmt.Sprintf("Voice replies of this chat were turned <b>%s</b>.", args)
+}
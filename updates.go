@@ -0,0 +1,132 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// interval of saving the ids of handled updates to the database
+//
+// (not saved on every update, as the whole database file is rewritten on each save)
+const UpdateIDSaveIntervalSeconds = 5
+
+// key of a message being handled
+type updateMessageKey struct {
+	botName   string
+	chatID    int64
+	messageID int64
+}
+
+// updates being handled, for resuming polling from the lowest unanswered one
+//
+// an update is answered when its handler returned and all requests enqueued by it are finished
+// (requests are associated with updates through their messages)
+var updates = struct {
+	sync.Mutex
+
+	handling map[string]map[int64]bool  // ids of updates whose handlers are running, keyed by bot names
+	messages map[updateMessageKey]int64 // ids of updates of messages whose handlers are running
+	latest   map[string]int64           // ids of the latest received updates, keyed by bot names
+}{
+	handling: map[string]map[int64]bool{},
+	messages: map[updateMessageKey]int64{},
+	latest:   map[string]int64{},
+}
+
+// mark given update of given bot as being handled (with its message, if any)
+func beginUpdate(botName string, updateID int64, messageChatID, messageID int64) {
+	updates.Lock()
+	defer updates.Unlock()
+
+	if updates.handling[botName] == nil {
+		updates.handling[botName] = map[int64]bool{}
+	}
+	updates.handling[botName][updateID] = true
+	updates.latest[botName] = max(updates.latest[botName], updateID)
+
+	if messageID != 0 {
+		updates.messages[updateMessageKey{botName, messageChatID, messageID}] = updateID
+	}
+}
+
+// mark given update of given bot as handled (its requests may be still queued or in flight)
+func endUpdate(botName string, updateID int64, messageChatID, messageID int64) {
+	updates.Lock()
+	defer updates.Unlock()
+
+	delete(updates.handling[botName], updateID)
+	if messageID != 0 {
+		delete(updates.messages, updateMessageKey{botName, messageChatID, messageID})
+	}
+}
+
+// returns the message (or the edited message) of given update (nil if none)
+func updateMessage(update tg.Update) *tg.Message {
+	if update.HasMessage() {
+		return update.Message
+	} else if update.HasEditedMessage() {
+		return update.EditedMessage
+	}
+	return nil
+}
+
+// returns the id of the update which is being handled with given message (0 if none)
+func updateIDOfMessage(botName string, chatID, messageID int64) int64 {
+	updates.Lock()
+	defer updates.Unlock()
+
+	return updates.messages[updateMessageKey{botName, chatID, messageID}]
+}
+
+// returns the id of given bot's update, before which all updates were answered (0 if none was received)
+func answeredUpdateID(botName string) int64 {
+	updates.Lock()
+	defer updates.Unlock()
+
+	// (the tracker is read while locked, so that no request is enqueued by an update which is being ended)
+	queued, inFlight := tracker.snapshot()
+
+	var lowest int64
+	for id := range updates.handling[botName] {
+		if lowest == 0 || id < lowest {
+			lowest = id
+		}
+	}
+	for _, r := range append(queued, inFlight...) {
+		if r.botName == botName && r.updateID > 0 && (lowest == 0 || r.updateID < lowest) {
+			lowest = r.updateID
+		}
+	}
+
+	if lowest > 0 {
+		return lowest - 1
+	}
+	return updates.latest[botName]
+}
+
+// save the ids of answered updates of all bots
+func saveAnsweredUpdateIDs() {
+	updates.Lock()
+	botNames := []string{}
+	for botName := range updates.latest {
+		botNames = append(botNames, botName)
+	}
+	updates.Unlock()
+
+	for _, botName := range botNames {
+		if id := answeredUpdateID(botName); id > 0 {
+			saveLastUpdateID(botName, id)
+		}
+	}
+}
+
+// save the ids of answered updates periodically, in background
+func startSavingUpdateIDs() {
+	go func() {
+		for range time.Tick(UpdateIDSaveIntervalSeconds * time.Second) {
+			saveAnsweredUpdateIDs()
+		}
+	}()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestAnsweredUpdateID(t *testing.T) {
+	const botName = "test"
+
+	if id := answeredUpdateID(botName); id != 0 {
+		t.Errorf("no update should be answered yet: %d", id)
+	}
+
+	beginUpdate(botName, 10, 1, 100)
+	beginUpdate(botName, 11, 1, 101)
+
+	// (a request enqueued while handling update 10)
+	if id := updateIDOfMessage(botName, 1, 100); id != 10 {
+		t.Fatalf("unexpected update id of message: %d", id)
+	}
+	r := request{botName: botName, updateID: 10}
+	tracker.add(&r)
+
+	endUpdate(botName, 11, 1, 101)
+	if id := answeredUpdateID(botName); id != 9 {
+		t.Errorf("update 10 is still being handled: %d", id)
+	}
+
+	endUpdate(botName, 10, 1, 100)
+	if id := answeredUpdateID(botName); id != 9 {
+		t.Errorf("request of update 10 is still queued: %d", id)
+	}
+
+	tracker.start(r)
+	if id := answeredUpdateID(botName); id != 9 {
+		t.Errorf("request of update 10 is still in flight: %d", id)
+	}
+
+	tracker.finish(r)
+	if id := answeredUpdateID(botName); id != 11 {
+		t.Errorf("all updates should be answered: %d", id)
+	}
+}
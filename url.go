@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// constants for fetching web pages
+const (
+	URLFetchTimeoutSeconds = 30
+	MaxURLFetchBytes       = 5 * 1024 * 1024
+	MaxURLTextLength       = 100000 // in characters (will be truncated to the context length later)
+)
+
+// default preset for summarizing web pages
+var defaultURLPreset = preset{
+	Template:    "Summarize the following web page:\n\n%t",
+	Placeholder: "%t",
+}
+
+// configs for summarizing web pages (`/url`)
+type urlSummarizationConfig struct {
+	Preset         string   `json:"preset,omitempty"`          // command of the preset for summarizing (eg. "/tldr")
+	AllowedDomains []string `json:"allowed_domains,omitempty"` // if given, only these domains (and their subdomains) can be fetched
+	DeniedDomains  []string `json:"denied_domains,omitempty"`  // these domains (and their subdomains) cannot be fetched
+}
+
+// regular expressions for extracting text from HTML
+var (
+	htmlBoilerplateRegex = regexp.MustCompile(`(?is)<(script|style|noscript|nav|header|footer|aside|form|svg|iframe)\b.*?</(script|style|noscript|nav|header|footer|aside|form|svg|iframe)>`)
+	htmlCommentRegex     = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlMainRegex        = regexp.MustCompile(`(?is)<(article|main)\b[^>]*>(.*)</(article|main)>`)
+	htmlBlockRegex       = regexp.MustCompile(`(?i)</?(p|div|br|li|h[1-6]|tr|section|blockquote|pre)\b[^>]*>`)
+	htmlTagRegex         = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacesRegex          = regexp.MustCompile(`[ \t\r\f\v]+`)
+	newlinesRegex        = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// check if given host matches any of given domains (or their subdomains)
+func matchesDomains(host string, domains []string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range domains {
+		domain = strings.ToLower(domain)
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// check if given url can be fetched with the allowlist and denylist
+func urlAllowed(conf config, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme: '%s'", u.Scheme)
+	}
+
+	host := u.Hostname()
+	if matchesDomains(host, conf.URLSummarization.DeniedDomains) {
+		return fmt.Errorf("denied domain: %s", host)
+	}
+	if len(conf.URLSummarization.AllowedDomains) > 0 && !matchesDomains(host, conf.URLSummarization.AllowedDomains) {
+		return fmt.Errorf("not an allowed domain: %s", host)
+	}
+
+	return nil
+}
+
+// check the address being dialed (after name resolution), and deny non-public ones
+// (loopback, private, link-local, and unspecified addresses), for not fetching internal services
+func denyNonPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid address: %s", host)
+	}
+	if !isPublicAddress(ip) {
+		return fmt.Errorf("non-public address: %s", ip)
+	}
+	return nil
+}
+
+// check if given ip address is a public one
+func isPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap() // (eg. "::ffff:127.0.0.1" => "127.0.0.1")
+
+	return ip.IsGlobalUnicast() &&
+		!ip.IsPrivate() &&
+		!ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// shared address space for carrier-grade NAT (RFC 6598), which is not public either
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// fetch the web page at given url, and extract its main text
+func fetchURLText(conf config, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if err := urlAllowed(conf, u); err != nil {
+		return "", err
+	}
+
+	client := http.Client{
+		Timeout: URLFetchTimeoutSeconds * time.Second,
+		Transport: &http.Transport{
+			Proxy: nil, // (a proxy would dial the addresses instead)
+			DialContext: (&net.Dialer{
+				Timeout: URLFetchTimeoutSeconds * time.Second,
+				Control: denyNonPublicAddress,
+			}).DialContext,
+			TLSHandshakeTimeout: URLFetchTimeoutSeconds * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("too many redirects")
+			}
+			return urlAllowed(conf, req.URL) // (redirected urls should be allowed too, and their addresses are checked when dialed)
+		},
+	}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	bytes, err := io.ReadAll(io.LimitReader(resp.Body, MaxURLFetchBytes))
+	if err != nil {
+		return "", err
+	}
+
+	text := strings.ToValidUTF8(string(bytes), "")
+	if strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		text = extractHTMLText(text)
+	}
+	if len(text) > MaxURLTextLength {
+		text = strings.ToValidUTF8(text[:MaxURLTextLength], "")
+	}
+
+	return text, nil
+}
+
+// extract the main text from given HTML, stripping boilerplates
+//
+// NOTE: it is a simple readability-style extraction, which prefers the content of `<article>` or `<main>`
+func extractHTMLText(doc string) string {
+	doc = htmlCommentRegex.ReplaceAllString(doc, "")
+	doc = htmlBoilerplateRegex.ReplaceAllString(doc, "")
+	if matches := htmlMainRegex.FindStringSubmatch(doc); matches != nil {
+		doc = matches[2]
+	}
+
+	doc = htmlBlockRegex.ReplaceAllString(doc, "\n")
+	doc = htmlTagRegex.ReplaceAllString(doc, "")
+	doc = html.UnescapeString(doc)
+	doc = spacesRegex.ReplaceAllString(doc, " ")
+	doc = newlinesRegex.ReplaceAllString(doc, "\n\n")
+
+	return strings.TrimSpace(doc)
+}
+
+// handle `/url` command: fetch the web page and summarize it
+func handleURLCommand(conf config, bot *tg.Bot, reqQueue *priorityQueue, args string, message tg.Message) {
+	target := args
+	if target == "" && message.HasReplyTo() && message.ReplyToMessage.HasText() {
+		target = *message.ReplyToMessage.Text
+	}
+	target = strings.TrimSpace(target)
+	if target == "" {
+		sendReply(conf, bot, message, fmt.Sprintf("Usage: %s [URL] (or reply to a message with an url)", CommandURL))
+		return
+	}
+	target, _, _ = strings.Cut(target, " ")
+
+	text, err := fetchURLText(conf, target)
+	if err != nil {
+		log.Printf("Error: failed to fetch url '%s': %s", loggable(conf, target), err)
+
+		sendReply(conf, bot, message, fmt.Sprintf("Failed to fetch the url: <em>%s</em>", escapeForHTML(err.Error())))
+		return
+	}
+	if text == "" {
+		sendReply(conf, bot, message, "The web page has no text.")
+		return
+	}
+
+	p := defaultURLPreset
+	if command := conf.URLSummarization.Preset; command != "" {
+		if found, _, exists := presetForCommand(conf, command); exists {
+			p = found
+		} else {
+			log.Printf("Error: no such preset for `/url`: %s", command)
+		}
+	}
+
+	enqueuePresetRequests(conf, bot, reqQueue, p, text, message)
+}
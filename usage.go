@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// max number of usage records to keep for `/stats`
+const MaxUsageRecords = 10000
+
+// max number of users and hours to show in `/stats`
+const (
+	StatsTopUsers = 10
+	StatsTopHours = 3
+)
+
+// a record of a generation, persisted for `/stats`
+type usageRecord struct {
+	At       time.Time `json:"at"`
+	ChatID   int64     `json:"chat_id"`
+	UserID   int64     `json:"user_id,omitempty"`
+	Username string    `json:"username,omitempty"`
+	Model    string    `json:"model"`
+
+	DurationMillis int64 `json:"duration_ms"`
+	Failed         bool  `json:"failed,omitempty"`
+}
+
+// persist a usage record of given request
+func recordUsage(request request, duration time.Duration, failed bool) {
+	record := usageRecord{
+		At:             time.Now(),
+		ChatID:         request.targetChatID,
+		UserID:         request.userID,
+		Model:          request.model.String(),
+		DurationMillis: duration.Milliseconds(),
+		Failed:         failed,
+	}
+	if request.username != nil {
+		record.Username = *request.username
+	}
+
+	if err := db.update(func(data *dbData) {
+		data.Usage = append(data.Usage, record)
+		if len(data.Usage) > MaxUsageRecords {
+			data.Usage = data.Usage[len(data.Usage)-MaxUsageRecords:]
+		}
+	}); err != nil {
+		log.Printf("Error: failed to save usage record: %s", err)
+	}
+}
+
+// parse given period of `/stats` (eg. "24h", "7d", or "all"; 0 for all)
+func parseStatsPeriod(period string) (time.Duration, error) {
+	switch period {
+	case "", "all":
+		return 0, nil
+	}
+
+	if days, found := strings.CutSuffix(period, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid number of days: %s", days)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid period: %s", period)
+	}
+	return duration, nil
+}
+
+// returns the given percentile of sorted durations (in milliseconds)
+func percentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[min(len(sorted)-1, len(sorted)*p/100)]
+}
+
+// usage counts of a user or a model
+type usageCount struct {
+	name      string
+	requests  int
+	failures  int
+	durations []int64 // (of successful ones)
+}
+
+// returns usage counts sorted by the number of requests
+func sortedUsageCounts(counts map[string]*usageCount) (sorted []*usageCount) {
+	for _, c := range counts {
+		sorted = append(sorted, c)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].requests != sorted[j].requests {
+			return sorted[i].requests > sorted[j].requests
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+// generate a message of usage statistics for given period, computed from the persisted usage records
+func usageStatsMessage(args string) string {
+	period, err := parseStatsPeriod(args)
+	if err != nil {
+		return fmt.Sprintf("Usage: %s [PERIOD (eg. 24h, 7d) | all]", CommandStats)
+	}
+
+	var since time.Time
+	if period > 0 {
+		since = time.Now().Add(-period)
+	}
+
+	var records []usageRecord
+	db.read(func(data dbData) {
+		for _, r := range data.Usage {
+			if !r.At.Before(since) {
+				records = append(records, r)
+			}
+		}
+	})
+
+	periodStr := "all time"
+	if period > 0 {
+		periodStr = "last " + args
+	}
+	if len(records) == 0 {
+		return fmt.Sprintf("No requests in %s.", periodStr)
+	}
+
+	users, models := map[string]*usageCount{}, map[string]*usageCount{}
+	hours := make([]int, 24)
+	failures := 0
+	durations := []int64{}
+	for _, r := range records {
+		user := r.Username
+		if user == "" {
+			user = strconv.FormatInt(r.UserID, 10)
+		}
+
+		for _, c := range []struct {
+			counts map[string]*usageCount
+			key    string
+		}{{users, user}, {models, r.Model}} {
+			if _, exists := c.counts[c.key]; !exists {
+				c.counts[c.key] = &usageCount{name: c.key}
+			}
+			count := c.counts[c.key]
+			count.requests++
+			if r.Failed {
+				count.failures++
+			} else {
+				count.durations = append(count.durations, r.DurationMillis)
+			}
+		}
+
+		hours[r.At.Local().Hour()]++
+		if r.Failed {
+			failures++
+		} else {
+			durations = append(durations, r.DurationMillis)
+		}
+	}
+
+	latencies := func(durations []int64) string {
+		if len(durations) == 0 {
+			return "-"
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+		var total int64
+		for _, d := range durations {
+			total += d
+		}
+
+		return fmt.Sprintf("avg %ss, p50 %ss, p90 %ss, p99 %ss",
+			msecsToString(total/int64(len(durations))),
+			msecsToString(percentile(durations, 50)),
+			msecsToString(percentile(durations, 90)),
+			msecsToString(percentile(durations, 99)))
+	}
+
+	lines := []string{
+		fmt.Sprintf("<b>Requests</b> (%s): %d, %d failed", periodStr, len(records), failures),
+		fmt.Sprintf("<b>Latencies</b>: %s", latencies(durations)),
+		"",
+		"<b>Models</b>:",
+	}
+	for _, c := range sortedUsageCounts(models) {
+		lines = append(lines, fmt.Sprintf("- %s: %d request(s), %d failed (%s)", escapeForHTML(c.name), c.requests, c.failures, latencies(c.durations)))
+	}
+
+	lines = append(lines, "", "<b>Users</b>:")
+	for i, c := range sortedUsageCounts(users) {
+		if i >= StatsTopUsers {
+			lines = append(lines, fmt.Sprintf("- ... and %d more", len(users)-StatsTopUsers))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %d request(s), %d failed", escapeForHTML(c.name), c.requests, c.failures))
+	}
+
+	busiest := []int{}
+	for hour := range hours {
+		if hours[hour] > 0 {
+			busiest = append(busiest, hour)
+		}
+	}
+	sort.SliceStable(busiest, func(i, j int) bool { return hours[busiest[i]] > hours[busiest[j]] })
+	lines = append(lines, "", "<b>Busiest hours</b>:")
+	for i, hour := range busiest {
+		if i >= StatsTopHours {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("- %02d:00-%02d:59: %d request(s)", hour, hour, hours[hour]))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// validate given model, and return found problems
+func validateModel(model model) (problems []string) {
+	if model.isImageGenerator() {
+		if info, err := os.Stat(*model.ImageGeneratorPath); err != nil {
+			problems = append(problems, fmt.Sprintf("`image_generator_path` is not accessible: %s", err))
+		} else if !isExecutable(info) {
+			problems = append(problems, fmt.Sprintf("`image_generator_path` is not executable: %s", *model.ImageGeneratorPath))
+		}
+		if !strings.Contains(strings.Join(model.ImageGeneratorParameters, " "), "%o") {
+			problems = append(problems, "placeholder '%o' (for the output file) does not appear in `image_generator_parameters`")
+		}
+
+		return append(problems, validateEnv(model)...)
+	}
+
+	if model.APIBaseURL != nil || model.ModelName != nil {
+		if model.APIBaseURL == nil {
+			problems = append(problems, "`api_base_url` is missing")
+		} else if u, err := url.Parse(*model.APIBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			problems = append(problems, fmt.Sprintf("`api_base_url` is not a valid url: %s", *model.APIBaseURL))
+		}
+		if model.ModelName == nil {
+			problems = append(problems, "`model_name` is missing")
+		}
+
+		return problems
+	}
+
+	if model.OllamaModel != nil || model.OllamaBaseURL != nil {
+		if model.OllamaModel == nil {
+			problems = append(problems, "`ollama_model` is missing")
+		}
+		if model.OllamaBaseURL != nil {
+			if u, err := url.Parse(*model.OllamaBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				problems = append(problems, fmt.Sprintf("`ollama_base_url` is not a valid url: %s", *model.OllamaBaseURL))
+			}
+		}
+
+		return problems
+	}
+
+	if model.LlamafilePath == nil {
+		return append(problems, "`llamafile_path` is missing")
+	}
+
+	if info, err := os.Stat(*model.LlamafilePath); err != nil {
+		problems = append(problems, fmt.Sprintf("`llamafile_path` is not accessible: %s", err))
+	} else if info.IsDir() {
+		problems = append(problems, fmt.Sprintf("`llamafile_path` is a directory: %s", *model.LlamafilePath))
+	} else if !isExecutable(info) {
+		problems = append(problems, fmt.Sprintf("`llamafile_path` is not executable: %s", *model.LlamafilePath))
+	}
+
+	if model.LlamafilePromptPattern == nil {
+		return append(problems, "`llamafile_prompt_pattern` is missing (and no chat template was found in GGUF metadata)")
+	}
+
+	pattern := *model.LlamafilePromptPattern
+	if model.LlamafilePromptPlaceholder != nil && !strings.Contains(pattern, *model.LlamafilePromptPlaceholder) {
+		problems = append(problems, fmt.Sprintf("placeholder '%s' does not appear in `llamafile_prompt_pattern`", *model.LlamafilePromptPlaceholder))
+	}
+	if model.LlamafileOriginalPlaceholder != nil && !strings.Contains(pattern, *model.LlamafileOriginalPlaceholder) {
+		problems = append(problems, fmt.Sprintf("original placeholder '%s' does not appear in `llamafile_prompt_pattern`", *model.LlamafileOriginalPlaceholder))
+	}
+	if model.LlamafileCommentPlaceholder != nil && !strings.Contains(pattern, *model.LlamafileCommentPlaceholder) {
+		problems = append(problems, fmt.Sprintf("comment placeholder '%s' does not appear in `llamafile_prompt_pattern`", *model.LlamafileCommentPlaceholder))
+	}
+	if model.LlamafilePromptPlaceholder == nil && model.LlamafileOriginalPlaceholder == nil && model.LlamafileCommentPlaceholder == nil {
+		if _, err := template.New("prompt").Parse(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("`llamafile_prompt_pattern` is not a valid template: %s", err))
+		}
+	}
+
+	if model.LlamafileServerPort != nil && (*model.LlamafileServerPort <= 0 || *model.LlamafileServerPort > 65535) {
+		problems = append(problems, fmt.Sprintf("`llamafile_server_port` is not a valid port: %d", *model.LlamafileServerPort))
+	}
+
+	if model.GrammarFile != nil {
+		if _, err := os.Stat(*model.GrammarFile); err != nil {
+			problems = append(problems, fmt.Sprintf("`grammar_file` is not accessible: %s", err))
+		}
+	}
+
+	if limits := model.ResourceLimits; limits != nil && (limits.MemoryMB < 0 || limits.CPUSeconds < 0 || limits.FileSizeMB < 0) {
+		problems = append(problems, "`resource_limits` has a negative value")
+	}
+	problems = append(problems, validateScheduling(model.Scheduling)...)
+	problems = append(problems, validateEnv(model)...)
+	problems = append(problems, validateGPU(model)...)
+	problems = append(problems, validateTuning(model)...)
+	problems = append(problems, validateSandbox(model)...)
+	if model.Watchdog != nil && model.Watchdog.StallSeconds <= 0 {
+		problems = append(problems, fmt.Sprintf("`watchdog.stall_seconds` is not positive: %d", model.Watchdog.StallSeconds))
+	}
+
+	return problems
+}
+
+// validate enabled models in given config, and print a summary of them
+//
+// if `disable_invalid_models` is true, invalid models will be disabled,
+// otherwise it will return an error
+func validateModels(conf *config) error {
+	invalid := 0
+
+	for i, model := range conf.Models {
+		if model.Disabled {
+			log.Printf(">>> model #%d (%s): disabled", i, model)
+			continue
+		}
+
+		if problems := validateModel(model); len(problems) > 0 {
+			invalid++
+
+			log.Printf(">>> model #%d (%s): invalid\n  - %s", i, model, strings.Join(problems, "\n  - "))
+
+			if conf.DisableInvalidModels {
+				log.Printf(">>> disabling invalid model #%d", i)
+
+				conf.Models[i].Disabled = true
+			}
+		} else {
+			log.Printf(">>> model #%d (%s): ok", i, model)
+		}
+	}
+
+	if invalid > 0 && !conf.DisableInvalidModels {
+		return fmt.Errorf("%d model(s) are misconfigured", invalid)
+	}
+
+	return nil
+}
+
+// validate given config as a whole (besides each model), and return found problems
+func validateConfig(conf config) (problems []string) {
+	tokenSources := 0
+	for _, set := range []bool{conf.TelegramBotToken != "", conf.TelegramBotTokenFile != "", len(conf.TelegramBotTokenCommand) > 0} {
+		if set {
+			tokenSources++
+		}
+	}
+	if tokenSources == 0 {
+		problems = append(problems, "`telegram_bot_token` (or `telegram_bot_token_file`, `telegram_bot_token_command`) is missing")
+	} else if tokenSources > 1 {
+		problems = append(problems, "only one of `telegram_bot_token`, `telegram_bot_token_file`, and `telegram_bot_token_command` should be set")
+	}
+	if conf.TelegramBotTokenFile != "" {
+		if _, err := os.Stat(conf.telegramBotTokenFilePath()); err != nil {
+			problems = append(problems, fmt.Sprintf("`telegram_bot_token_file` is not accessible: %s", err))
+		}
+	}
+	if len(conf.Models) == 0 {
+		problems = append(problems, "`models` is empty")
+	}
+	problems = append(problems, validateBots(conf)...)
+	if conf.TelegramAPIBaseURL != "" {
+		if _, err := parseTelegramAPIBaseURL(conf.TelegramAPIBaseURL); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if conf.TelegramProxy != "" {
+		if _, err := parseTelegramProxy(conf.TelegramProxy); err != nil {
+			problems = append(problems, fmt.Sprintf("`telegram_proxy` is invalid: %s", err))
+		}
+	}
+	if conf.TelegramTimeoutSeconds < 0 {
+		problems = append(problems, "`telegram_timeout_seconds` should not be negative")
+	}
+	problems = append(problems, validateSchedules(conf)...)
+	if err := validateTools(conf.Tools); err != nil {
+		problems = append(problems, err.Error())
+	}
+	problems = append(problems, validateDigest(conf)...)
+	if conf.Pprof != nil {
+		if err := validatePprofListenAddress(pprofListenAddress(conf)); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	problems = append(problems, validateGRPC(conf)...)
+	if conf.Health != nil {
+		if err := validateHealthListenAddress(healthListenAddress(conf)); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	problems = append(problems, validateListenAddresses(conf)...)
+	if !slices.Contains([]string{"", LogOutputStderr, LogOutputSyslog, LogOutputJournald}, conf.LogOutput) {
+		problems = append(problems, fmt.Sprintf("`log_output` is not one of %s, %s, and %s: %s", LogOutputStderr, LogOutputSyslog, LogOutputJournald, conf.LogOutput))
+	}
+	for chatID, names := range conf.ChatModels {
+		for _, name := range names {
+			if !isModelReference(conf, name) {
+				problems = append(problems, fmt.Sprintf("`chat_models` of chat %d has an unknown model: '%s'", chatID, name))
+			}
+		}
+	}
+
+	// usernames
+	lists := []struct {
+		key       string
+		usernames []string
+	}{
+		{"`allowed_telegram_usernames`", conf.AllowedTelegramUsernames},
+		{"`admin_telegram_usernames`", conf.AdminTelegramUsernames},
+		{"`priority_telegram_usernames`", conf.PriorityTelegramUsernames},
+	}
+	for i, model := range conf.Models {
+		if len(model.AllowedUsernames) > 0 {
+			lists = append(lists, struct {
+				key       string
+				usernames []string
+			}{fmt.Sprintf("`allowed_usernames` of model #%d (%s)", i, model), model.AllowedUsernames})
+		}
+	}
+	for _, list := range lists {
+		seen := map[string]bool{}
+		for _, username := range list.usernames {
+			if username == "" {
+				problems = append(problems, fmt.Sprintf("%s has an empty username", list.key))
+			} else if strings.HasPrefix(username, "@") {
+				problems = append(problems, fmt.Sprintf("%s has a username with leading '@' (which never matches): %s", list.key, username))
+			} else if seen[username] {
+				problems = append(problems, fmt.Sprintf("%s has a duplicate username: %s", list.key, username))
+			}
+			seen[username] = true
+		}
+	}
+	if len(conf.AllowedTelegramUsernames) > 0 {
+		usernames := append(append([]string{}, conf.AdminTelegramUsernames...), conf.PriorityTelegramUsernames...)
+		for _, model := range conf.Models {
+			usernames = append(usernames, model.AllowedUsernames...)
+		}
+		reported := map[string]bool{}
+		for _, username := range usernames {
+			if !slices.Contains(conf.AllowedTelegramUsernames, username) && !reported[username] {
+				reported[username] = true
+				problems = append(problems, fmt.Sprintf("user '%s' is not in `allowed_telegram_usernames`, so they cannot use the bot at all", username))
+			}
+		}
+	}
+
+	// duplicates
+	names := map[string]int{}
+	for i, model := range conf.Models {
+		if model.Pool == nil { // (models in a pool may be the same)
+			if j, exists := names[model.String()]; exists {
+				problems = append(problems, fmt.Sprintf("models #%d and #%d have the same name: %s", j, i, model))
+			}
+			names[model.String()] = i
+		}
+	}
+	aliases := map[string]int{}
+	for i, model := range conf.Models {
+		if model.Name != nil && strings.TrimSpace(*model.Name) == "" {
+			problems = append(problems, fmt.Sprintf("`name` of model #%d is empty", i))
+		}
+		for _, alias := range model.Aliases {
+			if _, err := strconv.Atoi(alias); err == nil || alias == "" {
+				problems = append(problems, fmt.Sprintf("model #%d (%s) has an invalid alias (empty or numeric, which is confused with indices): '%s'", i, model, alias))
+			} else if j, exists := aliases[alias]; exists && j != i {
+				problems = append(problems, fmt.Sprintf("models #%d and #%d have the same alias: %s", j, i, alias))
+			} else if j, exists := names[alias]; exists && j != i {
+				problems = append(problems, fmt.Sprintf("alias of model #%d is the same as the name of model #%d: %s", i, j, alias))
+			}
+			aliases[alias] = i
+		}
+	}
+	commands := map[string]bool{}
+	for _, preset := range conf.Presets {
+		if !strings.HasPrefix(preset.Command, "/") {
+			problems = append(problems, fmt.Sprintf("command of preset does not start with '/': %s", preset.Command))
+		}
+		if commands[preset.Command] {
+			problems = append(problems, fmt.Sprintf("duplicate command of presets: %s", preset.Command))
+		}
+		commands[preset.Command] = true
+
+		if preset.Placeholder != "" && !strings.Contains(preset.Template, preset.Placeholder) {
+			problems = append(problems, fmt.Sprintf("placeholder '%s' does not appear in `template` of preset %s", preset.Placeholder, preset.Command))
+		}
+	}
+
+	// indices of models
+	checkIndex := func(key string, index *int) {
+		if index != nil && (*index < 0 || *index >= len(conf.Models)) {
+			problems = append(problems, fmt.Sprintf("`%s` is out of range: %d", key, *index))
+		}
+	}
+	checkIndex("summarization_model_index", conf.SummarizationModelIndex)
+	for _, preset := range conf.Presets {
+		checkIndex(fmt.Sprintf("presets[%s].model_index", preset.Command), preset.ModelIndex)
+	}
+	if conf.RAG != nil {
+		checkIndex("rag.embedding_model_index", &conf.RAG.EmbeddingModelIndex)
+	}
+	if conf.SemanticCache != nil {
+		checkIndex("semantic_cache.embedding_model_index", &conf.SemanticCache.EmbeddingModelIndex)
+	}
+	if conf.InjectionGuard != nil {
+		checkIndex("injection_guard.classifier_model_index", conf.InjectionGuard.ClassifierModelIndex)
+	}
+	if conf.Router != nil {
+		checkIndex("router.classifier_model_index", conf.Router.ClassifierModelIndex)
+		checkIndex("router.default_model_index", conf.Router.DefaultModelIndex)
+		for _, r := range conf.Router.Routes {
+			checkIndex(fmt.Sprintf("router.routes[%s].model_index", r.Name), &r.ModelIndex)
+		}
+	}
+
+	// others
+	if err := compileContentFilter(conf); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if conf.Quota != nil {
+		if conf.Quota.Period != "" && conf.Quota.Period != QuotaPeriodDaily && conf.Quota.Period != QuotaPeriodWeekly {
+			problems = append(problems, fmt.Sprintf("`quota.period` is not valid: %s", conf.Quota.Period))
+		}
+		if conf.Quota.ResetHour < 0 || conf.Quota.ResetHour > 23 {
+			problems = append(problems, fmt.Sprintf("`quota.reset_hour` is out of range: %d", conf.Quota.ResetHour))
+		}
+	}
+	if conf.Dashboard != nil && (conf.Dashboard.Username == "" || conf.Dashboard.Password == "") {
+		problems = append(problems, "`dashboard.username` and `dashboard.password` are required")
+	}
+
+	return problems
+}
+
+// check if given name is an index, name, or pool of a model in config
+func isModelReference(conf config, name string) bool {
+	if name == "" {
+		return false
+	}
+	if _, found := modelByIndexOrName(conf, name); found {
+		return true
+	}
+	for _, m := range conf.Models {
+		if m.Pool != nil && *m.Pool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// a local address which the bot listens on
+type listenAddress struct {
+	key  string // eg. "`api`"
+	addr string // eg. "127.0.0.1:8081"
+}
+
+// returns the addresses which the bot (and llamafiles in server mode) will listen on with given config
+func listenAddresses(conf config) (addrs []listenAddress) {
+	if conf.Dashboard != nil {
+		addrs = append(addrs, listenAddress{"`dashboard`", dashboardListenAddress(conf)})
+	}
+	if conf.API != nil {
+		addrs = append(addrs, listenAddress{"`api`", apiListenAddress(conf)})
+	}
+	if conf.GRPC != nil {
+		addrs = append(addrs, listenAddress{"`grpc`", grpcListenAddress(conf)})
+	}
+	if conf.Pprof != nil {
+		addrs = append(addrs, listenAddress{"`pprof`", pprofListenAddress(conf)})
+	}
+	if conf.Health != nil {
+		addrs = append(addrs, listenAddress{"`health`", healthListenAddress(conf)})
+	}
+	for i, model := range conf.Models {
+		if model.LlamafileServerPort != nil {
+			addrs = append(addrs, listenAddress{fmt.Sprintf("`llamafile_server_port` of model #%d", i), fmt.Sprintf("127.0.0.1:%d", *model.LlamafileServerPort)})
+		}
+	}
+
+	return addrs
+}
+
+// check if the listen addresses in given config collide with each other, and return found problems
+//
+// (addresses with the same port collide when their hosts are the same, or either of them is a wildcard, eg. ":8081")
+func validateListenAddresses(conf config) (problems []string) {
+	addrs := listenAddresses(conf)
+	for i, a := range addrs {
+		for _, b := range addrs[i+1:] {
+			if listenAddressesCollide(a.addr, b.addr) {
+				problems = append(problems, fmt.Sprintf("%s and %s have the same listen address: %s, %s", a.key, b.key, a.addr, b.addr))
+			}
+		}
+	}
+	return problems
+}
+
+// check if given listen addresses collide
+func listenAddressesCollide(a, b string) bool {
+	hostA, portA, errA := net.SplitHostPort(a)
+	hostB, portB, errB := net.SplitHostPort(b)
+	if errA != nil || errB != nil { // (invalid ones are reported elsewhere)
+		return false
+	}
+	if portA != portB {
+		return false
+	}
+
+	wildcard := func(host string) bool {
+		return host == "" || host == "0.0.0.0" || host == "::"
+	}
+	return hostA == hostB || wildcard(hostA) || wildcard(hostB)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestListenAddressesCollide(t *testing.T) {
+	for _, test := range []struct {
+		a, b    string
+		collide bool
+	}{
+		{"127.0.0.1:8081", "127.0.0.1:8081", true},
+		{"127.0.0.1:8081", "127.0.0.1:8082", false},
+		{":8081", "127.0.0.1:8081", true},
+		{"0.0.0.0:8081", "192.168.0.2:8081", true},
+		{"127.0.0.1:8081", "192.168.0.2:8081", false},
+		{"invalid", "invalid", false},
+	} {
+		if collide := listenAddressesCollide(test.a, test.b); collide != test.collide {
+			t.Errorf("'%s' and '%s' should collide: %t", test.a, test.b, test.collide)
+		}
+	}
+}
+
+func TestValidateListenAddresses(t *testing.T) {
+	port := 8081
+	conf := config{
+		Models: []model{{LlamafileServerPort: &port}},
+		API:    &apiConfig{},
+		GRPC:   &grpcConfig{},
+		Pprof:  &pprofConfig{},
+		Health: &healthConfig{},
+	}
+	if problems := validateListenAddresses(conf); len(problems) != 1 {
+		t.Errorf("only `api` and `llamafile_server_port` should collide with defaults: %v", problems)
+	}
+
+	conf.API.ListenAddress = "127.0.0.1:8083"
+	if problems := validateListenAddresses(conf); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+
+	conf.Health.ListenAddress = ":50051"
+	if problems := validateListenAddresses(conf); len(problems) != 1 {
+		t.Errorf("`health` should collide with `grpc`: %v", problems)
+	}
+}
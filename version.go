@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// build metadata, which can be embedded with ldflags, eg.
+//
+//	$ go build -ldflags "-X main.version=v1.2.3 -X main.commit=$(git rev-parse --short HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// (if omitted, they are filled in from the build info of go)
+var (
+	version   string
+	commit    string
+	buildDate string
+)
+
+// returns the version, commit, and build date of this binary
+func buildMetadata() (ver, rev, date string) {
+	ver, rev, date = version, commit, buildDate
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if ver == "" && info.Main.Version != "" {
+			ver = info.Main.Version
+		}
+
+		var revision, modified string
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				revision = setting.Value
+			case "vcs.modified":
+				if setting.Value == "true" {
+					modified = "-dirty"
+				}
+			case "vcs.time":
+				if date == "" {
+					date = setting.Value
+				}
+			}
+		}
+		if rev == "" && revision != "" {
+			rev = revision + modified
+		}
+	}
+
+	if ver == "" {
+		ver = "(devel)"
+	}
+	if rev == "" {
+		rev = "unknown"
+	}
+	if date == "" {
+		date = "unknown"
+	}
+
+	return ver, rev, date
+}
+
+// returns a one-line description of the version
+func versionString() string {
+	ver, rev, date := buildMetadata()
+
+	return fmt.Sprintf("telegram-llamafiles-bot %s (commit: %s, built: %s, %s %s/%s)", ver, rev, date, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// generate a message about the versions of this bot and llamafiles of enabled models
+func versionMessage(conf config) string {
+	ver, rev, date := buildMetadata()
+
+	lines := []string{
+		fmt.Sprintf("<b>Version</b>: %s", escapeForHTML(ver)),
+		fmt.Sprintf("<b>Commit</b>: %s", escapeForHTML(rev)),
+		fmt.Sprintf("<b>Built</b>: %s", escapeForHTML(date)),
+		fmt.Sprintf("<b>Go</b>: %s (%s/%s)", runtime.Version(), runtime.GOOS, runtime.GOARCH),
+	}
+
+	llamafiles := []string{}
+	for _, model := range conf.Models {
+		if model.isDisabled() || !model.isLlamafile() {
+			continue
+		}
+
+		llamafileVersion := "unknown"
+		if l, err := launcherFor(*model.LlamafilePath); err == nil && l.version != "" {
+			llamafileVersion = l.version
+		}
+		llamafiles = append(llamafiles, fmt.Sprintf("- %s: %s", escapeForHTML(model.String()), escapeForHTML(llamafileVersion)))
+	}
+	if len(llamafiles) > 0 {
+		lines = append(lines, "", "<b>Llamafiles</b>:")
+		lines = append(lines, llamafiles...)
+	}
+
+	return strings.Join(lines, "\n")
+}
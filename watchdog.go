@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// watchdog of llamafile generations, which kills (and retries once) a stuck one:
+// no output and no CPU usage for a while (eg. hung mmap, or GPU deadlock)
+//
+// NOTE: CPU usage is read from /proc, so it works only on Linux
+type watchdogConfig struct {
+	StallSeconds int  `json:"stall_seconds"`      // seconds without output and CPU usage, before killing the generation
+	NoRetry      bool `json:"no_retry,omitempty"` // fail without retrying once
+}
+
+// max interval of watchdog checks
+const WatchdogIntervalSeconds = 5
+
+// min CPU usage (relative to the elapsed time) of a process which is not stuck
+const WatchdogMinCPUUsage = 0.01
+
+// clock ticks per second of CPU times in /proc (`USER_HZ`)
+const ClockTicksPerSecond = 100
+
+var warnUnsupportedWatchdog sync.Once
+
+// writer which records the time of its last write
+type progressWriter struct {
+	buf       bytes.Buffer
+	lastWrite atomic.Int64 // (unix nanoseconds)
+}
+
+// write given bytes, and record the time
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.lastWrite.Store(time.Now().UnixNano())
+	return w.buf.Write(p)
+}
+
+// run given command with given watchdog, and return whether it was killed for being stuck
+//
+// (given stdout should be the one of the command)
+func runWatched(cmd *exec.Cmd, watchdog *watchdogConfig, stdout *progressWriter) (stalled bool, err error) {
+	if watchdog == nil || watchdog.StallSeconds <= 0 {
+		return false, cmd.Run()
+	}
+
+	cmd.WaitDelay = WatchdogIntervalSeconds * time.Second // (for not waiting for pipes held by orphaned descendants)
+	if err := cmd.Start(); err != nil {
+		return false, err
+	}
+	pid := cmd.Process.Pid
+	stdout.lastWrite.Store(time.Now().UnixNano())
+
+	var killed atomic.Bool
+	done := make(chan struct{})
+	go func() {
+		stall := time.Duration(watchdog.StallSeconds) * time.Second
+		interval := min(stall/2, WatchdogIntervalSeconds*time.Second)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		lastTicks, measurable := processTreeCPUTicks(pid)
+		if !measurable {
+			warnUnsupportedWatchdog.Do(func() {
+				log.Printf("Error: watchdog cannot read CPU usage of processes (only on Linux), so stuck generations are not detected")
+			})
+			return
+		}
+		lastActive := time.Now()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				ticks, _ := processTreeCPUTicks(pid)
+				if float64(ticks-lastTicks)/ClockTicksPerSecond >= interval.Seconds()*WatchdogMinCPUUsage {
+					lastActive = now
+				}
+				lastTicks = ticks
+
+				if lastWrite := time.Unix(0, stdout.lastWrite.Load()); lastWrite.After(lastActive) {
+					lastActive = lastWrite
+				}
+
+				if now.Sub(lastActive) >= stall {
+					killed.Store(true)
+					killProcessTree(pid)
+					return
+				}
+			}
+		}
+	}()
+
+	err = cmd.Wait()
+	close(done)
+
+	return killed.Load(), err
+}
+
+// returns the sum of CPU times (in clock ticks) of given process and its descendants
+func processTreeCPUTicks(pid int) (int64, bool) {
+	ticks, ok := processCPUTicks(pid)
+	if !ok {
+		return 0, false
+	}
+
+	for _, child := range childProcesses(pid) {
+		if t, ok := processTreeCPUTicks(child); ok {
+			ticks += t
+		}
+	}
+
+	return ticks, true
+}
+
+// kill given process and its descendants (from the leaves)
+func killProcessTree(pid int) {
+	for _, child := range childProcesses(pid) {
+		killProcessTree(child)
+	}
+
+	if p, err := os.FindProcess(pid); err == nil {
+		_ = p.Kill()
+	}
+}
+
+// returns the CPU time (user + system, in clock ticks) of given process, from /proc/[pid]/stat
+func processCPUTicks(pid int) (int64, bool) {
+	bytes, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, false
+	}
+
+	// (fields after the command name in parentheses, which may contain spaces)
+	stat := string(bytes)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	if len(fields) < 13 {
+		return 0, false
+	}
+	utime, err1 := strconv.ParseInt(fields[11], 10, 64)
+	stime, err2 := strconv.ParseInt(fields[12], 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+
+	return utime + stime, true
+}
+
+// returns child processes of given process, from /proc/[pid]/task/[tid]/children
+func childProcesses(pid int) (children []int) {
+	tasks, err := os.ReadDir(fmt.Sprintf("/proc/%d/task", pid))
+	if err != nil {
+		return nil
+	}
+
+	for _, task := range tasks {
+		bytes, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%s/children", pid, task.Name()))
+		if err != nil {
+			continue
+		}
+		for _, field := range strings.Fields(string(bytes)) {
+			if child, err := strconv.Atoi(field); err == nil {
+				children = append(children, child)
+			}
+		}
+	}
+
+	return children
+}
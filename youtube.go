@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/meinside/telegram-bot-go"
+)
+
+// constants for YouTube transcripts
+const (
+	YouTubeFetchTimeoutSeconds = 30
+	MaxYouTubeFetchBytes       = 10 * 1024 * 1024
+
+	youTubeParagraphSeconds = 60 // transcript lines are grouped into paragraphs of this duration
+)
+
+// prompts for summarizing YouTube transcripts
+const (
+	youTubeChunkPrompt   = "Summarize the following part of a video transcript, keeping the [mm:ss] timestamps of key points:"
+	youTubeSummaryPrompt = "Summarize the following video transcript, and list its key points with their [mm:ss] timestamps:"
+)
+
+// configs for summarizing transcripts of YouTube videos
+type youTubeSummarizationConfig struct {
+	AutoDetect bool     `json:"auto_detect,omitempty"` // summarize YouTube links in messages automatically (without `/yt`)
+	ModelIndex *int     `json:"model_index,omitempty"` // index of the model in `models` for summarizing transcripts (if omitted, models of the chat will be used)
+	Languages  []string `json:"languages,omitempty"`   // preferred languages of captions (eg. ["en", "ko"])
+	ChunkSize  int      `json:"chunk_size,omitempty"`  // size of each chunk in characters
+}
+
+// regular expression for YouTube video urls
+var youTubeURLRegex = regexp.MustCompile(`(?:https?://)?(?:www\.|m\.)?(?:youtube\.com/(?:watch\?(?:\S*&)?v=|shorts/|live/)|youtu\.be/)([A-Za-z0-9_-]{11})`)
+
+// returns the id of the first YouTube video url in given text
+func youTubeVideoID(text string) (string, bool) {
+	if matches := youTubeURLRegex.FindStringSubmatch(text); matches != nil {
+		return matches[1], true
+	}
+	return "", false
+}
+
+// caption track in YouTube's watch page
+type youTubeCaptionTrack struct {
+	BaseURL      string `json:"baseUrl"`
+	LanguageCode string `json:"languageCode"`
+	Kind         string `json:"kind"` // "asr" for auto-generated ones
+}
+
+// transcript xml of YouTube captions
+type youTubeTranscript struct {
+	Texts []struct {
+		Start string `xml:"start,attr"`
+		Text  string `xml:",chardata"`
+	} `xml:"text"`
+}
+
+// fetch given url with a timeout and a size limit
+func fetchYouTube(url string) (string, error) {
+	client := http.Client{Timeout: YouTubeFetchTimeoutSeconds * time.Second}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	bytes, err := io.ReadAll(io.LimitReader(resp.Body, MaxYouTubeFetchBytes))
+	return string(bytes), err
+}
+
+// fetch the transcript of given YouTube video, as paragraphs with [mm:ss] timestamps
+func fetchYouTubeTranscript(conf config, videoID string) (string, error) {
+	page, err := fetchYouTube("https://www.youtube.com/watch?v=" + videoID)
+	if err != nil {
+		return "", err
+	}
+
+	// find caption tracks in the player response
+	_, tracksJSON, found := strings.Cut(page, `"captionTracks":`)
+	if !found {
+		return "", fmt.Errorf("no captions available")
+	}
+	var tracks []youTubeCaptionTrack
+	if err := json.NewDecoder(strings.NewReader(tracksJSON)).Decode(&tracks); err != nil {
+		return "", fmt.Errorf("failed to parse caption tracks: %s", err)
+	}
+	if len(tracks) == 0 {
+		return "", fmt.Errorf("no captions available")
+	}
+
+	// select a caption track in the preferred languages (manual ones first)
+	track := tracks[0]
+selection:
+	for _, language := range conf.YouTubeSummarization.Languages {
+		for _, manual := range []bool{true, false} {
+			for _, t := range tracks {
+				if t.LanguageCode == language && (t.Kind != "asr") == manual {
+					track = t
+					break selection
+				}
+			}
+		}
+	}
+
+	transcriptXML, err := fetchYouTube(track.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	var transcript youTubeTranscript
+	if err := xml.Unmarshal([]byte(transcriptXML), &transcript); err != nil {
+		return "", fmt.Errorf("failed to parse transcript: %s", err)
+	}
+
+	// group lines into paragraphs with timestamps
+	var sb strings.Builder
+	paragraphStart := -youTubeParagraphSeconds
+	for _, t := range transcript.Texts {
+		start, _ := strconv.ParseFloat(t.Start, 64)
+		text := strings.Join(strings.Fields(html.UnescapeString(t.Text)), " ")
+		if text == "" {
+			continue
+		}
+
+		if int(start)-paragraphStart >= youTubeParagraphSeconds {
+			if sb.Len() > 0 {
+				sb.WriteString("\n\n")
+			}
+			paragraphStart = int(start)
+			fmt.Fprintf(&sb, "[%02d:%02d]", paragraphStart/60, paragraphStart%60)
+		}
+		sb.WriteString(" " + text)
+	}
+
+	return sb.String(), nil
+}
+
+// handle a YouTube video url: fetch its transcript, and enqueue requests for summarizing it
+func handleYouTubeURL(conf config, bot *tg.Bot, reqQueue *priorityQueue, videoID string, message tg.Message) {
+	c := conf.YouTubeSummarization
+
+	transcript, err := fetchYouTubeTranscript(conf, videoID)
+	if err != nil {
+		log.Printf("Error: failed to fetch transcript of YouTube video '%s': %s", videoID, err)
+
+		sendReply(conf, bot, message, fmt.Sprintf("Failed to fetch the transcript: <em>%s</em>", escapeForHTML(err.Error())))
+		return
+	}
+
+	chunkSize := DefaultDocumentChunkSize
+	if c.ChunkSize > 0 {
+		chunkSize = c.ChunkSize
+	}
+	chunks := chunkText(transcript, chunkSize)
+	if len(chunks) == 0 {
+		sendReply(conf, bot, message, "The transcript is empty.")
+		return
+	}
+
+	log.Printf(">>> summarizing transcript of YouTube video '%s' in %d chunk(s)", videoID, len(chunks))
+
+	models := modelsForChat(conf, message.Chat.ID)
+	if c.ModelIndex != nil && *c.ModelIndex >= 0 && *c.ModelIndex < len(conf.Models) {
+		models = []model{conf.Models[*c.ModelIndex]}
+	}
+
+	// (the instruction for timestamps is passed as the comment, so it is kept in the final summary)
+	originalText := escapeForShell(chunks[0])
+	commentText := youTubeSummaryPrompt
+	extra := &requestExtra{
+		documentChunks: chunks,
+		chunkPrompt:    youTubeChunkPrompt,
+	}
+
+	group := newRequestGroup(conf, len(models))
+	for _, model := range models {
+		enqueueRequest(conf, bot, reqQueue, model, &originalText, &commentText, generationOptions{}, group, extra, message)
+	}
+}
+
+// handle `/yt` command
+func handleYouTubeCommand(conf config, bot *tg.Bot, reqQueue *priorityQueue, args string, message tg.Message) {
+	target := args
+	if target == "" && message.HasReplyTo() && message.ReplyToMessage.HasText() {
+		target = *message.ReplyToMessage.Text
+	}
+
+	videoID, found := youTubeVideoID(target)
+	if !found {
+		sendReply(conf, bot, message, fmt.Sprintf("Usage: %s [YOUTUBE_URL] (or reply to a message with a YouTube url)", CommandYouTube))
+		return
+	}
+
+	handleYouTubeURL(conf, bot, reqQueue, videoID, message)
+}